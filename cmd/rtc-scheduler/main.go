@@ -4,18 +4,33 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"rtc-scheduler/internal/application/usecases"
 	"rtc-scheduler/internal/infrastructure/config"
+	"rtc-scheduler/internal/infrastructure/history"
+	"rtc-scheduler/internal/infrastructure/lock"
+	"rtc-scheduler/internal/infrastructure/power"
 	"rtc-scheduler/internal/infrastructure/rtc"
 	"rtc-scheduler/internal/infrastructure/scheduler"
+	"rtc-scheduler/internal/infrastructure/settings"
+	"rtc-scheduler/internal/infrastructure/state"
 	"rtc-scheduler/internal/infrastructure/systemd"
+	"rtc-scheduler/internal/infrastructure/ups"
+	"rtc-scheduler/internal/infrastructure/webhook"
+	"rtc-scheduler/internal/infrastructure/wol"
 	"rtc-scheduler/internal/presentation/cli"
+	"rtc-scheduler/internal/presentation/mqtt"
 	"rtc-scheduler/pkg/logger"
 )
 
 const (
 	configFilePath = "/etc/rtc-scheduler.json"
+	configDropIns  = "/etc/rtc-scheduler.d"
+	stateDir       = systemd.StateDir
+	lockFilePath   = systemd.RuntimeDir + "/lock"
 	version        = "1.0.11"
 )
 
@@ -25,8 +40,19 @@ var (
 )
 
 func main() {
+	// Ajustes de arranque (RTC, zona horaria, scheduler, log, unidad systemd)
+	cfg, err := initializeSettings(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(2)
+	}
+
 	// Inicializar logger
-	log := logger.New()
+	log, err := initializeLogger(os.Args[1:], cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌ Invalid logging configuration:", err)
+		os.Exit(2)
+	}
 
 	// Verificar versión
 	if len(os.Args) > 1 && (os.Args[1] == "-version" || os.Args[1] == "--version") {
@@ -43,7 +69,7 @@ func main() {
 	}
 
 	// Crear contenedor de dependencias
-	container := initializeDependencies(log)
+	container := initializeDependencies(cfg, log)
 
 	// Crear CLI
 	cliApp := cli.NewCLI(
@@ -55,6 +81,35 @@ func main() {
 		container.disableUC,
 		container.clearUC,
 		container.runServiceUC,
+		container.historyUC,
+		container.shutdownUC,
+		container.wakePeersUC,
+		container.upsMonitorUC,
+		container.snoozeUC,
+		container.skipNextUC,
+		container.vacationUC,
+		container.profilesUC,
+		container.reconfigUC,
+		container.configHistUC,
+		container.dumpUC,
+		container.validateUC,
+		container.migrateUC,
+		container.exportUC,
+		container.importUC,
+		container.oneOffsUC,
+		container.sleepUC,
+		container.markReadyUC,
+		container.commandLock,
+		mqtt.NewController(
+			container.mqttSettingsUC,
+			container.statusUC,
+			container.enableUC,
+			container.disableUC,
+			container.reconfigUC,
+			container.snoozeUC,
+			container.commandLock,
+			log,
+		),
 		log,
 	)
 
@@ -65,13 +120,108 @@ func main() {
 	}
 }
 
+// initializeSettings aplica, en orden, los valores por defecto, el archivo
+// /etc/rtc-scheduler/config.yaml, las variables de entorno y los flags
+// (-rtc-device, -timezone, -scheduler)
+func initializeSettings(args []string) (settings.Settings, error) {
+	cfg, err := settings.FromFile(settings.Defaults(), settings.DefaultPath)
+	if err != nil {
+		return cfg, err
+	}
+	cfg = settings.FromEnv(cfg)
+
+	for _, key := range settings.Keys {
+		if key.Flag == "" {
+			continue
+		}
+		if value, ok := flagValue(args, key.Flag); ok {
+			if err := cfg.Set(key.Path, value); err != nil {
+				return cfg, err
+			}
+		}
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// initializeLogger crea el logger aplicando, en orden, los valores por defecto,
+// scheduler.log_file, las variables de entorno y los flags -log-level, -log-format
+// y -log-output. Los flags se leen aquí porque el logger se necesita antes de que
+// la CLI los parsee.
+func initializeLogger(args []string, cfg settings.Settings) (logger.Logger, error) {
+	opts := logger.DefaultOptions()
+	if cfg.LogFile != "" {
+		opts.Output = cfg.LogFile
+	}
+
+	opts, err := logger.OptionsFromEnv(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if value, ok := flagValue(args, "log-level"); ok {
+		if opts.Level, err = logger.ParseLevel(value); err != nil {
+			return nil, err
+		}
+	}
+
+	if value, ok := flagValue(args, "log-format"); ok {
+		if opts.Format, err = logger.ParseFormat(value); err != nil {
+			return nil, err
+		}
+	}
+
+	if value, ok := flagValue(args, "log-output"); ok {
+		opts.Output = value
+	}
+
+	return logger.NewWithOptions(opts)
+}
+
+// logFilePath retorna el archivo de log del servicio, o vacío si el log va a
+// stdout, stderr o journald. El servicio corre sin flags ni variables de log.
+func logFilePath(cfg settings.Settings) string {
+	if filepath.IsAbs(cfg.LogFile) {
+		return cfg.LogFile
+	}
+	return ""
+}
+
+// flagValue busca el valor de un flag en los argumentos ("-name value", "-name=value" o con "--")
+func flagValue(args []string, name string) (string, bool) {
+	for i, arg := range args {
+		trimmed := strings.TrimLeft(arg, "-")
+		if trimmed == arg {
+			continue
+		}
+
+		if trimmed == name && i+1 < len(args) {
+			return args[i+1], true
+		}
+		if strings.HasPrefix(trimmed, name+"=") {
+			return strings.TrimPrefix(trimmed, name+"="), true
+		}
+	}
+	return "", false
+}
+
 // DependencyContainer contiene todas las dependencias de la aplicación
 type DependencyContainer struct {
 	// Repositories
-	rtcRepo       *rtc.LinuxRTC
+	rtcRepo       *rtc.TrackingRTC
 	configRepo    *config.JSONConfigRepository
 	serviceRepo   *systemd.SystemdService
 	schedulerRepo *scheduler.HybridScheduler
+	eventRepo     *history.NotifyingEventRepository
+	powerRepo     *power.LinuxPowerState
+	wakeStateRepo *state.ArmedWakeStore
+	actionRepo    *power.SystemctlPower
+	wolRepo       *wol.UDPSender
+	upsRepo       *ups.NUTClient
+	snoozeRepo    *state.SnoozeStore
+	overrideRepo  *state.OverrideStore
+	lockRepo      *lock.FileLock
+	unitRepo      *systemd.UnitState
 
 	// Use Cases
 	installUC    *usecases.InstallServiceUseCase
@@ -82,19 +232,78 @@ type DependencyContainer struct {
 	disableUC    *usecases.DisableServiceUseCase
 	clearUC      *usecases.ClearAlarmUseCase
 	runServiceUC *usecases.RunServiceUseCase
+	historyUC    *usecases.ShowHistoryUseCase
+	shutdownUC   *usecases.ExecuteShutdownUseCase
+	wakePeersUC  *usecases.WakePeersUseCase
+	upsMonitorUC *usecases.MonitorUPSUseCase
+	snoozeUC     *usecases.SnoozeShutdownUseCase
+	skipNextUC   *usecases.SkipNextUseCase
+	vacationUC   *usecases.SetVacationUseCase
+	profilesUC   *usecases.ManageProfilesUseCase
+	reconfigUC   *usecases.ReconfigureScheduleUseCase
+	configHistUC *usecases.ConfigHistoryUseCase
+	dumpUC       *usecases.DumpConfigUseCase
+	validateUC   *usecases.ValidateConfigUseCase
+	migrateUC    *usecases.MigrateConfigUseCase
+	exportUC     *usecases.ExportStateUseCase
+	importUC     *usecases.ImportStateUseCase
+	oneOffsUC    *usecases.ManageOneOffsUseCase
+	sleepUC      *usecases.SleepNowUseCase
+	markReadyUC  *usecases.MarkWakeReadyUseCase
+	commandLock  *usecases.CommandLock
+
+	mqttSettingsUC *usecases.LoadMQTTSettingsUseCase
 }
 
 // initializeDependencies inicializa todas las dependencias (Dependency Injection)
-func initializeDependencies(log logger.Logger) *DependencyContainer {
+func initializeDependencies(cfg settings.Settings, log logger.Logger) *DependencyContainer {
+	// Los horarios HH:MM se interpretan en rtc.timezone (ya validada)
+	if loc, err := cfg.Location(); err == nil {
+		time.Local = loc
+	}
+
 	// Inicializar repositorios (Infrastructure Layer)
-	rtcRepo := rtc.NewLinuxRTC()
+	wakeStateRepo := state.NewArmedWakeStore(filepath.Join(stateDir, "armed_wake.json"))
+	rtcRepo := rtc.NewTrackingRTC(rtc.NewLinuxRTCForDevice(cfg.RTCDevice), wakeStateRepo)
 	configRepo := config.NewJSONConfigRepository(configFilePath)
-	serviceRepo := systemd.NewSystemdService()
+	configRepo.SetHistoryDir(filepath.Join(stateDir, "config-history"))
+	configRepo.SetDropInDir(configDropIns)
+	configRepo.SetSeed(cfg.SeedPath, cfg.SeedAfterImport == settings.SeedRemove)
+	serviceRepo := systemd.NewSystemdServiceWithUnit(systemd.Unit{
+		Name:        cfg.Unit(),
+		Description: cfg.ServiceDescription,
+		User:        cfg.ServiceUser,
+		RTCPath:     rtc.SysfsPath(cfg.RTCDevice),
+		ConfigPath:  configFilePath,
+		SeedPath:    cfg.SeedPath,
+		LogPath:     logFilePath(cfg),
+	})
 	schedulerRepo := scheduler.NewHybridScheduler()
+	if err := schedulerRepo.SetBackend(cfg.Scheduler); err != nil {
+		log.Warn("Ignoring scheduler.type", "error", err)
+	}
+	notifierRepo := webhook.NewWebhookNotifier(configRepo, filepath.Join(stateDir, "webhook-queue.jsonl"))
+	eventRepo := history.NewNotifyingEventRepository(
+		history.NewJSONLEventRepository(filepath.Join(stateDir, "events.jsonl")),
+		notifierRepo,
+	)
+	powerRepo := power.NewLinuxPowerState()
+	actionRepo := power.NewSystemctlPower()
+	wolRepo := wol.NewUDPSender()
+	upsRepo := ups.NewNUTClient()
+	snoozeRepo := state.NewSnoozeStore(filepath.Join(stateDir, "snooze.json"))
+	overrideRepo := state.NewOverrideStore(filepath.Join(stateDir, "overrides.json"))
+	lockRepo := lock.NewFileLock(lockFilePath)
+	unitRepo := systemd.NewUnitState()
+
+	// Los trabajos de apagado invocan este binario para notificar el apagado
+	if execPath, err := os.Executable(); err == nil {
+		schedulerRepo.SetExecutable(execPath)
+	}
 
 	// Verificar que componentes críticos estén disponibles
 	if !rtcRepo.IsAvailable() {
-		log.Warn("RTC device not available at /sys/class/rtc/rtc0")
+		log.Warn("RTC device not available", "device", cfg.RTCDevice, "path", rtc.SysfsPath(cfg.RTCDevice))
 		log.Warn("The program will continue but power scheduling will not work")
 	}
 
@@ -117,12 +326,16 @@ func initializeDependencies(log logger.Logger) *DependencyContainer {
 		configRepo,
 		serviceRepo,
 		schedulerRepo,
+		eventRepo,
 		log,
 	)
 
 	scheduleUC := usecases.NewSchedulePowerUseCase(
+		configRepo,
 		rtcRepo,
 		schedulerRepo,
+		overrideRepo,
+		eventRepo,
 		log,
 	)
 
@@ -131,6 +344,9 @@ func initializeDependencies(log logger.Logger) *DependencyContainer {
 		configRepo,
 		serviceRepo,
 		schedulerRepo,
+		eventRepo,
+		snoozeRepo,
+		overrideRepo,
 		log,
 	)
 
@@ -145,12 +361,14 @@ func initializeDependencies(log logger.Logger) *DependencyContainer {
 		serviceRepo,
 		schedulerRepo,
 		rtcRepo,
+		eventRepo,
 		log,
 	)
 
 	clearUC := usecases.NewClearAlarmUseCase(
 		rtcRepo,
 		schedulerRepo,
+		eventRepo,
 		log,
 	)
 
@@ -158,6 +376,169 @@ func initializeDependencies(log logger.Logger) *DependencyContainer {
 		configRepo,
 		rtcRepo,
 		schedulerRepo,
+		eventRepo,
+		powerRepo,
+		wakeStateRepo,
+		wolRepo,
+		upsRepo,
+		overrideRepo,
+		configRepo,
+		notifierRepo,
+		log,
+	)
+
+	historyUC := usecases.NewShowHistoryUseCase(
+		eventRepo,
+		log,
+	)
+
+	shutdownUC := usecases.NewExecuteShutdownUseCase(
+		configRepo,
+		actionRepo,
+		eventRepo,
+		log,
+	)
+
+	wakePeersUC := usecases.NewWakePeersUseCase(
+		configRepo,
+		wolRepo,
+		eventRepo,
+		log,
+	)
+
+	upsMonitorUC := usecases.NewMonitorUPSUseCase(
+		configRepo,
+		upsRepo,
+		rtcRepo,
+		schedulerRepo,
+		eventRepo,
+		lockRepo,
+		log,
+	)
+
+	snoozeUC := usecases.NewSnoozeShutdownUseCase(
+		configRepo,
+		rtcRepo,
+		schedulerRepo,
+		snoozeRepo,
+		eventRepo,
+		log,
+	)
+
+	skipNextUC := usecases.NewSkipNextUseCase(
+		configRepo,
+		rtcRepo,
+		schedulerRepo,
+		overrideRepo,
+		eventRepo,
+		log,
+	)
+
+	vacationUC := usecases.NewSetVacationUseCase(
+		configRepo,
+		rtcRepo,
+		schedulerRepo,
+		overrideRepo,
+		eventRepo,
+		log,
+	)
+
+	profilesUC := usecases.NewManageProfilesUseCase(
+		configRepo,
+		configRepo,
+		rtcRepo,
+		schedulerRepo,
+		overrideRepo,
+		eventRepo,
+		log,
+	)
+
+	reconfigUC := usecases.NewReconfigureScheduleUseCase(
+		configRepo,
+		configRepo,
+		rtcRepo,
+		schedulerRepo,
+		overrideRepo,
+		eventRepo,
+		log,
+	)
+
+	configHistUC := usecases.NewConfigHistoryUseCase(
+		configRepo,
+		configRepo,
+		rtcRepo,
+		schedulerRepo,
+		overrideRepo,
+		eventRepo,
+		log,
+	)
+
+	dumpUC := usecases.NewDumpConfigUseCase(
+		configRepo,
+		log,
+	)
+
+	validateUC := usecases.NewValidateConfigUseCase(
+		configRepo,
+		rtcRepo,
+		schedulerRepo,
+		actionRepo,
+		log,
+	)
+
+	migrateUC := usecases.NewMigrateConfigUseCase(
+		configRepo,
+		log,
+	)
+
+	exportUC := usecases.NewExportStateUseCase(
+		configRepo,
+		overrideRepo,
+		configRepo,
+		log,
+	)
+
+	importUC := usecases.NewImportStateUseCase(
+		configRepo,
+		overrideRepo,
+		configRepo,
+		rtcRepo,
+		schedulerRepo,
+		eventRepo,
+		log,
+	)
+
+	oneOffsUC := usecases.NewManageOneOffsUseCase(
+		configRepo,
+		rtcRepo,
+		schedulerRepo,
+		overrideRepo,
+		eventRepo,
+		log,
+	)
+
+	sleepUC := usecases.NewSleepNowUseCase(
+		rtcRepo,
+		schedulerRepo,
+		actionRepo,
+		eventRepo,
+		log,
+	)
+
+	markReadyUC := usecases.NewMarkWakeReadyUseCase(
+		configRepo,
+		eventRepo,
+		unitRepo,
+		log,
+	)
+
+	commandLock := usecases.NewCommandLock(
+		lockRepo,
+		log,
+	)
+
+	mqttSettingsUC := usecases.NewLoadMQTTSettingsUseCase(
+		configRepo,
 		log,
 	)
 
@@ -166,6 +547,16 @@ func initializeDependencies(log logger.Logger) *DependencyContainer {
 		configRepo:    configRepo,
 		serviceRepo:   serviceRepo,
 		schedulerRepo: schedulerRepo,
+		eventRepo:     eventRepo,
+		powerRepo:     powerRepo,
+		wakeStateRepo: wakeStateRepo,
+		actionRepo:    actionRepo,
+		wolRepo:       wolRepo,
+		upsRepo:       upsRepo,
+		snoozeRepo:    snoozeRepo,
+		overrideRepo:  overrideRepo,
+		lockRepo:      lockRepo,
+		unitRepo:      unitRepo,
 		installUC:     installUC,
 		uninstallUC:   uninstallUC,
 		scheduleUC:    scheduleUC,
@@ -174,5 +565,26 @@ func initializeDependencies(log logger.Logger) *DependencyContainer {
 		disableUC:     disableUC,
 		clearUC:       clearUC,
 		runServiceUC:  runServiceUC,
+		historyUC:     historyUC,
+		shutdownUC:    shutdownUC,
+		wakePeersUC:   wakePeersUC,
+		upsMonitorUC:  upsMonitorUC,
+		snoozeUC:      snoozeUC,
+		skipNextUC:    skipNextUC,
+		vacationUC:    vacationUC,
+		profilesUC:    profilesUC,
+		reconfigUC:    reconfigUC,
+		configHistUC:  configHistUC,
+		dumpUC:        dumpUC,
+		validateUC:    validateUC,
+		migrateUC:     migrateUC,
+		exportUC:      exportUC,
+		importUC:      importUC,
+		oneOffsUC:     oneOffsUC,
+		sleepUC:       sleepUC,
+		markReadyUC:   markReadyUC,
+		commandLock:   commandLock,
+
+		mqttSettingsUC: mqttSettingsUC,
 	}
 }
\ No newline at end of file
@@ -2,11 +2,77 @@
 
 package dto
 
-import "time"
+import (
+	"fmt"
+	"sort"
+	"time"
 
+	"rtc-scheduler/internal/domain/entities"
+)
+
+// Acciones de una entrada de la cola de eventos únicos
+const (
+	ScheduleActionWake     = "wake"
+	ScheduleActionShutdown = "shutdown"
+)
+
+// ScheduleDTO es una entrada de la cola de eventos únicos. Cada ventana única
+// se guarda como dos entradas con el mismo ID: su despertar y su apagado.
 type ScheduleDTO struct {
-	ID      string
-	Time    time.Time
-	Action  string
-	Enabled bool
+	ID      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	Enabled bool      `json:"enabled"`
+	By      string    `json:"by,omitempty"`
+}
+
+// FromOneOffs convierte la cola de ventanas únicas en entradas
+func FromOneOffs(oneOffs []*entities.OneOff) []ScheduleDTO {
+	var queue []ScheduleDTO
+	for _, oneOff := range oneOffs {
+		queue = append(queue,
+			ScheduleDTO{ID: oneOff.ID, Time: oneOff.WakeAt, Action: ScheduleActionWake, Enabled: true, By: oneOff.By},
+			ScheduleDTO{ID: oneOff.ID, Time: oneOff.ShutdownAt, Action: ScheduleActionShutdown, Enabled: true, By: oneOff.By},
+		)
+	}
+	return queue
+}
+
+// ToOneOffs arma las ventanas únicas a partir de las entradas. Las entradas
+// deshabilitadas (Enabled false) se ignoran; una ventana sin su despertar o
+// su apagado es un error.
+func ToOneOffs(queue []ScheduleDTO) ([]*entities.OneOff, error) {
+	byID := make(map[string]*entities.OneOff)
+	var ids []string
+	for _, entry := range queue {
+		if !entry.Enabled {
+			continue
+		}
+		oneOff, ok := byID[entry.ID]
+		if !ok {
+			oneOff = &entities.OneOff{ID: entry.ID, By: entry.By}
+			byID[entry.ID] = oneOff
+			ids = append(ids, entry.ID)
+		}
+
+		switch entry.Action {
+		case ScheduleActionWake:
+			oneOff.WakeAt = entry.Time.Local()
+		case ScheduleActionShutdown:
+			oneOff.ShutdownAt = entry.Time.Local()
+		default:
+			return nil, fmt.Errorf("one-off %s: unknown action %q (use wake or shutdown)", entry.ID, entry.Action)
+		}
+	}
+
+	oneOffs := make([]*entities.OneOff, 0, len(ids))
+	for _, id := range ids {
+		oneOff := byID[id]
+		if oneOff.WakeAt.IsZero() || oneOff.ShutdownAt.IsZero() {
+			return nil, fmt.Errorf("one-off %s needs both a wake and a shutdown entry", id)
+		}
+		oneOffs = append(oneOffs, oneOff)
+	}
+	sort.SliceStable(oneOffs, func(i, j int) bool { return oneOffs[i].WakeAt.Before(oneOffs[j].WakeAt) })
+	return oneOffs, nil
 }
\ No newline at end of file
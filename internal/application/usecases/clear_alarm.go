@@ -2,6 +2,7 @@
 package usecases
 
 import (
+	"rtc-scheduler/internal/domain/entities"
 	"rtc-scheduler/internal/domain/repositories"
 	"rtc-scheduler/pkg/logger"
 )
@@ -17,17 +18,20 @@ type ClearAlarmOutput struct {
 type ClearAlarmUseCase struct {
 	rtcRepo       repositories.RTCRepository
 	schedulerRepo repositories.SchedulerRepository
+	eventRepo     repositories.EventRepository
 	logger        logger.Logger
 }
 
 func NewClearAlarmUseCase(
 	rtc repositories.RTCRepository,
 	scheduler repositories.SchedulerRepository,
+	events repositories.EventRepository,
 	log logger.Logger,
 ) *ClearAlarmUseCase {
 	return &ClearAlarmUseCase{
 		rtcRepo:       rtc,
 		schedulerRepo: scheduler,
+		eventRepo:     events,
 		logger:        log,
 	}
 }
@@ -40,10 +44,13 @@ func (uc *ClearAlarmUseCase) Execute(input *ClearAlarmInput) (*ClearAlarmOutput,
 		uc.logger.Error("Failed to clear RTC wake alarm", "error", err)
 		return nil, err
 	}
+	recordEvent(uc.eventRepo, uc.logger, entities.EventAlarmCleared, "RTC wake alarm cleared", "source", "clear")
 
 	// Cancelar tareas de apagado programadas
 	if err := uc.schedulerRepo.CancelShutdown(); err != nil {
 		uc.logger.Warn("Failed to cancel scheduled shutdowns", "error", err)
+	} else {
+		recordEvent(uc.eventRepo, uc.logger, entities.EventShutdownCancelled, "Scheduled shutdowns cancelled", "source", "clear")
 	}
 
 	uc.logger.Info("Wake alarm cleared successfully")
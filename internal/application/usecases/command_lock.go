@@ -0,0 +1,64 @@
+// internal/application/usecases/command_lock.go
+package usecases
+
+import (
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+// DefaultLockTimeout es cuánto espera un comando a que termine otro
+const DefaultLockTimeout = 30 * time.Second
+
+// CommandLock serializa los comandos que modifican la configuración, la alarma
+// RTC o los apagados programados, vengan de la CLI, de MQTT o del servicio.
+// Sin LockRepository no bloquea nada.
+type CommandLock struct {
+	lockRepo repositories.LockRepository
+	timeout  time.Duration
+	logger   logger.Logger
+}
+
+func NewCommandLock(lock repositories.LockRepository, log logger.Logger) *CommandLock {
+	return &CommandLock{
+		lockRepo: lock,
+		timeout:  DefaultLockTimeout,
+		logger:   log,
+	}
+}
+
+// Run ejecuta fn con el lock tomado; falla si otro comando lo retiene más de timeout
+func (l *CommandLock) Run(command, by string, fn func() error) error {
+	if l == nil {
+		return fn()
+	}
+
+	release, err := acquireLock(l.lockRepo, l.timeout, l.logger, command, by)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return fn()
+}
+
+// acquireLock toma el lock de comandos para command; con lockRepo nil no hace nada
+func acquireLock(lockRepo repositories.LockRepository, timeout time.Duration, log logger.Logger, command, by string) (func(), error) {
+	if lockRepo == nil {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	release, err := lockRepo.Acquire(&entities.LockHolder{Command: command, User: by}, timeout)
+	if err != nil {
+		log.Error("Failed to acquire command lock", "command", command, "error", err)
+		return nil, err
+	}
+
+	if waited := time.Since(start); waited > time.Second {
+		log.Info("Command lock acquired after waiting", "command", command, "waited", waited.Round(time.Millisecond))
+	}
+	return release, nil
+}
\ No newline at end of file
@@ -0,0 +1,182 @@
+// internal/application/usecases/config_history.go
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+	"rtc-scheduler/pkg/textdiff"
+)
+
+// Acciones aceptadas por ConfigHistoryUseCase
+const (
+	ConfigHistoryList     = "list"
+	ConfigHistoryDiff     = "diff"
+	ConfigHistoryRollback = "rollback"
+)
+
+// configDiffContext son las líneas sin cambios mostradas alrededor de cada cambio
+const configDiffContext = 3
+
+var ErrInvalidConfigVersion = errors.New("configuration version must be a number")
+
+type ConfigHistoryInput struct {
+	Action string
+	// From y To son versiones para diff (To vacío = la más reciente)
+	From string
+	To   string
+	// Version es la versión a restaurar con rollback
+	Version string
+	By      string
+}
+
+type ConfigHistoryOutput struct {
+	Versions []*entities.ConfigVersion
+	Diff     string
+	Message  string
+}
+
+// ConfigHistoryUseCase lista, compara y restaura versiones anteriores de la
+// configuración. Restaurar una versión guarda una nueva (el rollback también
+// queda en el historial) y vuelve a armar la alarma RTC.
+type ConfigHistoryUseCase struct {
+	configRepo    repositories.ConfigRepository
+	historyRepo   repositories.ConfigHistoryRepository
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	overrideRepo  repositories.OverrideRepository
+	eventRepo     repositories.EventRepository
+	logger        logger.Logger
+}
+
+func NewConfigHistoryUseCase(
+	config repositories.ConfigRepository,
+	history repositories.ConfigHistoryRepository,
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	overrides repositories.OverrideRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *ConfigHistoryUseCase {
+	return &ConfigHistoryUseCase{
+		configRepo:    config,
+		historyRepo:   history,
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		overrideRepo:  overrides,
+		eventRepo:     events,
+		logger:        log,
+	}
+}
+
+func (uc *ConfigHistoryUseCase) Execute(input *ConfigHistoryInput) (*ConfigHistoryOutput, error) {
+	switch input.Action {
+	case ConfigHistoryList:
+		versions, err := uc.historyRepo.ListVersions()
+		if err != nil {
+			return nil, err
+		}
+		return &ConfigHistoryOutput{
+			Versions: versions,
+			Message:  fmt.Sprintf("%d version(s) kept", len(versions)),
+		}, nil
+
+	case ConfigHistoryDiff:
+		return uc.diff(input)
+
+	case ConfigHistoryRollback:
+		return uc.rollback(input)
+
+	default:
+		return nil, fmt.Errorf("unknown config history action %q", input.Action)
+	}
+}
+
+// diff compara dos versiones; sin To, compara con la más reciente
+func (uc *ConfigHistoryUseCase) diff(input *ConfigHistoryInput) (*ConfigHistoryOutput, error) {
+	from, err := uc.loadVersion(input.From)
+	if err != nil {
+		return nil, err
+	}
+
+	var to *entities.ConfigVersion
+	if input.To == "" {
+		versions, err := uc.historyRepo.ListVersions()
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			return nil, fmt.Errorf("%w: history is empty", entities.ErrUnknownConfigVersion)
+		}
+		to = versions[len(versions)-1]
+	} else if to, err = uc.loadVersion(input.To); err != nil {
+		return nil, err
+	}
+
+	lines := textdiff.Lines(from.Content, to.Content)
+	output := &ConfigHistoryOutput{
+		Versions: []*entities.ConfigVersion{from, to},
+		Message:  fmt.Sprintf("Version %d → %d", from.ID, to.ID),
+	}
+	if textdiff.Changed(lines) {
+		output.Diff = textdiff.Unified(lines, configDiffContext)
+	} else {
+		output.Message += ": no differences"
+	}
+	return output, nil
+}
+
+// rollback restaura una versión guardada y vuelve a armar el próximo ciclo
+func (uc *ConfigHistoryUseCase) rollback(input *ConfigHistoryInput) (*ConfigHistoryOutput, error) {
+	version, err := uc.loadVersion(input.Version)
+	if err != nil {
+		return nil, err
+	}
+	if version.Config == nil {
+		return nil, fmt.Errorf("version %d cannot be read", version.ID)
+	}
+
+	config := version.Config
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("version %d is invalid: %w", version.ID, err)
+	}
+	config.Update()
+
+	if err := uc.configRepo.Save(config); err != nil {
+		uc.logger.Error("Failed to save configuration", "error", err)
+		return nil, err
+	}
+
+	uc.logger.Info("Configuration rolled back", "version", version.ID, "by", input.By)
+	recordEvent(uc.eventRepo, uc.logger, entities.EventConfigChanged, "Configuration rolled back",
+		"action", "rollback", "version", strconv.Itoa(version.ID),
+		"wake_time", config.WakeTime, "shutdown_time", config.ShutdownTime, "by", input.By)
+
+	message := fmt.Sprintf("Configuration restored from version %d (%s)", version.ID, version.Summary())
+	if config.Enabled {
+		armer := newScheduleArmer(uc.rtcRepo, uc.schedulerRepo, uc.overrideRepo, uc.eventRepo)
+		armed, err := armer.arm(uc.logger, config, "rollback", true)
+		if err != nil {
+			return nil, fmt.Errorf("configuration restored but re-arming failed: %w", err)
+		}
+		message += ", next wake " + armed.describeWake()
+	}
+
+	return &ConfigHistoryOutput{
+		Versions: []*entities.ConfigVersion{version},
+		Message:  message,
+	}, nil
+}
+
+// loadVersion interpreta el número de versión y la carga
+func (uc *ConfigHistoryUseCase) loadVersion(value string) (*entities.ConfigVersion, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidConfigVersion, value)
+	}
+	return uc.historyRepo.LoadVersion(id)
+}
\ No newline at end of file
@@ -2,6 +2,7 @@
 package usecases
 
 import (
+	"rtc-scheduler/internal/domain/entities"
 	"rtc-scheduler/internal/domain/repositories"
 	"rtc-scheduler/pkg/logger"
 )
@@ -19,6 +20,7 @@ type DisableServiceUseCase struct {
 	serviceRepo   repositories.ServiceRepository
 	schedulerRepo repositories.SchedulerRepository
 	rtcRepo       repositories.RTCRepository
+	eventRepo     repositories.EventRepository
 	logger        logger.Logger
 }
 
@@ -27,6 +29,7 @@ func NewDisableServiceUseCase(
 	service repositories.ServiceRepository,
 	scheduler repositories.SchedulerRepository,
 	rtc repositories.RTCRepository,
+	events repositories.EventRepository,
 	log logger.Logger,
 ) *DisableServiceUseCase {
 	return &DisableServiceUseCase{
@@ -34,6 +37,7 @@ func NewDisableServiceUseCase(
 		serviceRepo:   service,
 		schedulerRepo: scheduler,
 		rtcRepo:       rtc,
+		eventRepo:     events,
 		logger:        log,
 	}
 }
@@ -55,11 +59,15 @@ func (uc *DisableServiceUseCase) Execute(input *DisableServiceInput) (*DisableSe
 	// Limpiar alarmas RTC
 	if err := uc.rtcRepo.ClearWakeAlarm(); err != nil {
 		uc.logger.Warn("Failed to clear RTC wake alarm", "error", err)
+	} else {
+		recordEvent(uc.eventRepo, uc.logger, entities.EventAlarmCleared, "RTC wake alarm cleared", "source", "disable")
 	}
 
 	// Cancelar tareas programadas
 	if err := uc.schedulerRepo.CancelShutdown(); err != nil {
 		uc.logger.Warn("Failed to cancel scheduled shutdowns", "error", err)
+	} else {
+		recordEvent(uc.eventRepo, uc.logger, entities.EventShutdownCancelled, "Scheduled shutdowns cancelled", "source", "disable")
 	}
 
 	// Actualizar configuración
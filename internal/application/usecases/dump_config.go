@@ -0,0 +1,40 @@
+// internal/application/usecases/dump_config.go
+package usecases
+
+import (
+	"fmt"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type DumpConfigInput struct{}
+
+type DumpConfigOutput struct {
+	Effective *entities.EffectiveConfig
+}
+
+// DumpConfigUseCase muestra la configuración efectiva (archivo principal más
+// fragmentos de /etc/rtc-scheduler.d) y de qué archivo sale cada valor
+type DumpConfigUseCase struct {
+	sourceRepo repositories.ConfigSourceRepository
+	logger     logger.Logger
+}
+
+func NewDumpConfigUseCase(sources repositories.ConfigSourceRepository, log logger.Logger) *DumpConfigUseCase {
+	return &DumpConfigUseCase{
+		sourceRepo: sources,
+		logger:     log,
+	}
+}
+
+func (uc *DumpConfigUseCase) Execute(input *DumpConfigInput) (*DumpConfigOutput, error) {
+	effective, err := uc.sourceRepo.LoadEffective()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	uc.logger.Debug("Effective configuration loaded", "files", len(effective.Files), "values", len(effective.Values))
+	return &DumpConfigOutput{Effective: effective}, nil
+}
\ No newline at end of file
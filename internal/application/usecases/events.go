@@ -0,0 +1,62 @@
+// internal/application/usecases/events.go
+package usecases
+
+import (
+	"fmt"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+// recordEvent agrega un evento al historial. Un fallo al registrar nunca
+// interrumpe el caso de uso: solo se reporta como advertencia.
+// Los argumentos adicionales son pares clave/valor, igual que en el logger.
+func recordEvent(
+	events repositories.EventRepository,
+	log logger.Logger,
+	eventType entities.EventType,
+	message string,
+	args ...interface{},
+) {
+	recordEventWithLevel(events, log, eventType, entities.EventLevelInfo, message, args...)
+}
+
+// recordEventWithLevel agrega un evento con una severidad específica
+func recordEventWithLevel(
+	events repositories.EventRepository,
+	log logger.Logger,
+	eventType entities.EventType,
+	level entities.EventLevel,
+	message string,
+	args ...interface{},
+) {
+	if events == nil {
+		return
+	}
+
+	event := entities.NewPowerEvent(eventType, message, eventFields(args...))
+	event.Level = level
+
+	appendEvent(events, log, event)
+}
+
+// appendEvent agrega un evento ya construido (p.ej. con un timestamp distinto de ahora)
+func appendEvent(events repositories.EventRepository, log logger.Logger, event *entities.PowerEvent) {
+	if events == nil {
+		return
+	}
+
+	if err := events.Append(event); err != nil {
+		log.Warn("Failed to record or publish event", "event", event.Type, "error", err)
+	}
+}
+
+// eventFields convierte pares clave/valor en el mapa de campos del evento
+func eventFields(args ...interface{}) map[string]string {
+	fields := make(map[string]string)
+	for i := 0; i+1 < len(args); i += 2 {
+		fields[fmt.Sprint(args[i])] = fmt.Sprint(args[i+1])
+	}
+	return fields
+}
\ No newline at end of file
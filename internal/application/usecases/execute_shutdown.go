@@ -0,0 +1,86 @@
+// internal/application/usecases/execute_shutdown.go
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type ExecuteShutdownInput struct {
+	Action string
+}
+
+type ExecuteShutdownOutput struct {
+	Action  entities.PowerAction
+	Message string
+}
+
+// ExecuteShutdownUseCase se ejecuta desde el trabajo programado a la hora de
+// apagado: avisa que el apagado es inminente, espera el período de gracia
+// configurado y ejecuta la acción de energía
+type ExecuteShutdownUseCase struct {
+	configRepo repositories.ConfigRepository
+	powerRepo  repositories.PowerRepository
+	eventRepo  repositories.EventRepository
+	logger     logger.Logger
+}
+
+func NewExecuteShutdownUseCase(
+	config repositories.ConfigRepository,
+	power repositories.PowerRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *ExecuteShutdownUseCase {
+	return &ExecuteShutdownUseCase{
+		configRepo: config,
+		powerRepo:  power,
+		eventRepo:  events,
+		logger:     log,
+	}
+}
+
+func (uc *ExecuteShutdownUseCase) Execute(input *ExecuteShutdownInput) (*ExecuteShutdownOutput, error) {
+	action, err := entities.ParsePowerAction(input.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("Executing scheduled shutdown", "action", action)
+
+	// Sin configuración se ejecuta la acción sin período de gracia
+	grace := time.Duration(0)
+	if uc.configRepo.Exists() {
+		if config, err := uc.configRepo.Load(); err == nil {
+			grace = config.ShutdownGrace()
+		} else {
+			uc.logger.Warn("Failed to load configuration, using no grace period", "error", err)
+		}
+	}
+
+	recordEvent(uc.eventRepo, uc.logger, entities.EventShutdownImminent, fmt.Sprintf("System %s imminent", action),
+		"action", action, "grace_seconds", int(grace.Seconds()))
+
+	if grace > 0 {
+		uc.logger.Info("Waiting shutdown grace period", "grace", grace)
+		time.Sleep(grace)
+	}
+
+	recordEvent(uc.eventRepo, uc.logger, entities.EventShutdownExecuted, fmt.Sprintf("Executing system %s", action),
+		"action", action)
+
+	if err := uc.powerRepo.Execute(action); err != nil {
+		uc.logger.Error("Failed to execute power action", "action", action, "error", err)
+		recordEventWithLevel(uc.eventRepo, uc.logger, entities.EventError, entities.EventLevelError,
+			fmt.Sprintf("Failed to execute system %s", action), "action", action, "error", err)
+		return nil, err
+	}
+
+	return &ExecuteShutdownOutput{
+		Action:  action,
+		Message: fmt.Sprintf("System %s executed", action),
+	}, nil
+}
\ No newline at end of file
@@ -0,0 +1,75 @@
+// internal/application/usecases/export_state.go
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type ExportStateInput struct {
+	// Path es el archivo de destino (vacío o "-" = salida estándar)
+	Path string
+}
+
+type ExportStateOutput struct {
+	Bundle  *entities.StateBundle
+	Message string
+}
+
+// ExportStateUseCase guarda en un archivo el estado completo del equipo
+// (configuración efectiva y excepciones vigentes) para importarlo en otro
+// equipo o usarlo como semilla de primer arranque
+type ExportStateUseCase struct {
+	configRepo   repositories.ConfigRepository
+	overrideRepo repositories.OverrideRepository
+	bundleRepo   repositories.StateBundleRepository
+	logger       logger.Logger
+}
+
+func NewExportStateUseCase(
+	config repositories.ConfigRepository,
+	overrides repositories.OverrideRepository,
+	bundles repositories.StateBundleRepository,
+	log logger.Logger,
+) *ExportStateUseCase {
+	return &ExportStateUseCase{
+		configRepo:   config,
+		overrideRepo: overrides,
+		bundleRepo:   bundles,
+		logger:       log,
+	}
+}
+
+func (uc *ExportStateUseCase) Execute(input *ExportStateInput) (*ExportStateOutput, error) {
+	config, err := uc.configRepo.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	overrides, err := uc.overrideRepo.LoadOverrides()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schedule overrides: %w", err)
+	}
+	// Las excepciones vencidas no se exportan; el archivo de estado no se toca
+	now := time.Now()
+	overrides.Prune(now)
+
+	bundle := &entities.StateBundle{
+		Config:     config,
+		Overrides:  overrides,
+		ExportedAt: now,
+	}
+	if err := uc.bundleRepo.WriteBundle(input.Path, bundle); err != nil {
+		return nil, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	uc.logger.Debug("State exported", "path", input.Path, "profiles", len(config.Profiles), "webhooks", len(config.Webhooks))
+	return &ExportStateOutput{
+		Bundle:  bundle,
+		Message: fmt.Sprintf("State exported to %s", input.Path),
+	}, nil
+}
\ No newline at end of file
@@ -0,0 +1,143 @@
+// internal/application/usecases/import_state.go
+package usecases
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type ImportStateInput struct {
+	// Path es un archivo de -export o una configuración suelta ("-" = entrada estándar)
+	Path string
+	By   string
+}
+
+type ImportStateOutput struct {
+	// Problems no vacío significa que no se instaló nada
+	Problems []entities.ConfigProblem
+	Bundle   *entities.StateBundle
+	Message  string
+}
+
+// ImportStateUseCase valida e instala un estado exportado: reemplaza la
+// configuración (queda en el historial de versiones) y las excepciones, y
+// vuelve a armar el próximo ciclo
+type ImportStateUseCase struct {
+	configRepo    repositories.ConfigRepository
+	overrideRepo  repositories.OverrideRepository
+	bundleRepo    repositories.StateBundleRepository
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	eventRepo     repositories.EventRepository
+	logger        logger.Logger
+}
+
+func NewImportStateUseCase(
+	config repositories.ConfigRepository,
+	overrides repositories.OverrideRepository,
+	bundles repositories.StateBundleRepository,
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *ImportStateUseCase {
+	return &ImportStateUseCase{
+		configRepo:    config,
+		overrideRepo:  overrides,
+		bundleRepo:    bundles,
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		eventRepo:     events,
+		logger:        log,
+	}
+}
+
+func (uc *ImportStateUseCase) Execute(input *ImportStateInput) (*ImportStateOutput, error) {
+	bundle, problems, err := readStateBundle(uc.bundleRepo, input.Path, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", input.Path, err)
+	}
+	if len(problems) > 0 {
+		return &ImportStateOutput{Problems: problems}, nil
+	}
+
+	if err := installStateBundle(uc.configRepo, uc.overrideRepo, uc.eventRepo, uc.logger, bundle, "import", input.By); err != nil {
+		return nil, err
+	}
+
+	config := bundle.Config
+	message := fmt.Sprintf("State imported from %s (%s-%s", input.Path, config.WakeTime, config.ShutdownTime)
+	if config.ActiveProfile != "" {
+		message += ", profile " + config.ActiveProfile
+	}
+	message += ")"
+
+	if config.Enabled {
+		armer := newScheduleArmer(uc.rtcRepo, uc.schedulerRepo, uc.overrideRepo, uc.eventRepo)
+		armed, err := armer.arm(uc.logger, config, "import", true)
+		if err != nil {
+			return nil, fmt.Errorf("state imported but re-arming failed: %w", err)
+		}
+		message += ", next wake " + armed.describeWake()
+	}
+
+	return &ImportStateOutput{Bundle: bundle, Message: message}, nil
+}
+
+// readStateBundle lee un estado exportado y reúne todos sus problemas: los de
+// formato y los de Config.Check
+func readStateBundle(bundles repositories.StateBundleRepository, path string, now time.Time) (*entities.StateBundle, []entities.ConfigProblem, error) {
+	bundle, problems, err := bundles.ReadBundle(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bundle != nil {
+		problems = append(problems, bundle.Config.Check(time.Local, now)...)
+	}
+	return bundle, problems, nil
+}
+
+// installStateBundle reemplaza la configuración y las excepciones por las del
+// bundle (ya validado) y registra el cambio
+func installStateBundle(
+	configs repositories.ConfigRepository,
+	overrides repositories.OverrideRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+	bundle *entities.StateBundle,
+	action, by string,
+) error {
+	config := bundle.Config
+	config.Update()
+	if err := configs.Save(config); err != nil {
+		log.Error("Failed to save configuration", "error", err)
+		return err
+	}
+
+	// Las excepciones que vencieron desde la exportación se descartan
+	bundle.Overrides.Prune(time.Now())
+	if err := overrides.SaveOverrides(bundle.Overrides); err != nil {
+		return fmt.Errorf("configuration installed but saving schedule overrides failed: %w", err)
+	}
+
+	log.Info("Configuration imported", "action", action, "by", by,
+		"wake_time", config.WakeTime, "shutdown_time", config.ShutdownTime)
+	recordEvent(events, log, entities.EventConfigChanged, "Configuration imported",
+		"action", action, "wake_time", config.WakeTime, "shutdown_time", config.ShutdownTime,
+		"profiles", fmt.Sprint(len(config.Profiles)), "by", by)
+	return nil
+}
+
+// problemList une los problemas en una sola línea para logs y eventos
+func problemList(problems []entities.ConfigProblem) string {
+	parts := make([]string, len(problems))
+	for i, problem := range problems {
+		parts[i] = problem.String()
+	}
+	return strings.Join(parts, "; ")
+}
\ No newline at end of file
@@ -103,13 +103,30 @@ func (uc *InstallServiceUseCase) Execute(input *InstallServiceInput) (*InstallSe
 	}, nil
 }
 
-// createConfiguration crea y guarda la configuración
+// createConfiguration crea y guarda la configuración. Si ya existe un archivo
+// de configuración se conservan sus demás ajustes (webhooks, retención, ...).
 func (uc *InstallServiceUseCase) createConfiguration(input *InstallServiceInput) error {
+	var config *entities.Config
+	if uc.configRepo.Exists() {
+		if existing, err := uc.configRepo.Load(); err == nil {
+			existing.SetSchedule(input.WakeTime, input.ShutdownTime)
+			existing.Enable()
+			if err := existing.Validate(); err != nil {
+				uc.logger.Error("Invalid configuration", "error", err)
+				return err
+			}
+			config = existing
+		}
+	}
+
 	// Validar formato de horarios
-	config, err := entities.NewConfig(input.WakeTime, input.ShutdownTime, true)
-	if err != nil {
-		uc.logger.Error("Invalid configuration", "error", err)
-		return err
+	if config == nil {
+		created, err := entities.NewConfig(input.WakeTime, input.ShutdownTime, true)
+		if err != nil {
+			uc.logger.Error("Invalid configuration", "error", err)
+			return err
+		}
+		config = created
 	}
 
 	// Guardar configuración directamente
@@ -0,0 +1,58 @@
+// internal/application/usecases/load_mqtt_settings.go
+package usecases
+
+import (
+	"errors"
+	"fmt"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+var ErrMQTTNotConfigured = errors.New("MQTT is not configured (add an \"mqtt\" section to the configuration)")
+
+type LoadMQTTSettingsInput struct{}
+
+type LoadMQTTSettingsOutput struct {
+	Settings *entities.MQTTConfig
+}
+
+// LoadMQTTSettingsUseCase obtiene la configuración de la integración MQTT
+type LoadMQTTSettingsUseCase struct {
+	configRepo repositories.ConfigRepository
+	logger     logger.Logger
+}
+
+func NewLoadMQTTSettingsUseCase(
+	config repositories.ConfigRepository,
+	log logger.Logger,
+) *LoadMQTTSettingsUseCase {
+	return &LoadMQTTSettingsUseCase{
+		configRepo: config,
+		logger:     log,
+	}
+}
+
+func (uc *LoadMQTTSettingsUseCase) Execute(input *LoadMQTTSettingsInput) (*LoadMQTTSettingsOutput, error) {
+	if !uc.configRepo.Exists() {
+		return nil, ErrMQTTNotConfigured
+	}
+
+	config, err := uc.configRepo.Load()
+	if err != nil {
+		uc.logger.Error("Failed to load configuration", "error", err)
+		return nil, err
+	}
+
+	if config.MQTT == nil {
+		return nil, ErrMQTTNotConfigured
+	}
+	if err := config.MQTT.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid MQTT configuration: %w", err)
+	}
+
+	return &LoadMQTTSettingsOutput{
+		Settings: config.MQTT,
+	}, nil
+}
\ No newline at end of file
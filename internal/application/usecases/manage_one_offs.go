@@ -0,0 +1,127 @@
+// internal/application/usecases/manage_one_offs.go
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+// Acciones aceptadas por ManageOneOffsUseCase
+const (
+	OneOffActionList   = "list"
+	OneOffActionCancel = "cancel"
+)
+
+var (
+	ErrUnknownOneOffAction = errors.New("unknown queue action, use list or cancel")
+	ErrOneOffIDRequired    = errors.New("one-off ID is required")
+)
+
+type ManageOneOffsInput struct {
+	Action string
+	ID     string
+	By     string
+}
+
+type ManageOneOffsOutput struct {
+	OneOffs []*entities.OneOff
+	Message string
+}
+
+// ManageOneOffsUseCase lista y cancela las ventanas únicas de la cola (las que
+// agrega el modo manual con fechas). Cancelar vuelve a armar el próximo ciclo.
+type ManageOneOffsUseCase struct {
+	configRepo    repositories.ConfigRepository
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	overrideRepo  repositories.OverrideRepository
+	eventRepo     repositories.EventRepository
+	logger        logger.Logger
+}
+
+func NewManageOneOffsUseCase(
+	config repositories.ConfigRepository,
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	overrides repositories.OverrideRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *ManageOneOffsUseCase {
+	return &ManageOneOffsUseCase{
+		configRepo:    config,
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		overrideRepo:  overrides,
+		eventRepo:     events,
+		logger:        log,
+	}
+}
+
+func (uc *ManageOneOffsUseCase) Execute(input *ManageOneOffsInput) (*ManageOneOffsOutput, error) {
+	armer := newScheduleArmer(uc.rtcRepo, uc.schedulerRepo, uc.overrideRepo, uc.eventRepo)
+
+	switch input.Action {
+	case OneOffActionList:
+		overrides, err := uc.overrideRepo.LoadOverrides()
+		if err != nil {
+			return nil, err
+		}
+		overrides.Prune(time.Now())
+		return &ManageOneOffsOutput{
+			OneOffs: overrides.OneOffs,
+			Message: fmt.Sprintf("%d one-off(s) queued", len(overrides.OneOffs)),
+		}, nil
+
+	case OneOffActionCancel:
+		if input.ID == "" {
+			return nil, ErrOneOffIDRequired
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownOneOffAction, input.Action)
+	}
+
+	overrides := armer.loadOverrides(uc.logger)
+	if overrides == nil {
+		overrides = &entities.ScheduleOverrides{}
+	}
+	cancelled, err := overrides.CancelOneOff(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.overrideRepo.SaveOverrides(overrides); err != nil {
+		uc.logger.Error("Failed to save schedule overrides", "error", err)
+		return nil, err
+	}
+	recordEvent(uc.eventRepo, uc.logger, entities.EventOverrideChanged, "One-off cancelled",
+		"kind", "one_off", "action", "cancel", "id", cancelled.ID,
+		"wake_time", cancelled.WakeAt.Format(time.RFC3339), "by", input.By)
+
+	// Sin horario diario se arma lo que queda en la cola; si no queda nada, la
+	// alarma de la ventana cancelada no debe seguir armada
+	var config *entities.Config
+	if uc.configRepo.Exists() {
+		if config, err = uc.configRepo.Load(); err != nil {
+			return nil, err
+		}
+		if !config.Enabled {
+			config = nil
+		}
+	}
+	_, err = armer.arm(uc.logger, config, "override", true)
+	if errors.Is(err, errNothingToArm) {
+		armer.disarm(uc.logger, "override")
+	} else if err != nil {
+		return nil, fmt.Errorf("one-off cancelled but re-arming failed: %w", err)
+	}
+
+	return &ManageOneOffsOutput{
+		OneOffs: overrides.OneOffs,
+		Message: fmt.Sprintf("One-off #%s cancelled: %s", cancelled.ID, cancelled),
+	}, nil
+}
\ No newline at end of file
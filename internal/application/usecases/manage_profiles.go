@@ -0,0 +1,259 @@
+// internal/application/usecases/manage_profiles.go
+package usecases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+// Acciones aceptadas por ManageProfilesUseCase
+const (
+	ProfileActionList   = "list"
+	ProfileActionUse    = "use"
+	ProfileActionCreate = "create"
+	ProfileActionDelete = "delete"
+)
+
+var (
+	ErrUnknownProfileAction = errors.New("unknown profile action, use list, use, create or delete")
+	ErrProfileNameRequired  = errors.New("profile name is required")
+	ErrProfileSetByDropIn   = errors.New("the profile switch would be overridden by a drop-in file")
+)
+
+type ManageProfilesInput struct {
+	Action string
+	Name   string
+	// WakeTime y ShutdownTime definen el perfil creado (vacío = horario actual)
+	WakeTime     string
+	ShutdownTime string
+	// On programa el cambio para una fecha YYYY-MM-DD en lugar de aplicarlo ya
+	On string
+	By string
+}
+
+type ManageProfilesOutput struct {
+	Profiles []entities.Profile
+	Active   string
+	Switches []entities.ProfileSwitch
+	Message  string
+}
+
+// ManageProfilesUseCase administra los perfiles de horario. Activar un perfil
+// cambia el horario diario y vuelve a armar la alarma RTC de inmediato.
+type ManageProfilesUseCase struct {
+	configRepo    repositories.ConfigRepository
+	sourceRepo    repositories.ConfigSourceRepository
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	overrideRepo  repositories.OverrideRepository
+	eventRepo     repositories.EventRepository
+	logger        logger.Logger
+}
+
+func NewManageProfilesUseCase(
+	config repositories.ConfigRepository,
+	sources repositories.ConfigSourceRepository,
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	overrides repositories.OverrideRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *ManageProfilesUseCase {
+	return &ManageProfilesUseCase{
+		configRepo:    config,
+		sourceRepo:    sources,
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		overrideRepo:  overrides,
+		eventRepo:     events,
+		logger:        log,
+	}
+}
+
+func (uc *ManageProfilesUseCase) Execute(input *ManageProfilesInput) (*ManageProfilesOutput, error) {
+	if input.Action != ProfileActionList && input.Name == "" {
+		return nil, ErrProfileNameRequired
+	}
+
+	config, err := uc.configRepo.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var message string
+	switch input.Action {
+	case ProfileActionList:
+		if len(config.Profiles) == 0 {
+			message = "No profiles defined"
+		} else {
+			message = fmt.Sprintf("%d profile(s) defined", len(config.Profiles))
+		}
+		return uc.output(config, message), nil
+
+	case ProfileActionCreate:
+		message, err = uc.create(config, input)
+	case ProfileActionDelete:
+		message, err = uc.delete(config, input)
+	case ProfileActionUse:
+		if input.On != "" {
+			message, err = uc.scheduleSwitch(config, input)
+		} else {
+			message, err = uc.use(config, input)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProfileAction, input.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.output(config, message), nil
+}
+
+// create agrega un perfil con el horario indicado o, si no se indica, el actual
+func (uc *ManageProfilesUseCase) create(config *entities.Config, input *ManageProfilesInput) (string, error) {
+	profile := entities.Profile{
+		Name:         input.Name,
+		WakeTime:     input.WakeTime,
+		ShutdownTime: input.ShutdownTime,
+	}
+	if profile.WakeTime == "" && profile.ShutdownTime == "" {
+		profile.WakeTime = config.WakeTime
+		profile.ShutdownTime = config.ShutdownTime
+	}
+
+	if err := config.AddProfile(profile); err != nil {
+		return "", err
+	}
+	if err := uc.save(config); err != nil {
+		return "", err
+	}
+
+	uc.logger.Info("Profile created", "profile", profile.Name, "wake_time", profile.WakeTime, "shutdown_time", profile.ShutdownTime)
+	return fmt.Sprintf("Profile %q created (wake %s, shutdown %s)", profile.Name, profile.WakeTime, profile.ShutdownTime), nil
+}
+
+// delete elimina un perfil que no esté activo
+func (uc *ManageProfilesUseCase) delete(config *entities.Config, input *ManageProfilesInput) (string, error) {
+	if err := config.DeleteProfile(input.Name); err != nil {
+		return "", err
+	}
+	if err := uc.save(config); err != nil {
+		return "", err
+	}
+
+	uc.logger.Info("Profile deleted", "profile", input.Name)
+	return fmt.Sprintf("Profile %q deleted", input.Name), nil
+}
+
+// scheduleSwitch programa la activación del perfil para una fecha
+func (uc *ManageProfilesUseCase) scheduleSwitch(config *entities.Config, input *ManageProfilesInput) (string, error) {
+	change, err := entities.NewProfileSwitch(input.Name, input.On, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if err := config.ScheduleProfileSwitch(*change); err != nil {
+		return "", err
+	}
+	// Al aplicarlo, el servicio no podría guardar un horario que fija un fragmento
+	if err := uc.checkDropIns(config, change.Profile); err != nil {
+		return "", err
+	}
+	if err := uc.save(config); err != nil {
+		return "", err
+	}
+	uc.logger.Info("Profile switch scheduled", "profile", change.Profile, "date", input.On, "by", input.By)
+
+	// El próximo despertar puede caer ya dentro del nuevo perfil
+	if err := uc.rearm(config); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Profile %q will be activated on %s", change.Profile, input.On), nil
+}
+
+// use activa el perfil ahora y vuelve a armar el próximo ciclo
+func (uc *ManageProfilesUseCase) use(config *entities.Config, input *ManageProfilesInput) (string, error) {
+	previous := config.ActiveProfile
+	if err := config.UseProfile(input.Name); err != nil {
+		return "", err
+	}
+	if err := uc.save(config); err != nil {
+		return "", err
+	}
+
+	uc.logger.Info("Profile activated", "profile", input.Name, "previous", previous, "by", input.By)
+	recordEvent(uc.eventRepo, uc.logger, entities.EventProfileSwitched, "Schedule profile activated",
+		"profile", input.Name, "previous", previous, "wake_time", config.WakeTime, "shutdown_time", config.ShutdownTime, "by", input.By)
+
+	if err := uc.rearm(config); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Profile %q active (wake %s, shutdown %s)", input.Name, config.WakeTime, config.ShutdownTime), nil
+}
+
+// checkDropIns verifica que ningún fragmento fije un valor que el perfil cambia
+func (uc *ManageProfilesUseCase) checkDropIns(config *entities.Config, name string) error {
+	if uc.sourceRepo == nil {
+		return nil
+	}
+	profile, err := config.FindProfile(name)
+	if err != nil {
+		return err
+	}
+	effective, err := uc.sourceRepo.LoadEffective()
+	if err != nil {
+		return fmt.Errorf("failed to read drop-in files: %w", err)
+	}
+
+	changes := map[string]string{
+		"active_profile": profile.Name,
+		"wake_time":      profile.WakeTime,
+		"shutdown_time":  profile.ShutdownTime,
+	}
+	for _, value := range effective.Values {
+		want, ok := changes[value.Path]
+		if !ok || len(effective.Files) == 0 || value.Source == effective.Files[0] {
+			continue
+		}
+		if encoded, _ := json.Marshal(want); string(encoded) != value.Value {
+			return fmt.Errorf("%w: %s is set to %s by %s", ErrProfileSetByDropIn, value.Path, value.Value, value.Source)
+		}
+	}
+	return nil
+}
+
+// rearm vuelve a armar la alarma RTC y el apagado si el servicio está habilitado
+func (uc *ManageProfilesUseCase) rearm(config *entities.Config) error {
+	if !config.Enabled {
+		return nil
+	}
+
+	armer := newScheduleArmer(uc.rtcRepo, uc.schedulerRepo, uc.overrideRepo, uc.eventRepo)
+	if _, err := armer.arm(uc.logger, config, "profile", true); err != nil {
+		return fmt.Errorf("profile saved but re-arming failed: %w", err)
+	}
+	return nil
+}
+
+func (uc *ManageProfilesUseCase) save(config *entities.Config) error {
+	if err := uc.configRepo.Save(config); err != nil {
+		uc.logger.Error("Failed to save configuration", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (uc *ManageProfilesUseCase) output(config *entities.Config, message string) *ManageProfilesOutput {
+	return &ManageProfilesOutput{
+		Profiles: config.Profiles,
+		Active:   config.ActiveProfile,
+		Switches: config.ProfileSwitches,
+		Message:  message,
+	}
+}
\ No newline at end of file
@@ -0,0 +1,107 @@
+// internal/application/usecases/manage_profiles_test.go
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/pkg/logger"
+)
+
+type fakeConfigSource struct{ effective *entities.EffectiveConfig }
+
+func (s *fakeConfigSource) LoadEffective() (*entities.EffectiveConfig, error) {
+	return s.effective, nil
+}
+
+// dropInConfigRepository no puede guardar: un fragmento fija el horario
+type dropInConfigRepository struct{ load func() *entities.Config }
+
+func (r *dropInConfigRepository) Load() (*entities.Config, error) { return r.load(), nil }
+func (r *dropInConfigRepository) Save(c *entities.Config) error {
+	return errors.New("value is set by a drop-in file: wake_time is set by 10-site.json")
+}
+func (r *dropInConfigRepository) Delete() error        { return nil }
+func (r *dropInConfigRepository) Exists() bool         { return true }
+func (r *dropInConfigRepository) CreateDefault() error { return nil }
+
+type memoryEventRepository struct{ events []*entities.PowerEvent }
+
+func (r *memoryEventRepository) Append(event *entities.PowerEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+func (r *memoryEventRepository) List(from, to time.Time) ([]*entities.PowerEvent, error) {
+	return r.events, nil
+}
+func (r *memoryEventRepository) Compact(maxAge time.Duration, maxEvents int) (int, error) {
+	return 0, nil
+}
+
+// configWithSummer retorna un horario 07:00-23:00 con el perfil summer (06:00-22:00)
+func configWithSummer(t *testing.T) *entities.Config {
+	t.Helper()
+	config, _ := entities.NewConfig("07:00", "23:00", true)
+	if err := config.AddProfile(entities.Profile{Name: "summer", WakeTime: "06:00", ShutdownTime: "22:00"}); err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
+
+func TestScheduleProfileSwitchRejectedByDropIn(t *testing.T) {
+	on := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	source := &fakeConfigSource{effective: &entities.EffectiveConfig{
+		Files: []string{"/etc/rtc-scheduler/config.json", "/etc/rtc-scheduler/config.d/10-site.json"},
+		Values: []entities.ConfigValue{
+			{Path: "shutdown_time", Value: `"23:00"`, Source: "/etc/rtc-scheduler/config.json"},
+			{Path: "wake_time", Value: `"07:00"`, Source: "/etc/rtc-scheduler/config.d/10-site.json"},
+		},
+	}}
+	configRepo := &memoryConfigRepository{config: configWithSummer(t)}
+	uc := NewManageProfilesUseCase(configRepo, source, &fakeRTC{}, &fakeScheduler{}, nil, nil, logger.NewWithLevel(logger.ErrorLevel))
+
+	_, err := uc.Execute(&ManageProfilesInput{Action: ProfileActionUse, Name: "summer", On: on})
+	if !errors.Is(err, ErrProfileSetByDropIn) {
+		t.Fatalf("Execute() error = %v, want ErrProfileSetByDropIn", err)
+	}
+
+	// Si el fragmento fija el mismo valor que el perfil, el cambio no lo pisa
+	source.effective.Values[1].Value = `"06:00"`
+	if _, err := uc.Execute(&ManageProfilesInput{Action: ProfileActionUse, Name: "summer", On: on}); err != nil {
+		t.Fatalf("Execute() error = %v, want the switch scheduled", err)
+	}
+	if len(configRepo.config.ProfileSwitches) != 1 {
+		t.Errorf("switches = %v, want one", configRepo.config.ProfileSwitches)
+	}
+}
+
+func TestRunServiceProfileSwitchNotSaved(t *testing.T) {
+	configRepo := &dropInConfigRepository{load: func() *entities.Config {
+		config := configWithSummer(t)
+		config.ProfileSwitches = []entities.ProfileSwitch{{Profile: "summer", At: time.Now().Add(-time.Hour)}}
+		return config
+	}}
+	events := &memoryEventRepository{}
+	scheduler := &fakeScheduler{}
+	uc := NewRunServiceUseCase(configRepo, &fakeRTC{}, scheduler, events, nil, nil, nil, nil, nil, nil, nil, logger.NewWithLevel(logger.ErrorLevel))
+
+	if _, err := uc.Execute(&RunServiceInput{}); err != nil {
+		t.Fatal(err)
+	}
+	var failed bool
+	for _, event := range events.events {
+		if event.Type == entities.EventProfileSwitched {
+			t.Errorf("recorded %q for a switch that was not saved", event.Message)
+		}
+		failed = failed || event.Type == entities.EventError
+	}
+	if !failed {
+		t.Error("no error event for the failed switch")
+	}
+	// Se arma el horario guardado, no el del perfil
+	if got := scheduler.shutdown.Format("15:04"); got != "23:00" {
+		t.Errorf("shutdown at %s, want the saved 23:00", got)
+	}
+}
\ No newline at end of file
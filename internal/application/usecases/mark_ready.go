@@ -0,0 +1,144 @@
+// internal/application/usecases/mark_ready.go
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+// readyPollInterval es cada cuánto se consulta si la unidad de "listo" está activa
+const readyPollInterval = 2 * time.Second
+
+type MarkWakeReadyInput struct{}
+
+type MarkWakeReadyOutput struct {
+	// Measured indica si se registró una medición; sin un despertar RTC
+	// pendiente de medir no hay nada que registrar
+	Measured    bool
+	WakeToReady time.Duration
+	Unit        string
+	Message     string
+}
+
+// MarkWakeReadyUseCase mide cuánto tarda el equipo en estar usable después de
+// la alarma RTC: espera a que la unidad de "listo" esté activa y registra el
+// tiempo desde la alarma en un evento wake_ready. Con learn_wake_lead, esas
+// mediciones ajustan la anticipación de las próximas alarmas. Lo ejecuta la
+// unidad <servicio>-ready al terminar cada arranque o reanudación.
+type MarkWakeReadyUseCase struct {
+	configRepo repositories.ConfigRepository
+	eventRepo  repositories.EventRepository
+	unitRepo   repositories.UnitStateRepository
+	logger     logger.Logger
+}
+
+func NewMarkWakeReadyUseCase(
+	config repositories.ConfigRepository,
+	events repositories.EventRepository,
+	units repositories.UnitStateRepository,
+	log logger.Logger,
+) *MarkWakeReadyUseCase {
+	return &MarkWakeReadyUseCase{
+		configRepo: config,
+		eventRepo:  events,
+		unitRepo:   units,
+		logger:     log,
+	}
+}
+
+func (uc *MarkWakeReadyUseCase) Execute(input *MarkWakeReadyInput) (*MarkWakeReadyOutput, error) {
+	unit := entities.DefaultReadyUnit
+	if uc.configRepo.Exists() {
+		config, err := uc.configRepo.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		unit = config.ReadyTarget()
+	}
+	output := &MarkWakeReadyOutput{Unit: unit}
+
+	wake, err := uc.pendingWake(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if wake == nil {
+		output.Message = "No RTC wake to measure"
+		return output, nil
+	}
+
+	armedFor, err := time.Parse(time.RFC3339, wake.Fields["armed_for"])
+	if err != nil {
+		return nil, fmt.Errorf("wake event has no alarm time: %w", err)
+	}
+
+	readyAt, err := uc.waitActive(unit, armedFor.Add(entities.MaxWakeToReady))
+	if err != nil {
+		return nil, err
+	}
+
+	elapsed := readyAt.Sub(armedFor)
+	if elapsed < 0 || elapsed > entities.MaxWakeToReady {
+		uc.logger.Warn("Discarding wake-to-ready measurement out of range", "wake_to_ready", elapsed, "unit", unit)
+		output.Message = fmt.Sprintf("Wake-to-ready time %s out of range, not recorded", elapsed.Round(time.Second))
+		return output, nil
+	}
+
+	output.Measured = true
+	output.WakeToReady = elapsed.Round(time.Second)
+	recordEvent(uc.eventRepo, uc.logger, entities.EventWakeReady,
+		fmt.Sprintf("System ready %s after the wake alarm", output.WakeToReady),
+		"armed_for", armedFor.Format(time.RFC3339),
+		"ready_at", readyAt.Format(time.RFC3339),
+		"wake_to_ready_seconds", int64(output.WakeToReady.Seconds()),
+		"unit", unit,
+		"kind", wake.Fields["kind"],
+	)
+	output.Message = fmt.Sprintf("%s active %s after the wake alarm", unit, output.WakeToReady)
+	return output, nil
+}
+
+// pendingWake retorna el último despertar producido por la alarma RTC (puntual
+// o con retraso) que todavía no se midió, o nil si no hay
+func (uc *MarkWakeReadyUseCase) pendingWake(now time.Time) (*entities.PowerEvent, error) {
+	events, err := uc.eventRepo.List(now.Add(-entities.MaxWakeToReady), time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event history: %w", err)
+	}
+
+	for i := len(events) - 1; i >= 0; i-- {
+		switch events[i].Type {
+		case entities.EventWakeReady, entities.EventWakeMissed:
+			return nil, nil
+		case entities.EventWakeDetected:
+			outcome := entities.WakeOutcome(events[i].Fields["outcome"])
+			if outcome != entities.WakeOnTime && outcome != entities.WakeLate {
+				return nil, nil
+			}
+			return events[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// waitActive espera a que unit esté activa, hasta deadline, y retorna cuándo
+func (uc *MarkWakeReadyUseCase) waitActive(unit string, deadline time.Time) (time.Time, error) {
+	for {
+		active, err := uc.unitRepo.IsActive(unit)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to query %s: %w", unit, err)
+		}
+		now := time.Now()
+		if active {
+			return now, nil
+		}
+		if now.After(deadline) {
+			return time.Time{}, fmt.Errorf("%s not active within %s of the wake alarm", unit, entities.MaxWakeToReady)
+		}
+		uc.logger.Debug("Waiting for ready unit", "unit", unit)
+		time.Sleep(readyPollInterval)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,50 @@
+// internal/application/usecases/migrate_config.go
+package usecases
+
+import (
+	"fmt"
+
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type MigrateConfigInput struct{}
+
+type MigrateConfigOutput struct {
+	From     int
+	To       int
+	Migrated bool
+	Message  string
+}
+
+// MigrateConfigUseCase reescribe la configuración en el formato actual. Las
+// cargas solo la migran en memoria; el archivo se actualiza con el próximo
+// cambio o con este comando, siempre con el lock de comandos tomado.
+type MigrateConfigUseCase struct {
+	migrationRepo repositories.ConfigMigrationRepository
+	logger        logger.Logger
+}
+
+func NewMigrateConfigUseCase(migrations repositories.ConfigMigrationRepository, log logger.Logger) *MigrateConfigUseCase {
+	return &MigrateConfigUseCase{
+		migrationRepo: migrations,
+		logger:        log,
+	}
+}
+
+func (uc *MigrateConfigUseCase) Execute(input *MigrateConfigInput) (*MigrateConfigOutput, error) {
+	from, to, err := uc.migrationRepo.Migrate()
+	if err != nil {
+		return nil, err
+	}
+
+	output := &MigrateConfigOutput{From: from, To: to, Migrated: from != to}
+	if !output.Migrated {
+		output.Message = fmt.Sprintf("Configuration already uses schema version %d", to)
+		return output, nil
+	}
+
+	uc.logger.Info("Configuration migrated", "from", from, "to", to)
+	output.Message = fmt.Sprintf("Configuration migrated from schema version %d to %d, the original was backed up", from, to)
+	return output, nil
+}
\ No newline at end of file
@@ -0,0 +1,202 @@
+// internal/application/usecases/monitor_ups.go
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+var ErrUPSNotConfigured = errors.New("UPS monitoring is not configured (\"ups\" in the configuration)")
+
+type MonitorUPSInput struct {
+	// Stop detiene el monitoreo al cerrarse
+	Stop <-chan struct{}
+}
+
+type MonitorUPSOutput struct {
+	Polls     int
+	Shutdowns int
+	Message   string
+}
+
+// MonitorUPSUseCase consulta la UPS periódicamente y, cuando el equipo está en
+// batería por debajo del umbral, adelanta el apagado y arma una alarma RTC para
+// cuando la energía probablemente haya vuelto
+type MonitorUPSUseCase struct {
+	configRepo    repositories.ConfigRepository
+	upsRepo       repositories.UPSRepository
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	eventRepo     repositories.EventRepository
+	lockRepo      repositories.LockRepository
+	logger        logger.Logger
+}
+
+func NewMonitorUPSUseCase(
+	config repositories.ConfigRepository,
+	ups repositories.UPSRepository,
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	events repositories.EventRepository,
+	lock repositories.LockRepository,
+	log logger.Logger,
+) *MonitorUPSUseCase {
+	return &MonitorUPSUseCase{
+		configRepo:    config,
+		upsRepo:       ups,
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		eventRepo:     events,
+		lockRepo:      lock,
+		logger:        log,
+	}
+}
+
+func (uc *MonitorUPSUseCase) Execute(input *MonitorUPSInput) (*MonitorUPSOutput, error) {
+	settings, err := uc.loadSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("Monitoring UPS", "ups", settings.Name, "address", settings.ServerAddress(),
+		"shutdown_charge", settings.ShutdownThreshold(), "interval", settings.PollInterval())
+
+	output := &MonitorUPSOutput{}
+	monitor := &upsMonitor{}
+
+	for {
+		// Recargar la configuración permite cambiar umbrales sin reiniciar
+		if current, err := uc.loadSettings(); err == nil {
+			settings = current
+		}
+
+		output.Polls++
+		if uc.poll(settings, monitor) {
+			output.Shutdowns++
+		}
+
+		select {
+		case <-input.Stop:
+			output.Message = fmt.Sprintf("UPS monitor stopped after %d polls (%d early shutdowns)", output.Polls, output.Shutdowns)
+			return output, nil
+		case <-time.After(settings.PollInterval()):
+		}
+	}
+}
+
+// upsMonitor guarda lo observado en consultas anteriores
+type upsMonitor struct {
+	onBattery bool
+	triggered bool
+	lastError string
+}
+
+// poll consulta la UPS una vez; retorna true si disparó un apagado anticipado
+func (uc *MonitorUPSUseCase) poll(settings *entities.UPSConfig, monitor *upsMonitor) bool {
+	status, err := uc.upsRepo.Status(settings)
+	if err != nil {
+		// Reportar solo cuando el error cambia, para no llenar el log en cada consulta
+		if err.Error() != monitor.lastError {
+			uc.logger.Warn("Failed to query UPS", "ups", settings.Name, "error", err)
+			monitor.lastError = err.Error()
+		}
+		return false
+	}
+	if monitor.lastError != "" {
+		uc.logger.Info("UPS reachable again", "ups", settings.Name)
+		monitor.lastError = ""
+	}
+
+	uc.logger.Debug("UPS status", "ups", settings.Name, "status", status.String())
+
+	if status.OnBattery() != monitor.onBattery {
+		monitor.onBattery = status.OnBattery()
+		if monitor.onBattery {
+			uc.logger.Warn("UPS on battery", "ups", settings.Name, "status", status.String())
+		} else {
+			uc.logger.Info("UPS back on line power", "ups", settings.Name, "status", status.String())
+			monitor.triggered = false
+		}
+	}
+
+	shutdown, reason := settings.ShouldShutdown(status)
+	if !shutdown || monitor.triggered {
+		return false
+	}
+
+	// Si otro comando tiene el lock, se reintenta en la próxima consulta
+	release, err := acquireLock(uc.lockRepo, DefaultLockTimeout, uc.logger, "ups-monitor", "ups")
+	if err != nil {
+		return false
+	}
+	defer release()
+
+	if err := suspendUntilRecovery(uc.rtcRepo, uc.schedulerRepo, uc.eventRepo, uc.logger,
+		settings, entities.EventUPSShutdown, "Early shutdown on battery: "+reason, status); err != nil {
+		return false
+	}
+
+	monitor.triggered = true
+	return true
+}
+
+// loadSettings retorna la configuración de la UPS
+func (uc *MonitorUPSUseCase) loadSettings() (*entities.UPSConfig, error) {
+	config, err := uc.configRepo.Load()
+	if err != nil {
+		return nil, err
+	}
+	if config.UPS == nil {
+		return nil, ErrUPSNotConfigured
+	}
+	return config.UPS, nil
+}
+
+// suspendUntilRecovery arma la alarma RTC para el próximo intento de despertar
+// y programa un apagado inmediato, reemplazando los apagados pendientes
+func suspendUntilRecovery(
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+	settings *entities.UPSConfig,
+	eventType entities.EventType,
+	message string,
+	status *entities.UPSStatus,
+) error {
+	now := time.Now()
+	wakeAt := now.Add(settings.RecoveryWake())
+	shutdownAt := now.Add(entities.UPSShutdownLead)
+
+	if err := rtc.SetWakeAlarm(wakeAt); err != nil {
+		log.Error("Failed to arm recovery wake alarm", "error", err)
+		recordEventWithLevel(events, log, entities.EventError, entities.EventLevelError,
+			"Failed to arm UPS recovery wake", "error", err)
+		return err
+	}
+	recordEvent(events, log, entities.EventAlarmArmed, "RTC wake alarm armed for UPS recovery",
+		"wake_time", wakeAt.Format(time.RFC3339), "source", "ups")
+
+	if err := scheduler.CancelShutdown(); err != nil {
+		log.Warn("Failed to cancel pending shutdowns", "error", err)
+	}
+	if err := scheduler.ScheduleShutdown(shutdownAt); err != nil {
+		log.Error("Failed to schedule early shutdown", "error", err)
+		recordEventWithLevel(events, log, entities.EventError, entities.EventLevelError,
+			"Failed to schedule UPS early shutdown", "error", err)
+		return err
+	}
+
+	log.Warn(message, "ups", settings.Name, "status", status.String(),
+		"shutdown_time", shutdownAt.Format(time.RFC3339), "wake_time", wakeAt.Format(time.RFC3339))
+	recordEventWithLevel(events, log, eventType, entities.EventLevelWarning, message,
+		"ups", settings.Name, "status", status.String(),
+		"shutdown_time", shutdownAt.Format(time.RFC3339), "wake_time", wakeAt.Format(time.RFC3339))
+
+	return nil
+}
\ No newline at end of file
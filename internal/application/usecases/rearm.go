@@ -0,0 +1,209 @@
+// internal/application/usecases/rearm.go
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/internal/infrastructure/scheduler"
+	"rtc-scheduler/pkg/logger"
+)
+
+// errNothingToArm indica que no hay horario diario ni ventanas únicas pendientes
+var errNothingToArm = errors.New("no daily schedule and no queued one-offs")
+
+// scheduleArmer arma la alarma RTC y el apagado del horario diario aplicando
+// las excepciones vigentes (skip-next, vacaciones) y combinándolo con la cola
+// de ventanas únicas. Lo usan el servicio y los comandos que cambian el
+// próximo ciclo.
+type scheduleArmer struct {
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	overrideRepo  repositories.OverrideRepository
+	eventRepo     repositories.EventRepository
+}
+
+// armResult describe lo que quedó programado. WakeTime es la hora a la que el
+// equipo debe estar listo; AlarmTime, la de la alarma RTC (antes, si hay
+// anticipación configurada o aprendida).
+type armResult struct {
+	WakeTime     time.Time
+	AlarmTime    time.Time
+	ShutdownTime time.Time
+	Degraded     bool
+	Backend      string
+}
+
+// describeWake retorna el próximo encendido y, si se adelantó, la alarma:
+// "2026-10-19 08:00 (alarm 07:57:15)"
+func (r *armResult) describeWake() string {
+	wake := r.WakeTime.Format("2006-01-02 15:04")
+	if r.AlarmTime.IsZero() || r.AlarmTime.Equal(r.WakeTime) {
+		return wake
+	}
+	return fmt.Sprintf("%s (alarm %s)", wake, r.AlarmTime.Format("15:04:05"))
+}
+
+func newScheduleArmer(
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	overrides repositories.OverrideRepository,
+	events repositories.EventRepository,
+) *scheduleArmer {
+	return &scheduleArmer{
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		overrideRepo:  overrides,
+		eventRepo:     events,
+	}
+}
+
+// loadOverrides retorna las excepciones vigentes; las vencidas se eliminan del disco
+func (a *scheduleArmer) loadOverrides(log logger.Logger) *entities.ScheduleOverrides {
+	if a.overrideRepo == nil {
+		return nil
+	}
+
+	overrides, err := a.overrideRepo.LoadOverrides()
+	if err != nil {
+		log.Warn("Failed to load schedule overrides, ignoring them", "error", err)
+		return nil
+	}
+
+	if overrides.Prune(time.Now()) {
+		log.Info("Expired schedule overrides removed, daily schedule resumes")
+		if err := a.overrideRepo.SaveOverrides(overrides); err != nil {
+			log.Warn("Failed to save schedule overrides", "error", err)
+		}
+	}
+	return overrides
+}
+
+// arm programa el próximo ciclo. Con replace, cancela antes los apagados
+// pendientes (cuando se re-arma con el equipo encendido). Con config nil
+// (sin configuración o deshabilitada) solo cuentan las ventanas únicas.
+func (a *scheduleArmer) arm(log logger.Logger, config *entities.Config, source string, replace bool) (*armResult, error) {
+	overrides := a.loadOverrides(log)
+
+	var wake, shutdown time.Time
+	if config != nil {
+		var err error
+		wake, shutdown, err = effectiveSchedule(config, overrides)
+		if err != nil {
+			log.Error("Failed to parse schedule from config", "error", err)
+			return nil, err
+		}
+	}
+
+	wake, shutdown = overrides.Merge(wake, shutdown, time.Now())
+	if wake.IsZero() && shutdown.IsZero() {
+		return nil, errNothingToArm
+	}
+
+	// La anticipación solo aplica al horario diario (y a la cola combinada con él)
+	alarm := wake
+	if config != nil && !wake.IsZero() {
+		alarm = wakeLead(config, a.eventRepo, log).AlarmFor(wake, time.Now())
+	}
+
+	log.Info("Executing schedule",
+		"wake_time", wake,
+		"alarm_time", alarm,
+		"shutdown_time", shutdown,
+	)
+
+	// Configurar alarma RTC; en la última ventana única sin horario diario no
+	// hay un próximo despertar
+	step := time.Now()
+	if wake.IsZero() {
+		if err := a.rtcRepo.ClearWakeAlarm(); err != nil {
+			log.Warn("Failed to clear RTC wake alarm", "error", err)
+		} else {
+			recordEvent(a.eventRepo, log, entities.EventAlarmCleared, "RTC wake alarm cleared, no wake after the last one-off", "source", source)
+		}
+	} else {
+		if err := a.rtcRepo.SetWakeAlarm(alarm); err != nil {
+			log.Error("Failed to set RTC wake alarm", "error", err)
+			return nil, err
+		}
+		logStep(log, "arm_rtc", step)
+		args := []interface{}{"wake_time", alarm.Format(time.RFC3339), "source", source}
+		if !alarm.Equal(wake) {
+			args = append(args, "ready_time", wake.Format(time.RFC3339),
+				"lead_seconds", int64(wake.Sub(alarm).Seconds()))
+		}
+		recordEvent(a.eventRepo, log, entities.EventAlarmArmed, "RTC wake alarm armed", args...)
+	}
+
+	// Programar apagado
+	step = time.Now()
+	backend := a.schedulerRepo.Backend()
+	if replace {
+		if err := a.schedulerRepo.CancelShutdown(); err != nil {
+			log.Warn("Failed to cancel pending shutdowns", "error", err)
+		}
+	}
+	if err := a.schedulerRepo.ScheduleShutdown(shutdown); err != nil {
+		// Verificar si es un error de filesystem read-only (modo degradado)
+		if errors.Is(err, scheduler.ErrFilesystemReadOnly) {
+			// Modo degradado: RTC funciona, pero shutdown no se programa
+			log.Warn("Filesystem is read-only, operating in degraded mode: RTC wake alarm configured but shutdown scheduling unavailable",
+				"error", err, "backend", backend)
+			return &armResult{WakeTime: wake, AlarmTime: alarm, Degraded: true, Backend: backend}, nil
+		}
+
+		// Para otros errores, limpiar alarma y fallar
+		if a.rtcRepo.ClearWakeAlarm() == nil {
+			recordEvent(a.eventRepo, log, entities.EventAlarmCleared, "RTC wake alarm cleared after scheduling failure", "source", source)
+		}
+		log.Error("Failed to schedule shutdown", "error", err, "backend", backend)
+		return nil, err
+	}
+	logStep(log, "schedule_shutdown", step, "backend", backend)
+	recordEvent(a.eventRepo, log, entities.EventShutdownScheduled, "Shutdown scheduled",
+		"shutdown_time", shutdown.Format(time.RFC3339), "source", source)
+
+	return &armResult{WakeTime: wake, AlarmTime: alarm, ShutdownTime: shutdown, Backend: backend}, nil
+}
+
+// disarm quita la alarma RTC y los apagados pendientes
+func (a *scheduleArmer) disarm(log logger.Logger, source string) {
+	if err := a.rtcRepo.ClearWakeAlarm(); err != nil {
+		log.Warn("Failed to clear RTC wake alarm", "error", err)
+	} else {
+		recordEvent(a.eventRepo, log, entities.EventAlarmCleared, "RTC wake alarm cleared, nothing left to arm", "source", source)
+	}
+	if err := a.schedulerRepo.CancelShutdown(); err != nil {
+		log.Warn("Failed to cancel pending shutdowns", "error", err)
+	}
+}
+
+// wakeLead retorna la anticipación de la alarma: la fija de la configuración
+// o, si se aprende, la calculada a partir de los despertares registrados
+func wakeLead(config *entities.Config, events repositories.EventRepository, log logger.Logger) entities.WakeLead {
+	var samples []time.Duration
+	if config.LearnWakeLead && events != nil {
+		history, err := events.List(time.Time{}, time.Time{})
+		if err != nil {
+			log.Warn("Failed to read wake-to-ready measurements, using the fixed lead", "error", err)
+		} else {
+			samples = entities.WakeReadySamples(history)
+		}
+	}
+	return config.WakeLead(samples)
+}
+
+// effectiveSchedule retorna el próximo despertar y apagado del horario diario
+// (o del perfil que entre en vigencia antes) con las excepciones aplicadas
+func effectiveSchedule(config *entities.Config, overrides *entities.ScheduleOverrides) (time.Time, time.Time, error) {
+	schedule, err := config.UpcomingSchedule()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	wake, shutdown := overrides.Apply(schedule.WakeTime, schedule.ShutdownTime)
+	return wake, shutdown, nil
+}
\ No newline at end of file
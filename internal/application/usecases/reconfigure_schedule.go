@@ -0,0 +1,143 @@
+// internal/application/usecases/reconfigure_schedule.go
+package usecases
+
+import (
+	"fmt"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type ReconfigureScheduleInput struct {
+	WakeTime     string
+	ShutdownTime string
+	// Rollback restaura la configuración anterior al último cambio
+	Rollback bool
+	By       string
+}
+
+type ReconfigureScheduleOutput struct {
+	WakeTime         string
+	ShutdownTime     string
+	PreviousWake     string
+	PreviousShutdown string
+	// Rearmed indica si se volvió a armar la alarma (solo con el servicio habilitado)
+	Rearmed  bool
+	Degraded bool
+	Message  string
+}
+
+// ReconfigureScheduleUseCase cambia el horario de un servicio ya instalado sin
+// reinstalarlo: guarda la configuración anterior, reemplaza los apagados
+// pendientes y vuelve a armar la alarma RTC.
+type ReconfigureScheduleUseCase struct {
+	configRepo    repositories.ConfigRepository
+	backupRepo    repositories.ConfigBackupRepository
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	overrideRepo  repositories.OverrideRepository
+	eventRepo     repositories.EventRepository
+	logger        logger.Logger
+}
+
+func NewReconfigureScheduleUseCase(
+	config repositories.ConfigRepository,
+	backup repositories.ConfigBackupRepository,
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	overrides repositories.OverrideRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *ReconfigureScheduleUseCase {
+	return &ReconfigureScheduleUseCase{
+		configRepo:    config,
+		backupRepo:    backup,
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		overrideRepo:  overrides,
+		eventRepo:     events,
+		logger:        log,
+	}
+}
+
+func (uc *ReconfigureScheduleUseCase) Execute(input *ReconfigureScheduleInput) (*ReconfigureScheduleOutput, error) {
+	current, err := uc.configRepo.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ReconfigureScheduleOutput{
+		PreviousWake:     current.WakeTime,
+		PreviousShutdown: current.ShutdownTime,
+	}
+
+	var updated *entities.Config
+	if input.Rollback {
+		if uc.backupRepo == nil {
+			return nil, fmt.Errorf("configuration backups are not available")
+		}
+		if updated, err = uc.backupRepo.LoadBackup(); err != nil {
+			return nil, err
+		}
+		if err := updated.Validate(); err != nil {
+			return nil, fmt.Errorf("previous configuration is invalid: %w", err)
+		}
+		updated.Update()
+	} else {
+		// Validar el nuevo horario antes de tocar nada (sobre una copia: los
+		// horarios solares necesitan la ubicación configurada)
+		copied := *current
+		copied.Profiles = append([]entities.Profile(nil), current.Profiles...)
+		updated = &copied
+		updated.SetSchedule(input.WakeTime, input.ShutdownTime)
+		if err := updated.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// La configuración actual queda como respaldo: un rollback se puede deshacer
+	if uc.backupRepo != nil {
+		if err := uc.backupRepo.SaveBackup(current); err != nil {
+			uc.logger.Error("Failed to back up configuration", "error", err)
+			return nil, err
+		}
+	}
+	if err := uc.configRepo.Save(updated); err != nil {
+		uc.logger.Error("Failed to save configuration", "error", err)
+		return nil, err
+	}
+
+	action := "set_schedule"
+	if input.Rollback {
+		action = "rollback"
+	}
+	uc.logger.Info("Schedule reconfigured", "action", action,
+		"wake_time", updated.WakeTime, "shutdown_time", updated.ShutdownTime,
+		"previous_wake", current.WakeTime, "previous_shutdown", current.ShutdownTime, "by", input.By)
+	recordEvent(uc.eventRepo, uc.logger, entities.EventConfigChanged, "Schedule reconfigured",
+		"action", action, "wake_time", updated.WakeTime, "shutdown_time", updated.ShutdownTime,
+		"previous_wake", current.WakeTime, "previous_shutdown", current.ShutdownTime, "by", input.By)
+
+	output.WakeTime = updated.WakeTime
+	output.ShutdownTime = updated.ShutdownTime
+	output.Message = fmt.Sprintf("Schedule changed from %s-%s to %s-%s",
+		current.WakeTime, current.ShutdownTime, updated.WakeTime, updated.ShutdownTime)
+
+	if !updated.Enabled {
+		output.Message += " (service disabled, applies when enabled)"
+		return output, nil
+	}
+
+	// Reemplazar los apagados pendientes y volver a armar la alarma
+	armer := newScheduleArmer(uc.rtcRepo, uc.schedulerRepo, uc.overrideRepo, uc.eventRepo)
+	armed, err := armer.arm(uc.logger, updated, "reconfigure", true)
+	if err != nil {
+		return nil, fmt.Errorf("configuration saved but re-arming failed: %w", err)
+	}
+
+	output.Rearmed = true
+	output.Degraded = armed.Degraded
+	output.Message += ", next wake " + armed.describeWake()
+	return output, nil
+}
\ No newline at end of file
@@ -2,27 +2,52 @@
 package usecases
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"os"
+	"strconv"
+	"time"
 
+	"rtc-scheduler/internal/domain/entities"
 	"rtc-scheduler/internal/domain/repositories"
-	"rtc-scheduler/internal/infrastructure/scheduler"
 	"rtc-scheduler/pkg/logger"
 )
 
 type RunServiceInput struct{}
 
 type RunServiceOutput struct {
-	Executed     bool
-	Message      string
+	Executed bool
+	Message  string
+	RunID    string
+	Degraded bool
+	Backend  string
+
+	// peers son los equipos a despertar con WakePeers, fuera del lock
+	peers []entities.WakePeer
 }
 
+// Resultados posibles de una ejecución del servicio (campo "result" del resumen)
+const (
+	runResultSuccess  = "success"
+	runResultDegraded = "degraded"
+	runResultSkipped  = "skipped"
+	runResultFailed   = "failed"
+)
+
 // RunServiceUseCase maneja la ejecución desde el servicio systemd
 type RunServiceUseCase struct {
 	configRepo    repositories.ConfigRepository
 	rtcRepo       repositories.RTCRepository
 	schedulerRepo repositories.SchedulerRepository
+	eventRepo     repositories.EventRepository
+	powerRepo     repositories.PowerStateRepository
+	wakeStateRepo repositories.WakeStateRepository
+	wolRepo       repositories.WakeOnLANRepository
+	upsRepo       repositories.UPSRepository
+	overrideRepo  repositories.OverrideRepository
+	bundleRepo    repositories.StateBundleRepository
+	notifierRepo  repositories.NotifierRepository
 	logger        logger.Logger
 }
 
@@ -30,131 +55,525 @@ func NewRunServiceUseCase(
 	config repositories.ConfigRepository,
 	rtc repositories.RTCRepository,
 	scheduler repositories.SchedulerRepository,
+	events repositories.EventRepository,
+	power repositories.PowerStateRepository,
+	wakeState repositories.WakeStateRepository,
+	wol repositories.WakeOnLANRepository,
+	ups repositories.UPSRepository,
+	overrides repositories.OverrideRepository,
+	bundles repositories.StateBundleRepository,
+	notifier repositories.NotifierRepository,
 	log logger.Logger,
 ) *RunServiceUseCase {
 	return &RunServiceUseCase{
 		configRepo:    config,
 		rtcRepo:       rtc,
 		schedulerRepo: scheduler,
+		eventRepo:     events,
+		powerRepo:     power,
+		wakeStateRepo: wakeState,
+		wolRepo:       wol,
+		upsRepo:       ups,
+		overrideRepo:  overrides,
+		bundleRepo:    bundles,
+		notifierRepo:  notifier,
 		logger:        log,
 	}
 }
 
+// Execute corre una ejecución completa del servicio. Todos los mensajes llevan
+// el mismo run_id y la ejecución termina con un único registro de resumen.
 func (uc *RunServiceUseCase) Execute(input *RunServiceInput) (*RunServiceOutput, error) {
-	uc.logger.Info("Running service execution")
+	runID := newRunID()
+	log := logger.With(uc.logger, "run_id", runID)
+	started := time.Now()
+
+	log.Info("Running service execution")
+
+	output, err := uc.run(log)
+
+	result := runResultFailed
+	summary := &RunServiceOutput{Backend: "none"}
+	if output != nil {
+		summary = output
+		switch {
+		case !output.Executed:
+			result = runResultSkipped
+		case output.Degraded:
+			result = runResultDegraded
+		default:
+			result = runResultSuccess
+		}
+	}
+	summary.RunID = runID
+
+	args := []interface{}{
+		"result", result,
+		"degraded", summary.Degraded,
+		"backend", summary.Backend,
+		"duration", time.Since(started).Round(time.Millisecond),
+	}
+	if err != nil {
+		log.Error("Service run finished", append(args, "error", err)...)
+		recordEventWithLevel(uc.eventRepo, log, entities.EventError, entities.EventLevelError,
+			"Service run failed", "run_id", runID, "error", err)
+		return nil, err
+	}
+	log.Info("Service run finished", append(args, "message", summary.Message)...)
+
+	return summary, nil
+}
+
+// run ejecuta los pasos del servicio; la salida nunca es nil cuando err es nil
+func (uc *RunServiceUseCase) run(log logger.Logger) (*RunServiceOutput, error) {
+	// Registrar si venimos de un arranque o de una reanudación y, en ese caso,
+	// verificar el despertar antes de que se arme la próxima alarma
+	step := time.Now()
+	var verification *entities.WakeVerification
+	if wakeEvent := uc.recordPowerState(log); wakeEvent != nil {
+		verification = uc.verifyWake(log, wakeEvent)
+	}
+	logStep(log, "power_state", step)
+
+	// En el primer arranque, instalar la semilla dejada en la partición de arranque
+	uc.importSeed(log)
 
 	// Validación inicial de dependencias
-	fmt.Fprintf(os.Stderr, "DEBUG: Validating dependencies...\n")
+	step = time.Now()
 	if !uc.rtcRepo.IsAvailable() {
-		errMsg := "RTC device is not available"
-		fmt.Fprintf(os.Stderr, "ERROR: %s\n", errMsg)
-		return nil, fmt.Errorf(errMsg)
+		return nil, fmt.Errorf("RTC device is not available")
 	}
-	fmt.Fprintf(os.Stderr, "DEBUG: RTC device is available\n")
-
 	if !uc.schedulerRepo.IsAvailable() {
-		errMsg := "Scheduler (at command) is not available"
-		fmt.Fprintf(os.Stderr, "ERROR: %s\n", errMsg)
-		return nil, fmt.Errorf(errMsg)
+		return nil, fmt.Errorf("shutdown scheduler (at or systemd-run) is not available")
 	}
-	fmt.Fprintf(os.Stderr, "DEBUG: Scheduler is available\n")
+	logStep(log, "dependencies", step)
 
 	// Verificar que haya configuración
 	if !uc.configRepo.Exists() {
-		errMsg := "No configuration found, skipping service execution"
-		uc.logger.Warn(errMsg)
-		// Also write to stderr for systemd logging
-		fmt.Fprintf(os.Stderr, "ERROR: %s\n", errMsg)
+		log.Warn("No configuration found, skipping service execution")
+		if output, err := uc.armOneOffs(log); output != nil || err != nil {
+			return output, err
+		}
 		return &RunServiceOutput{
 			Executed: false,
 			Message:  "No configuration found",
+			Backend:  "none",
 		}, nil
 	}
 
 	// Cargar configuración
-	fmt.Fprintf(os.Stderr, "DEBUG: Loading configuration...\n")
+	step = time.Now()
 	config, err := uc.configRepo.Load()
 	if err != nil {
-		errMsg := fmt.Sprintf("Failed to load configuration: %v", err)
-		uc.logger.Error("Failed to load configuration", "error", err)
-		fmt.Fprintf(os.Stderr, "ERROR: %s\n", errMsg)
+		log.Error("Failed to load configuration", "error", err)
 		return nil, err
 	}
-	fmt.Fprintf(os.Stderr, "DEBUG: Configuration loaded successfully\n")
+	logStep(log, "load_config", step)
+
+	// Activar los perfiles cuya fecha de cambio ya llegó
+	config = uc.applyProfileSwitches(log, config)
+
+	// Aplicar la política de retención del historial
+	step = time.Now()
+	uc.compactHistory(log, config)
+	logStep(log, "compact_history", step)
 
 	// Verificar que esté habilitado
 	if !config.Enabled {
-		msg := "Service is disabled, skipping execution"
-		uc.logger.Info(msg)
-		fmt.Fprintf(os.Stderr, "INFO: %s\n", msg)
+		log.Info("Service is disabled, skipping execution")
+		if output, err := uc.armOneOffs(log); output != nil || err != nil {
+			return output, err
+		}
 		return &RunServiceOutput{
 			Executed: false,
 			Message:  "Service is disabled",
+			Backend:  "none",
 		}, nil
 	}
 
-	// Convertir configuración a schedule
-	fmt.Fprintf(os.Stderr, "DEBUG: Parsing schedule from config...\n")
-	schedule, err := config.ParseToSchedule()
+	// Sin energía de red o con la batería baja, volver a suspender en lugar de
+	// aceptar el despertar programado
+	step = time.Now()
+	if output := uc.refuseWakeOnBattery(log, config, verification); output != nil {
+		return output, nil
+	}
+	logStep(log, "ups_check", step)
+
+	// Armar el próximo ciclo (alarma RTC y apagado), con las excepciones vigentes
+	armer := newScheduleArmer(uc.rtcRepo, uc.schedulerRepo, uc.overrideRepo, uc.eventRepo)
+	armed, err := armer.arm(log, config, "service", false)
 	if err != nil {
-		errMsg := fmt.Sprintf("Failed to parse schedule from config: %v", err)
-		uc.logger.Error("Failed to parse schedule from config", "error", err)
-		fmt.Fprintf(os.Stderr, "ERROR: %s\n", errMsg)
 		return nil, err
 	}
-	fmt.Fprintf(os.Stderr, "DEBUG: Schedule parsed successfully\n")
 
-	fmt.Fprintf(os.Stderr, "DEBUG: Executing schedule - Wake: %s, Shutdown: %s\n",
-		schedule.WakeTime.Format("2006-01-02 15:04:05"),
-		schedule.ShutdownTime.Format("2006-01-02 15:04:05"))
+	peers := uc.peersToWake(log, config, verification)
+
+	if armed.Degraded {
+		return &RunServiceOutput{
+			Executed: true,
+			Message:  "RTC wake alarm configured (shutdown scheduling unavailable due to read-only filesystem)",
+			Degraded: true,
+			Backend:  armed.Backend,
+			peers:    peers,
+		}, nil
+	}
+
+	return &RunServiceOutput{
+		Executed: true,
+		Message:  "Schedule configured successfully",
+		Backend:  armed.Backend,
+		peers:    peers,
+	}, nil
+}
 
-	uc.logger.Info("Executing schedule",
-		"wake_time", schedule.WakeTime,
-		"shutdown_time", schedule.ShutdownTime,
-	)
+// WakePeers despierta los peers de Wake-on-LAN que dejó pendientes Execute.
+// Se llama después de soltar el lock de comandos: las esperas entre peers
+// pueden sumar minutos y no deben bloquear a los demás comandos.
+func (uc *RunServiceUseCase) WakePeers(output *RunServiceOutput) {
+	if output == nil || len(output.peers) == 0 {
+		return
+	}
 
-	// Configurar alarma RTC
-	fmt.Fprintf(os.Stderr, "DEBUG: Setting RTC wake alarm...\n")
-	if err := uc.rtcRepo.SetWakeAlarm(schedule.WakeTime); err != nil {
-		errMsg := fmt.Sprintf("Failed to set RTC wake alarm: %v", err)
-		uc.logger.Error("Failed to set RTC wake alarm", "error", err)
-		fmt.Fprintf(os.Stderr, "ERROR: %s\n", errMsg)
+	log := logger.With(uc.logger, "run_id", output.RunID)
+	step := time.Now()
+	result, err := wakePeers(output.peers, uc.wolRepo, uc.eventRepo, log, "service")
+	if err != nil {
+		log.Warn("Failed to wake peers", "error", err)
+		return
+	}
+	logStep(log, "wake_peers", step, "woken", len(result.Woken), "failed", len(result.Failed), "skipped", len(result.Skipped))
+}
+
+// armOneOffs arma la cola de ventanas únicas cuando no hay horario diario que
+// armar. Retorna nil si la cola está vacía.
+func (uc *RunServiceUseCase) armOneOffs(log logger.Logger) (*RunServiceOutput, error) {
+	armer := newScheduleArmer(uc.rtcRepo, uc.schedulerRepo, uc.overrideRepo, uc.eventRepo)
+	armed, err := armer.arm(log, nil, "service", false)
+	if errors.Is(err, errNothingToArm) {
+		return nil, nil
+	}
+	if err != nil {
 		return nil, err
 	}
-	fmt.Fprintf(os.Stderr, "DEBUG: RTC wake alarm set successfully\n")
-
-	// Programar apagado
-	fmt.Fprintf(os.Stderr, "DEBUG: Scheduling shutdown...\n")
-	if err := uc.schedulerRepo.ScheduleShutdown(schedule.ShutdownTime); err != nil {
-		// Verificar si es un error de filesystem read-only (modo degradado)
-		if errors.Is(err, scheduler.ErrFilesystemReadOnly) {
-			// Modo degradado: RTC funciona, pero shutdown no se programa
-			warnMsg := "Filesystem is read-only, operating in degraded mode: RTC wake alarm configured but shutdown scheduling unavailable"
-			uc.logger.Warn(warnMsg, "error", err)
-			fmt.Fprintf(os.Stderr, "WARNING: %s\n", warnMsg)
-			fmt.Fprintf(os.Stderr, "INFO: RTC wake alarm remains configured for automatic startup\n")
-
-			uc.logger.Info("Service execution completed in degraded mode (RTC only)")
-
-			return &RunServiceOutput{
-				Executed: true,
-				Message:  "RTC wake alarm configured (shutdown scheduling unavailable due to read-only filesystem)",
-			}, nil
+	return &RunServiceOutput{
+		Executed: true,
+		Message:  "Queued one-off schedule configured",
+		Degraded: armed.Degraded,
+		Backend:  armed.Backend,
+	}, nil
+}
+
+// importSeed instala la semilla de primer arranque si está presente y la
+// retira para no volver a importarla. Una semilla inválida no cambia nada: se
+// renombra a .rejected y el servicio sigue con la configuración que hubiera.
+func (uc *RunServiceUseCase) importSeed(log logger.Logger) {
+	if uc.bundleRepo == nil {
+		return
+	}
+	path, ok := uc.bundleRepo.FindSeed()
+	if !ok {
+		return
+	}
+
+	step := time.Now()
+	bundle, problems, err := readStateBundle(uc.bundleRepo, path, time.Now())
+	if err != nil {
+		// Probablemente la partición no se pueda leer: se reintenta en el próximo arranque
+		log.Error("Failed to read seed configuration", "path", path, "error", err)
+		return
+	}
+
+	imported := false
+	if len(problems) > 0 {
+		log.Error("Seed configuration rejected", "path", path, "problems", problemList(problems))
+		recordEventWithLevel(uc.eventRepo, log, entities.EventError, entities.EventLevelError,
+			"Seed configuration rejected", "path", path, "problems", problemList(problems))
+	} else if err := installStateBundle(uc.configRepo, uc.overrideRepo, uc.eventRepo, log, bundle, "seed", "seed"); err != nil {
+		log.Error("Failed to install seed configuration", "path", path, "error", err)
+		recordEventWithLevel(uc.eventRepo, log, entities.EventError, entities.EventLevelError,
+			"Seed configuration could not be installed", "path", path, "error", err)
+	} else {
+		imported = true
+	}
+
+	retired, err := uc.bundleRepo.RetireSeed(imported)
+	if err != nil {
+		log.Warn("Failed to move seed configuration aside, it will be read again on next run", "path", path, "error", err)
+	} else if retired != "" {
+		log.Info("Seed configuration moved aside", "path", retired)
+	} else {
+		log.Info("Seed configuration removed", "path", path)
+	}
+	logStep(log, "seed", step, "imported", imported)
+}
+
+// logStep registra la duración de un paso de la ejecución
+func logStep(log logger.Logger, name string, started time.Time, args ...interface{}) {
+	args = append([]interface{}{"step", name, "duration", time.Since(started).Round(time.Microsecond)}, args...)
+	log.Debug("Step completed", args...)
+}
+
+// newRunID genera un identificador corto y aleatorio para correlacionar los
+// mensajes de una misma ejecución
+func newRunID() string {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(buf)
+}
+
+// recordPowerState registra un evento "booted" la primera vez que el servicio corre
+// tras un arranque, y un evento "resumed" cuando el contador de suspensiones del
+// kernel avanzó desde el último evento registrado en este mismo arranque.
+// Retorna el evento registrado, o nil si esta ejecución no corresponde a un despertar.
+func (uc *RunServiceUseCase) recordPowerState(log logger.Logger) *entities.PowerEvent {
+	if uc.powerRepo == nil || uc.eventRepo == nil {
+		return nil
+	}
+
+	bootID, err := uc.powerRepo.BootID()
+	if err != nil {
+		log.Warn("Failed to determine boot ID", "error", err)
+		return nil
+	}
+	bootTime, err := uc.powerRepo.BootTime()
+	if err != nil {
+		log.Debug("Boot time not available", "error", err)
+		bootTime = time.Now()
+	}
+
+	// Si no se puede leer el contador, solo se detectan arranques
+	suspendCount, err := uc.powerRepo.SuspendCount()
+	if err != nil {
+		log.Debug("Suspend statistics not available", "error", err)
+		suspendCount = 0
+	}
+
+	// Todo el historial: btime se mueve si NTP ajusta el reloj tras arrancar y
+	// un filtro por fecha podría dejar fuera el evento de este mismo arranque
+	events, err := uc.eventRepo.List(time.Time{}, time.Time{})
+	if err != nil {
+		log.Warn("Failed to read event history", "error", err)
+		return nil
+	}
+
+	var last *entities.PowerEvent
+	for _, event := range events {
+		if (event.Type == entities.EventBooted || event.Type == entities.EventResumed) &&
+			event.Fields["boot_id"] == bootID {
+			last = event
 		}
+	}
 
-		// Para otros errores, limpiar alarma y fallar
-		uc.rtcRepo.ClearWakeAlarm()
-		errMsg := fmt.Sprintf("Failed to schedule shutdown: %v", err)
-		uc.logger.Error("Failed to schedule shutdown", "error", err)
-		fmt.Fprintf(os.Stderr, "ERROR: %s\n", errMsg)
-		return nil, err
+	fields := map[string]string{
+		"boot_id":       bootID,
+		"boot_time":     bootTime.Format(time.RFC3339),
+		"suspend_count": strconv.Itoa(suspendCount),
+	}
+
+	if last == nil {
+		event := entities.NewPowerEvent(entities.EventBooted, "System booted", fields)
+		event.Timestamp = bootTime
+		appendEvent(uc.eventRepo, log, event)
+		return event
+	}
+
+	lastCount, _ := strconv.Atoi(last.Fields["suspend_count"])
+	if suspendCount > lastCount {
+		// La unidad de reanudación corre el servicio al volver, así que "ahora" aproxima la hora de reanudación
+		event := entities.NewPowerEvent(entities.EventResumed, "System resumed from suspend", fields)
+		appendEvent(uc.eventRepo, log, event)
+		return event
+	}
+
+	return nil
+}
+
+// verifyWake compara la alarma que estaba armada con el despertar real y
+// registra el resultado. Una alarma perdida genera un evento de advertencia.
+// Retorna nil si no había una alarma armada que verificar.
+func (uc *RunServiceUseCase) verifyWake(log logger.Logger, wakeEvent *entities.PowerEvent) *entities.WakeVerification {
+	if uc.wakeStateRepo == nil {
+		return nil
+	}
+
+	armed, err := uc.wakeStateRepo.LoadArmedWake()
+	if err != nil {
+		log.Warn("Failed to load armed wake time", "error", err)
+		return nil
+	}
+	if armed == nil {
+		log.Debug("No armed wake alarm recorded, skipping wake verification")
+		return nil
+	}
+
+	kind := entities.WakeKindBoot
+	var source *entities.WakeSource
+	if wakeEvent.Type == entities.EventResumed {
+		kind = entities.WakeKindResume
+		// Tras un arranque en frío el kernel no sabe qué encendió el equipo
+		source, err = uc.powerRepo.LastWakeSource()
+		if err != nil {
+			log.Debug("Failed to read wakeup sources", "error", err)
+		}
+	}
+
+	verification := entities.VerifyWake(armed, kind, wakeEvent.Timestamp, source,
+		entities.DefaultWakeTolerance, entities.DefaultLateWindow)
+
+	// La alarma ya fue evaluada; no volver a verificarla en la próxima ejecución
+	if err := uc.wakeStateRepo.ClearArmedWake(); err != nil {
+		log.Warn("Failed to clear armed wake record", "error", err)
+	}
+
+	sourceName := "unknown"
+	if source != nil {
+		sourceName = source.Name
+	}
+	args := []interface{}{
+		"outcome", verification.Outcome,
+		"kind", verification.Kind,
+		"armed_for", verification.ArmedFor.Format(time.RFC3339),
+		"woke_at", verification.WokeAt.Format(time.RFC3339),
+		"offset_seconds", int64(verification.Offset.Round(time.Second).Seconds()),
+		"source", sourceName,
 	}
-	fmt.Fprintf(os.Stderr, "DEBUG: Shutdown scheduled successfully\n")
 
-	uc.logger.Info("Service execution completed successfully")
+	if verification.Outcome == entities.WakeMissed {
+		log.Warn("Scheduled wake was missed", args...)
+		recordEventWithLevel(uc.eventRepo, log, entities.EventWakeMissed, entities.EventLevelWarning,
+			"Scheduled wake missed: "+verification.Summary(), args...)
+		return verification
+	}
+
+	log.Info("Wake verified", args...)
+	recordEvent(uc.eventRepo, log, entities.EventWakeDetected, "Wake detected: "+verification.Summary(), args...)
+	return verification
+}
+
+// FlushNotifications reintenta las notificaciones encoladas cuyo reintento
+// venció. Como WakePeers, se llama sin el lock de comandos: cada webhook que no
+// responde cuesta un timeout.
+func (uc *RunServiceUseCase) FlushNotifications(output *RunServiceOutput) {
+	if uc.notifierRepo == nil || output == nil {
+		return
+	}
+
+	log := logger.With(uc.logger, "run_id", output.RunID)
+	step := time.Now()
+	if err := uc.notifierRepo.Flush(); err != nil {
+		log.Warn("Failed to deliver queued notifications", "error", err)
+	}
+	logStep(log, "flush_notifications", step)
+}
+
+// peersToWake retorna los peers de Wake-on-LAN a despertar cuando este
+// arranque fue producido por la alarma RTC (puntual o con retraso)
+func (uc *RunServiceUseCase) peersToWake(log logger.Logger, config *entities.Config, verification *entities.WakeVerification) []entities.WakePeer {
+	if uc.wolRepo == nil || len(config.WakePeers) == 0 || verification == nil {
+		return nil
+	}
+	if verification.Outcome != entities.WakeOnTime && verification.Outcome != entities.WakeLate {
+		log.Debug("Not an RTC wake, skipping wake peers", "outcome", verification.Outcome)
+		return nil
+	}
+	return config.WakePeers
+}
+
+// refuseWakeOnBattery consulta la UPS tras un despertar por alarma RTC. Si la
+// energía o la batería no se recuperaron, programa un nuevo apagado y otro
+// intento de despertar, y retorna la salida de la ejecución; si no, retorna nil.
+// Si la UPS no responde, el despertar se acepta.
+func (uc *RunServiceUseCase) refuseWakeOnBattery(log logger.Logger, config *entities.Config, verification *entities.WakeVerification) *RunServiceOutput {
+	if uc.upsRepo == nil || config.UPS == nil || verification == nil {
+		return nil
+	}
+	if verification.Outcome != entities.WakeOnTime && verification.Outcome != entities.WakeLate {
+		return nil
+	}
+
+	status, err := uc.upsRepo.Status(config.UPS)
+	if err != nil {
+		log.Warn("Failed to query UPS, accepting wake", "ups", config.UPS.Name, "error", err)
+		return nil
+	}
+
+	allowed, reason := config.UPS.WakeAllowed(status)
+	if allowed {
+		log.Debug("UPS allows wake", "ups", config.UPS.Name, "status", status.String())
+		return nil
+	}
+
+	if err := suspendUntilRecovery(uc.rtcRepo, uc.schedulerRepo, uc.eventRepo, log,
+		config.UPS, entities.EventUPSWakeRefused, "Scheduled wake refused: "+reason, status); err != nil {
+		// Sin poder reprogramar, es preferible quedar encendido
+		return nil
+	}
 
 	return &RunServiceOutput{
 		Executed: true,
-		Message:  "Schedule configured successfully",
-	}, nil
+		Message:  fmt.Sprintf("Wake refused (%s), suspending until %s", reason, time.Now().Add(config.UPS.RecoveryWake()).Format("15:04")),
+		Degraded: true,
+		Backend:  uc.schedulerRepo.Backend(),
+	}
+}
+
+// compactHistory elimina eventos según la retención configurada
+func (uc *RunServiceUseCase) compactHistory(log logger.Logger, config *entities.Config) {
+	if uc.eventRepo == nil {
+		return
+	}
+
+	maxAge, maxEvents := config.HistoryRetention()
+	removed, err := uc.eventRepo.Compact(maxAge, maxEvents)
+	if err != nil {
+		log.Warn("Failed to compact event history", "error", err)
+		return
+	}
+	if removed > 0 {
+		log.Info("Event history compacted", "removed", removed)
+	}
+}
+
+// applyProfileSwitches activa los cambios de perfil vencidos y guarda la
+// configuración. Si no se puede guardar (p. ej. un fragmento fija el horario)
+// el cambio no se aplica: retorna la configuración guardada, sin el perfil
+func (uc *RunServiceUseCase) applyProfileSwitches(log logger.Logger, config *entities.Config) *entities.Config {
+	now := time.Now()
+	previous := config.ActiveProfile
+	applied, err := config.ApplyDueProfileSwitches(now)
+	if err != nil {
+		log.Warn("Failed to apply scheduled profile switch", "error", err)
+	}
+	if applied == nil {
+		return config
+	}
+
+	if err := uc.configRepo.Save(config); err != nil {
+		log.Error("Failed to save configuration after profile switch", "profile", applied.Profile, "error", err)
+		recordEventWithLevel(uc.eventRepo, log, entities.EventError, entities.EventLevelError,
+			"Scheduled profile switch could not be applied", "profile", applied.Profile, "previous", previous,
+			"date", applied.At.Format("2006-01-02"), "error", err)
+
+		saved, err := uc.configRepo.Load()
+		if err != nil {
+			log.Error("Failed to reload configuration", "error", err)
+			return config
+		}
+		// El cambio queda pendiente en el archivo (se reintenta en cada corrida),
+		// pero este ciclo se arma con el horario guardado
+		pending := saved.ProfileSwitches[:0]
+		for _, s := range saved.ProfileSwitches {
+			if s.At.After(now) {
+				pending = append(pending, s)
+			}
+		}
+		saved.ProfileSwitches = pending
+		return saved
+	}
+	recordEvent(uc.eventRepo, log, entities.EventProfileSwitched, "Scheduled profile switch applied",
+		"profile", applied.Profile, "previous", previous, "date", applied.At.Format("2006-01-02"),
+		"wake_time", config.WakeTime, "shutdown_time", config.ShutdownTime, "by", "schedule")
+	return config
 }
\ No newline at end of file
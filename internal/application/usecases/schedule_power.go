@@ -3,6 +3,7 @@ package usecases
 
 import (
 	"fmt"
+	"time"
 
 	"rtc-scheduler/internal/domain/entities"
 	"rtc-scheduler/internal/domain/repositories"
@@ -10,32 +11,46 @@ import (
 )
 
 type SchedulePowerInput struct {
+	// WakeTime y ShutdownTime son HH:MM (próxima ocurrencia) o una fecha con
+	// hora ("2026-12-24 06:00" o RFC 3339), que se agrega a la cola de ventanas únicas
 	WakeTime     string
 	ShutdownTime string
 	TestMode     bool
+	By           string
 }
 
 type SchedulePowerOutput struct {
-	Schedule     *entities.Schedule
-	Message      string
-	TestMode     bool
+	Schedule *entities.Schedule
+	// OneOff es la ventana agregada a la cola (nil con HH:MM o en modo test)
+	OneOff   *entities.OneOff
+	Message  string
+	TestMode bool
 }
 
 // SchedulePowerUseCase maneja la programación de encendido/apagado
 type SchedulePowerUseCase struct {
+	configRepo    repositories.ConfigRepository
 	rtcRepo       repositories.RTCRepository
 	schedulerRepo repositories.SchedulerRepository
+	overrideRepo  repositories.OverrideRepository
+	eventRepo     repositories.EventRepository
 	logger        logger.Logger
 }
 
 func NewSchedulePowerUseCase(
+	config repositories.ConfigRepository,
 	rtc repositories.RTCRepository,
 	scheduler repositories.SchedulerRepository,
+	overrides repositories.OverrideRepository,
+	events repositories.EventRepository,
 	log logger.Logger,
 ) *SchedulePowerUseCase {
 	return &SchedulePowerUseCase{
+		configRepo:    config,
 		rtcRepo:       rtc,
 		schedulerRepo: scheduler,
+		overrideRepo:  overrides,
+		eventRepo:     events,
 		logger:        log,
 	}
 }
@@ -47,9 +62,18 @@ func (uc *SchedulePowerUseCase) Execute(input *SchedulePowerInput) (*SchedulePow
 		"test_mode", input.TestMode,
 	)
 
-	// Crear configuración
-	config, err := entities.NewConfig(input.WakeTime, input.ShutdownTime, true)
-	if err != nil {
+	if entities.IsDateTime(input.WakeTime) || entities.IsDateTime(input.ShutdownTime) {
+		return uc.scheduleDateTime(input)
+	}
+
+	// Crear configuración; los horarios solares usan la ubicación configurada
+	config := &entities.Config{WakeTime: input.WakeTime, ShutdownTime: input.ShutdownTime, Enabled: true}
+	if uc.configRepo.Exists() {
+		if stored, err := uc.configRepo.Load(); err == nil {
+			config.Location = stored.Location
+		}
+	}
+	if err := config.Validate(); err != nil {
 		uc.logger.Error("Invalid configuration", "error", err)
 		return nil, fmt.Errorf("invalid time configuration: %w", err)
 	}
@@ -61,22 +85,90 @@ func (uc *SchedulePowerUseCase) Execute(input *SchedulePowerInput) (*SchedulePow
 		return nil, fmt.Errorf("failed to parse schedule: %w", err)
 	}
 
+	return uc.armOnce(schedule, input.TestMode)
+}
+
+// scheduleDateTime agrega una ventana en fechas concretas a la cola y re-arma
+// combinándola con el horario diario. En modo test se arma directamente, sin
+// guardarla.
+func (uc *SchedulePowerUseCase) scheduleDateTime(input *SchedulePowerInput) (*SchedulePowerOutput, error) {
+	if !entities.IsDateTime(input.WakeTime) || !entities.IsDateTime(input.ShutdownTime) {
+		return nil, entities.ErrMixedTimeFormats
+	}
+
+	wakeAt, err := entities.ParseDateTime(input.WakeTime)
+	if err != nil {
+		return nil, err
+	}
+	shutdownAt, err := entities.ParseDateTime(input.ShutdownTime)
+	if err != nil {
+		return nil, err
+	}
+	oneOff, err := entities.NewOneOff(wakeAt, shutdownAt, time.Now())
+	if err != nil {
+		uc.logger.Error("Invalid one-off schedule", "error", err)
+		return nil, err
+	}
+
+	if input.TestMode {
+		return uc.armOnce(&entities.Schedule{WakeTime: wakeAt, ShutdownTime: shutdownAt, Enabled: true, CreatedAt: time.Now()}, true)
+	}
+
+	// El horario diario sigue vigente si existe y está habilitado
+	var config *entities.Config
+	if uc.configRepo.Exists() {
+		if config, err = uc.configRepo.Load(); err != nil {
+			return nil, err
+		}
+	}
+
+	armer := newScheduleArmer(uc.rtcRepo, uc.schedulerRepo, uc.overrideRepo, uc.eventRepo)
+	overrides := armer.loadOverrides(uc.logger)
+	if overrides == nil {
+		overrides = &entities.ScheduleOverrides{}
+	}
+	oneOff.By = input.By
+	overrides.AddOneOff(oneOff)
+
+	uc.logger.Info("Queueing one-off schedule", "id", oneOff.ID, "wake_time", wakeAt, "shutdown_time", shutdownAt, "by", input.By)
+
+	if err := saveOverridesAndRearm(armer, uc.overrideRepo, uc.eventRepo, uc.logger, config, overrides,
+		"One-off queued", "kind", "one_off", "action", "add", "id", oneOff.ID,
+		"wake_time", wakeAt.Format(time.RFC3339), "shutdown_time", shutdownAt.Format(time.RFC3339), "by", input.By); err != nil {
+		return nil, err
+	}
+
+	return &SchedulePowerOutput{
+		Schedule: &entities.Schedule{WakeTime: wakeAt, ShutdownTime: shutdownAt, Enabled: true, CreatedAt: time.Now()},
+		OneOff:   oneOff,
+		Message:  fmt.Sprintf("One-off #%s queued: %s", oneOff.ID, oneOff),
+	}, nil
+}
+
+// armOnce arma directamente la alarma RTC y el apagado de schedule
+func (uc *SchedulePowerUseCase) armOnce(schedule *entities.Schedule, testMode bool) (*SchedulePowerOutput, error) {
 	// Configurar alarma RTC para encendido
 	if err := uc.rtcRepo.SetWakeAlarm(schedule.WakeTime); err != nil {
 		uc.logger.Error("Failed to set RTC wake alarm", "error", err)
 		return nil, fmt.Errorf("failed to set wake alarm: %w", err)
 	}
+	recordEvent(uc.eventRepo, uc.logger, entities.EventAlarmArmed, "RTC wake alarm armed",
+		"wake_time", schedule.WakeTime.Format(time.RFC3339), "source", "manual")
 
 	// Programar apagado
 	if err := uc.schedulerRepo.ScheduleShutdown(schedule.ShutdownTime); err != nil {
 		// Si falla, limpiar la alarma RTC
-		uc.rtcRepo.ClearWakeAlarm()
+		if uc.rtcRepo.ClearWakeAlarm() == nil {
+			recordEvent(uc.eventRepo, uc.logger, entities.EventAlarmCleared, "RTC wake alarm cleared after scheduling failure", "source", "manual")
+		}
 		uc.logger.Error("Failed to schedule shutdown", "error", err)
 		return nil, fmt.Errorf("failed to schedule shutdown: %w", err)
 	}
+	recordEvent(uc.eventRepo, uc.logger, entities.EventShutdownScheduled, "Shutdown scheduled",
+		"shutdown_time", schedule.ShutdownTime.Format(time.RFC3339), "source", "manual", "test_mode", testMode)
 
 	mode := "production"
-	if input.TestMode {
+	if testMode {
 		mode = "test"
 	}
 
@@ -85,6 +177,6 @@ func (uc *SchedulePowerUseCase) Execute(input *SchedulePowerInput) (*SchedulePow
 	return &SchedulePowerOutput{
 		Schedule: schedule,
 		Message:  fmt.Sprintf("Power scheduling configured successfully (%s mode)", mode),
-		TestMode: input.TestMode,
+		TestMode: testMode,
 	}, nil
 }
\ No newline at end of file
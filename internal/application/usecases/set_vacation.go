@@ -0,0 +1,102 @@
+// internal/application/usecases/set_vacation.go
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type SetVacationInput struct {
+	// From y To son fechas YYYY-MM-DD (ambas incluidas)
+	From string
+	To   string
+	// Cancel termina las vacaciones configuradas
+	Cancel bool
+	By     string
+}
+
+type SetVacationOutput struct {
+	Vacation *entities.Vacation
+	Message  string
+}
+
+// SetVacationUseCase mantiene el equipo apagado durante un rango de fechas.
+// Los despertares dentro del rango se omiten y el horario diario vuelve solo
+// al terminar.
+type SetVacationUseCase struct {
+	configRepo    repositories.ConfigRepository
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	overrideRepo  repositories.OverrideRepository
+	eventRepo     repositories.EventRepository
+	logger        logger.Logger
+}
+
+func NewSetVacationUseCase(
+	config repositories.ConfigRepository,
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	overrides repositories.OverrideRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *SetVacationUseCase {
+	return &SetVacationUseCase{
+		configRepo:    config,
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		overrideRepo:  overrides,
+		eventRepo:     events,
+		logger:        log,
+	}
+}
+
+func (uc *SetVacationUseCase) Execute(input *SetVacationInput) (*SetVacationOutput, error) {
+	config, err := uc.configRepo.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	armer := newScheduleArmer(uc.rtcRepo, uc.schedulerRepo, uc.overrideRepo, uc.eventRepo)
+	overrides := armer.loadOverrides(uc.logger)
+	if overrides == nil {
+		overrides = &entities.ScheduleOverrides{}
+	}
+
+	if input.Cancel {
+		if overrides.Vacation == nil {
+			return &SetVacationOutput{Message: "No vacation configured"}, nil
+		}
+		overrides.Vacation = nil
+		if err := saveOverridesAndRearm(armer, uc.overrideRepo, uc.eventRepo, uc.logger, config, overrides,
+			"Vacation cancelled", "kind", "vacation", "action", "cancel", "by", input.By); err != nil {
+			return nil, err
+		}
+		return &SetVacationOutput{Message: "Vacation cancelled, daily schedule restored"}, nil
+	}
+
+	vacation, err := entities.NewVacation(input.From, input.To, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	vacation.By = input.By
+	overrides.Vacation = vacation
+
+	uc.logger.Info("Setting vacation", "from", input.From, "to", input.To, "by", input.By)
+
+	if err := saveOverridesAndRearm(armer, uc.overrideRepo, uc.eventRepo, uc.logger, config, overrides,
+		"Vacation set "+vacation.String(),
+		"kind", "vacation", "action", "set", "from", input.From, "to", input.To, "by", input.By); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Vacation set: %s, no wakes until %s", vacation.String(), vacation.End().Format("2006-01-02"))
+	if !config.Enabled {
+		message += " (service is disabled)"
+	}
+
+	return &SetVacationOutput{Vacation: vacation, Message: message}, nil
+}
\ No newline at end of file
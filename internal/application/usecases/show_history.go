@@ -0,0 +1,113 @@
+// internal/application/usecases/show_history.go
+package usecases
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type ShowHistoryInput struct {
+	From time.Time // Cero = sin límite inferior
+	To   time.Time // Cero = sin límite superior
+}
+
+type ShowHistoryOutput struct {
+	Events  []*entities.PowerEvent
+	Message string
+}
+
+// ShowHistoryUseCase muestra el historial de eventos de energía
+type ShowHistoryUseCase struct {
+	eventRepo repositories.EventRepository
+	logger    logger.Logger
+}
+
+func NewShowHistoryUseCase(
+	events repositories.EventRepository,
+	log logger.Logger,
+) *ShowHistoryUseCase {
+	return &ShowHistoryUseCase{
+		eventRepo: events,
+		logger:    log,
+	}
+}
+
+func (uc *ShowHistoryUseCase) Execute(input *ShowHistoryInput) (*ShowHistoryOutput, error) {
+	uc.logger.Info("Reading event history", "from", input.From, "to", input.To)
+
+	if !input.From.IsZero() && !input.To.IsZero() && input.To.Before(input.From) {
+		return nil, fmt.Errorf("invalid time range: end is before start")
+	}
+
+	events, err := uc.eventRepo.List(input.From, input.To)
+	if err != nil {
+		uc.logger.Error("Failed to read event history", "error", err)
+		return nil, err
+	}
+
+	output := &ShowHistoryOutput{Events: events}
+	output.Message = uc.generateHistoryMessage(output)
+
+	return output, nil
+}
+
+func (uc *ShowHistoryUseCase) generateHistoryMessage(output *ShowHistoryOutput) string {
+	var msg string
+
+	msg += "📜 Power Event History\n"
+	msg += "═══════════════════════════════════════\n\n"
+
+	if len(output.Events) == 0 {
+		msg += "   No events recorded in this range\n"
+	}
+
+	for _, event := range output.Events {
+		msg += fmt.Sprintf("   %s  %-10s %s%s\n",
+			event.Timestamp.Format("2006-01-02 15:04:05"),
+			event.Type,
+			levelPrefix(event.Level),
+			event.Message,
+		)
+		if details := formatEventFields(event.Fields); details != "" {
+			msg += fmt.Sprintf("   %19s  %-10s %s\n", "", "", details)
+		}
+	}
+
+	msg += fmt.Sprintf("\n   Total: %d event(s)\n", len(output.Events))
+	msg += "═══════════════════════════════════════"
+
+	return msg
+}
+
+// levelPrefix retorna un indicador visual para eventos que no son informativos
+func levelPrefix(level entities.EventLevel) string {
+	switch level {
+	case entities.EventLevelWarning:
+		return "⚠️  "
+	case entities.EventLevelError:
+		return "❌ "
+	default:
+		return ""
+	}
+}
+
+// formatEventFields formatea los campos del evento como pares clave=valor ordenados
+func formatEventFields(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", key, fields[key]))
+	}
+	return strings.Join(parts, " ")
+}
\ No newline at end of file
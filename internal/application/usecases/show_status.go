@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"rtc-scheduler/internal/domain/entities"
 	"rtc-scheduler/internal/domain/repositories"
 	"rtc-scheduler/pkg/logger"
 )
@@ -12,18 +13,28 @@ import (
 type ShowStatusInput struct{}
 
 type ShowStatusOutput struct {
-	ServiceInstalled   bool
-	ServiceEnabled     bool
-	ServiceRunning     bool
-	ConfigExists       bool
-	WakeTime           string
-	ShutdownTime       string
-	Enabled            bool
-	RTCWakeAlarm       string
-	RTCCurrentTime     string
-	SystemTime         string
-	ScheduledJobs      []*repositories.ShutdownJob
-	Message            string
+	ServiceInstalled bool
+	ServiceEnabled   bool
+	ServiceRunning   bool
+	ConfigExists     bool
+	WakeTime         string
+	ShutdownTime     string
+	Enabled          bool
+	Profile          string                  // Perfil activo (vacío si no hay)
+	ProfileSwitch    *entities.ProfileSwitch // Próximo cambio de perfil programado (nil si no hay)
+	RTCWakeAlarm     string
+	RTCCurrentTime   string
+	SystemTime       string
+	ScheduledJobs    []*repositories.ShutdownJob
+	LastWake         *entities.PowerEvent        // Última verificación de despertar (nil si no hay)
+	NextWake         time.Time                   // Próximo encendido efectivo (cero sin horario ni ventanas únicas)
+	NextAlarm        time.Time                   // Alarma RTC del próximo encendido (antes, si hay anticipación)
+	WakeLead         entities.WakeLead           // Anticipación de la alarma respecto a NextWake
+	LastReady        *entities.PowerEvent        // Última medición de alarma a "listo" (nil si no hay)
+	NextShutdown     time.Time                   // Próximo apagado (pospuesto si hay un snooze activo)
+	Snooze           *entities.SnoozeState       // Snooze activo (nil si no hay)
+	Overrides        *entities.ScheduleOverrides // Skip-next, vacaciones y ventanas únicas vigentes (nil si no hay)
+	Message          string
 }
 
 // ShowStatusUseCase muestra el estado completo del sistema
@@ -32,6 +43,9 @@ type ShowStatusUseCase struct {
 	configRepo    repositories.ConfigRepository
 	serviceRepo   repositories.ServiceRepository
 	schedulerRepo repositories.SchedulerRepository
+	eventRepo     repositories.EventRepository
+	snoozeRepo    repositories.SnoozeRepository
+	overrideRepo  repositories.OverrideRepository
 	logger        logger.Logger
 }
 
@@ -40,6 +54,9 @@ func NewShowStatusUseCase(
 	config repositories.ConfigRepository,
 	service repositories.ServiceRepository,
 	scheduler repositories.SchedulerRepository,
+	events repositories.EventRepository,
+	snooze repositories.SnoozeRepository,
+	overrides repositories.OverrideRepository,
 	log logger.Logger,
 ) *ShowStatusUseCase {
 	return &ShowStatusUseCase{
@@ -47,12 +64,15 @@ func NewShowStatusUseCase(
 		configRepo:    config,
 		serviceRepo:   service,
 		schedulerRepo: scheduler,
+		eventRepo:     events,
+		snoozeRepo:    snooze,
+		overrideRepo:  overrides,
 		logger:        log,
 	}
 }
 
 func (uc *ShowStatusUseCase) Execute(input *ShowStatusInput) (*ShowStatusOutput, error) {
-	uc.logger.Info("Gathering system status")
+	uc.logger.Debug("Gathering system status")
 
 	output := &ShowStatusOutput{}
 
@@ -67,6 +87,10 @@ func (uc *ShowStatusUseCase) Execute(input *ShowStatusInput) (*ShowStatusOutput,
 	}
 
 	// Verificar configuración
+	var wake, shutdown time.Time
+	var lead *entities.WakeLead
+	events := uc.loadEvents()
+	output.Overrides = uc.findOverrides()
 	output.ConfigExists = uc.configRepo.Exists()
 	if output.ConfigExists {
 		config, err := uc.configRepo.Load()
@@ -74,8 +98,25 @@ func (uc *ShowStatusUseCase) Execute(input *ShowStatusInput) (*ShowStatusOutput,
 			output.WakeTime = config.WakeTime
 			output.ShutdownTime = config.ShutdownTime
 			output.Enabled = config.Enabled
+			output.Profile = config.ActiveProfile
+			output.ProfileSwitch = config.NextProfileSwitch()
+
+			if config.Enabled {
+				if w, s, err := effectiveSchedule(config, output.Overrides); err == nil {
+					wake, shutdown = w, s
+				}
+				configLead := config.WakeLead(entities.WakeReadySamples(events))
+				lead = &configLead
+			}
 		}
 	}
+	// La cola de ventanas únicas cuenta aunque no haya horario diario
+	output.NextWake, output.NextShutdown = output.Overrides.Merge(wake, shutdown, time.Now())
+	output.NextAlarm = output.NextWake
+	if lead != nil && !output.NextWake.IsZero() {
+		output.WakeLead = *lead
+		output.NextAlarm = lead.AlarmFor(output.NextWake, time.Now())
+	}
 
 	// Estado RTC
 	if uc.rtcRepo.IsAvailable() {
@@ -104,10 +145,20 @@ func (uc *ShowStatusUseCase) Execute(input *ShowStatusInput) (*ShowStatusOutput,
 		}
 	}
 
+	// Snooze activo: solo cuenta si el apagado pospuesto sigue programado
+	output.Snooze = uc.findActiveSnooze(output.ScheduledJobs)
+	if output.Snooze != nil {
+		output.NextShutdown = output.Snooze.ShutdownAt
+	}
+
+	// Última verificación de despertar y medición de alarma a "listo"
+	output.LastWake = findLastEvent(events, entities.EventWakeDetected, entities.EventWakeMissed)
+	output.LastReady = findLastEvent(events, entities.EventWakeReady)
+
 	// Generar mensaje de resumen
 	output.Message = uc.generateStatusMessage(output)
 
-	uc.logger.Info("Status gathered successfully")
+	uc.logger.Debug("Status gathered successfully")
 	return output, nil
 }
 
@@ -143,6 +194,12 @@ func (uc *ShowStatusUseCase) generateStatusMessage(output *ShowStatusOutput) str
 		}
 		msg += fmt.Sprintf("   Shutdown Time: %s\n", shutdownStatus)
 		msg += fmt.Sprintf("   Enabled: %s\n", map[bool]string{true: "✅ Yes", false: "❌ No"}[output.Enabled])
+		if output.Profile != "" {
+			msg += fmt.Sprintf("   Profile: %s\n", output.Profile)
+		}
+		if output.ProfileSwitch != nil {
+			msg += fmt.Sprintf("   Profile Switch: %s\n", output.ProfileSwitch.String())
+		}
 	} else {
 		msg += "   Exists: ❌ No\n"
 	}
@@ -160,6 +217,34 @@ func (uc *ShowStatusUseCase) generateStatusMessage(output *ShowStatusOutput) str
 	msg += fmt.Sprintf("   Current Time: %s\n", output.SystemTime)
 	msg += "\n"
 
+	// Próximo despertar
+	if !output.NextWake.IsZero() {
+		msg += "🌄 Next Wake:\n"
+		msg += fmt.Sprintf("   Alarm: %s\n", output.NextAlarm.Format("2006-01-02 15:04:05"))
+		msg += fmt.Sprintf("   Ready By: %s (lead %s)\n", output.NextWake.Format("2006-01-02 15:04:05"), output.WakeLead.String())
+		msg += "\n"
+	}
+
+	// Último despertar
+	msg += "🌅 Last Wake:\n"
+	if output.LastWake != nil {
+		fields := output.LastWake.Fields
+		outcome := fields["outcome"]
+		if output.LastWake.Type == entities.EventWakeMissed {
+			outcome = "⚠️  " + outcome
+		}
+		msg += fmt.Sprintf("   Result: %s (%s)\n", outcome, fields["kind"])
+		msg += fmt.Sprintf("   Armed For: %s\n", formatEventTime(fields["armed_for"]))
+		msg += fmt.Sprintf("   Woke At: %s (%ss)\n", formatEventTime(fields["woke_at"]), signedSeconds(fields["offset_seconds"]))
+		msg += fmt.Sprintf("   Wake Source: %s\n", fields["source"])
+		if output.LastReady != nil && output.LastReady.Fields["armed_for"] == fields["armed_for"] {
+			msg += fmt.Sprintf("   Ready After: %ss (%s)\n", output.LastReady.Fields["wake_to_ready_seconds"], output.LastReady.Fields["unit"])
+		}
+	} else {
+		msg += "   No verified wake recorded\n"
+	}
+	msg += "\n"
+
 	// Tareas programadas
 	msg += "⏰ Scheduled Jobs:\n"
 	if len(output.ScheduledJobs) > 0 {
@@ -170,7 +255,118 @@ func (uc *ShowStatusUseCase) generateStatusMessage(output *ShowStatusOutput) str
 		msg += "   None\n"
 	}
 
+	if !output.Overrides.IsEmpty() {
+		msg += "\n🏖️  Overrides:\n"
+		if skip := output.Overrides.Skip; skip != nil {
+			msg += fmt.Sprintf("   Skipped Wake: %s (by %s)\n", skip.WakeAt.Format("2006-01-02 15:04"), skip.By)
+			if skip.SkipShutdown {
+				msg += fmt.Sprintf("   Skipped Shutdown: %s\n", skip.ShutdownAt.Format("2006-01-02 15:04"))
+			}
+		}
+		if vacation := output.Overrides.Vacation; vacation != nil {
+			msg += fmt.Sprintf("   Vacation: %s (by %s)\n", vacation.String(), vacation.By)
+		}
+		for _, oneOff := range output.Overrides.OneOffs {
+			msg += fmt.Sprintf("   One-off #%s: %s (by %s)\n", oneOff.ID, oneOff.String(), oneOff.By)
+		}
+	}
+
+	if output.Snooze != nil {
+		msg += "\n😴 Snooze:\n"
+		msg += fmt.Sprintf("   Shutdown Postponed: %s → %s\n",
+			output.Snooze.OriginalShutdown.Format("2006-01-02 15:04"), output.Snooze.ShutdownAt.Format("2006-01-02 15:04"))
+		msg += fmt.Sprintf("   Snoozed By: %s at %s\n", output.Snooze.By, output.Snooze.At.Format("15:04"))
+		msg += fmt.Sprintf("   Used Today: %s\n", output.Snooze.Extended)
+	}
+
 	msg += "\n═══════════════════════════════════════"
 
 	return msg
+}
+
+// findActiveSnooze retorna el snooze vigente si el trabajo pospuesto sigue programado
+func (uc *ShowStatusUseCase) findActiveSnooze(jobs []*repositories.ShutdownJob) *entities.SnoozeState {
+	if uc.snoozeRepo == nil {
+		return nil
+	}
+
+	snooze, err := uc.snoozeRepo.LoadSnooze()
+	if err != nil {
+		uc.logger.Debug("Failed to read snooze state", "error", err)
+		return nil
+	}
+
+	now := time.Now()
+	if !snooze.Active(now) {
+		return nil
+	}
+
+	// Un -disable o -clear posterior cancela el apagado pospuesto
+	next, err := nextJobTime(jobs, now)
+	if err != nil || next.Sub(snooze.ShutdownAt).Abs() >= time.Minute {
+		return nil
+	}
+	return snooze
+}
+
+// findOverrides retorna las excepciones vigentes, sin modificar el archivo
+func (uc *ShowStatusUseCase) findOverrides() *entities.ScheduleOverrides {
+	if uc.overrideRepo == nil {
+		return nil
+	}
+
+	overrides, err := uc.overrideRepo.LoadOverrides()
+	if err != nil {
+		uc.logger.Debug("Failed to read schedule overrides", "error", err)
+		return nil
+	}
+
+	overrides.Prune(time.Now())
+	if overrides.IsEmpty() {
+		return nil
+	}
+	return overrides
+}
+
+// loadEvents retorna el historial completo de eventos (nil si no se puede leer)
+func (uc *ShowStatusUseCase) loadEvents() []*entities.PowerEvent {
+	if uc.eventRepo == nil {
+		return nil
+	}
+
+	events, err := uc.eventRepo.List(time.Time{}, time.Time{})
+	if err != nil {
+		uc.logger.Debug("Failed to read event history", "error", err)
+		return nil
+	}
+	return events
+}
+
+// findLastEvent busca en el historial el evento más reciente de alguno de los tipos
+func findLastEvent(events []*entities.PowerEvent, types ...entities.EventType) *entities.PowerEvent {
+	for i := len(events) - 1; i >= 0; i-- {
+		for _, eventType := range types {
+			if events[i].Type == eventType {
+				return events[i]
+			}
+		}
+	}
+	return nil
+}
+
+// formatEventTime convierte un timestamp RFC 3339 de un evento al formato de status
+func formatEventTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// signedSeconds antepone "+" a los desfases positivos
+func signedSeconds(value string) string {
+	if value != "" && value[0] != '-' {
+		return "+" + value
+	}
+	return value
 }
\ No newline at end of file
@@ -0,0 +1,148 @@
+// internal/application/usecases/skip_next.go
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type SkipNextInput struct {
+	// SkipShutdown omite también el próximo apagado
+	SkipShutdown bool
+	// Cancel elimina un skip-next pendiente
+	Cancel bool
+	By     string
+}
+
+type SkipNextOutput struct {
+	Skip    *entities.SkipNext
+	Message string
+}
+
+// SkipNextUseCase omite una sola vez el próximo despertar (y opcionalmente el
+// próximo apagado). Al pasar, el horario diario continúa sin intervención.
+type SkipNextUseCase struct {
+	configRepo    repositories.ConfigRepository
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	overrideRepo  repositories.OverrideRepository
+	eventRepo     repositories.EventRepository
+	logger        logger.Logger
+}
+
+func NewSkipNextUseCase(
+	config repositories.ConfigRepository,
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	overrides repositories.OverrideRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *SkipNextUseCase {
+	return &SkipNextUseCase{
+		configRepo:    config,
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		overrideRepo:  overrides,
+		eventRepo:     events,
+		logger:        log,
+	}
+}
+
+func (uc *SkipNextUseCase) Execute(input *SkipNextInput) (*SkipNextOutput, error) {
+	config, err := uc.configRepo.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	armer := newScheduleArmer(uc.rtcRepo, uc.schedulerRepo, uc.overrideRepo, uc.eventRepo)
+	overrides := armer.loadOverrides(uc.logger)
+	if overrides == nil {
+		overrides = &entities.ScheduleOverrides{}
+	}
+
+	if input.Cancel {
+		if overrides.Skip == nil {
+			return &SkipNextOutput{Message: "No skipped cycle pending"}, nil
+		}
+		overrides.Skip = nil
+		if err := saveOverridesAndRearm(armer, uc.overrideRepo, uc.eventRepo, uc.logger, config, overrides,
+			"Skip-next cancelled", "kind", "skip_next", "action", "cancel", "by", input.By); err != nil {
+			return nil, err
+		}
+		return &SkipNextOutput{Message: "Skipped cycle cancelled, daily schedule restored"}, nil
+	}
+
+	if !config.Enabled {
+		return nil, entities.ErrNoScheduleToSkip
+	}
+
+	// El ciclo a omitir es el que se armaría hoy, sin contar un skip anterior
+	overrides.Skip = nil
+	wake, shutdown, err := effectiveSchedule(config, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := &entities.SkipNext{
+		WakeAt:       wake,
+		SkipShutdown: input.SkipShutdown,
+		By:           input.By,
+		CreatedAt:    time.Now(),
+	}
+	if input.SkipShutdown {
+		skip.ShutdownAt = shutdown
+	}
+	overrides.Skip = skip
+
+	uc.logger.Info("Skipping next cycle", "wake_time", wake, "skip_shutdown", input.SkipShutdown, "by", input.By)
+
+	args := []interface{}{"kind", "skip_next", "action", "set", "wake_time", wake.Format(time.RFC3339), "by", input.By}
+	if input.SkipShutdown {
+		args = append(args, "shutdown_time", shutdown.Format(time.RFC3339))
+	}
+	if err := saveOverridesAndRearm(armer, uc.overrideRepo, uc.eventRepo, uc.logger, config, overrides,
+		"Next cycle skipped", args...); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Wake at %s skipped", wake.Format("2006-01-02 15:04"))
+	if input.SkipShutdown {
+		message += fmt.Sprintf(", shutdown at %s skipped", shutdown.Format("2006-01-02 15:04"))
+	}
+
+	return &SkipNextOutput{Skip: skip, Message: message}, nil
+}
+
+// saveOverridesAndRearm persiste las excepciones y vuelve a armar el próximo
+// ciclo para que la alarma RTC las refleje. Con config nil o deshabilitada solo
+// se arman las ventanas únicas de la cola.
+func saveOverridesAndRearm(
+	armer *scheduleArmer,
+	overrideRepo repositories.OverrideRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+	config *entities.Config,
+	overrides *entities.ScheduleOverrides,
+	message string,
+	args ...interface{},
+) error {
+	if err := overrideRepo.SaveOverrides(overrides); err != nil {
+		log.Error("Failed to save schedule overrides", "error", err)
+		return err
+	}
+	recordEvent(events, log, entities.EventOverrideChanged, message, args...)
+
+	if config != nil && !config.Enabled {
+		config = nil
+	}
+
+	if _, err := armer.arm(log, config, "override", true); err != nil && !errors.Is(err, errNothingToArm) {
+		return fmt.Errorf("override saved but re-arming failed: %w", err)
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,178 @@
+// internal/application/usecases/sleep_now.go
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+	"rtc-scheduler/pkg/timeparse"
+)
+
+// minSleep es lo mínimo que se puede dormir: suspender toma unos segundos y la
+// alarma debe dispararse después
+const minSleep = time.Minute
+
+var (
+	ErrSleepTimeRequired = errors.New("use either -sleep-for or -sleep-until")
+	ErrSleepTooShort     = fmt.Errorf("sleep must last at least %s", minSleep)
+	ErrWakeAlarmMismatch = errors.New("wake alarm verification failed")
+)
+
+type SleepNowInput struct {
+	// For es cuánto dormir ("8h", "+90m", "2d"); Until, hasta cuándo ("06:00",
+	// "tomorrow 7am"). Se usa uno de los dos.
+	For   string
+	Until string
+	// TestMode arma y verifica la alarma pero no suspende
+	TestMode bool
+	By       string
+}
+
+type SleepNowOutput struct {
+	WakeAt   time.Time
+	Action   entities.PowerAction
+	TestMode bool
+	Message  string
+}
+
+// SleepNowUseCase suspende el equipo ya y lo despierta más tarde, como
+// rtcwake: arma la alarma RTC, verifica que quedó armada y ejecuta la acción
+// de apagado configurada sin esperar a un trabajo programado. Los apagados
+// pendientes se cancelan; la unidad de reanudación corre el servicio al
+// volver y este arma de nuevo el horario. No se re-arma aquí al retornar
+// Execute: systemctl suspend vuelve antes de que el equipo duerma y armar el
+// horario reemplazaría la alarma recién verificada.
+type SleepNowUseCase struct {
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	powerRepo     repositories.PowerRepository
+	eventRepo     repositories.EventRepository
+	logger        logger.Logger
+}
+
+func NewSleepNowUseCase(
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	power repositories.PowerRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *SleepNowUseCase {
+	return &SleepNowUseCase{
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		powerRepo:     power,
+		eventRepo:     events,
+		logger:        log,
+	}
+}
+
+func (uc *SleepNowUseCase) Execute(input *SleepNowInput) (*SleepNowOutput, error) {
+	now := time.Now()
+	wake, err := sleepWakeTime(input.For, input.Until, now)
+	if err != nil {
+		return nil, err
+	}
+
+	action := uc.schedulerRepo.ShutdownAction()
+	if action == "" {
+		action = entities.PowerActionSuspend
+	}
+
+	uc.logger.Info("Sleeping now", "wake_time", wake, "action", action, "test_mode", input.TestMode, "by", input.By)
+
+	if !uc.rtcRepo.IsAvailable() {
+		return nil, fmt.Errorf("RTC device is not available")
+	}
+	if !input.TestMode {
+		if err := uc.powerRepo.Supports(action); err != nil {
+			return nil, err
+		}
+	}
+
+	// Armar y verificar la alarma: sin ella el equipo no volvería a despertar
+	if err := uc.rtcRepo.SetWakeAlarm(wake); err != nil {
+		uc.logger.Error("Failed to set RTC wake alarm", "error", err)
+		return nil, fmt.Errorf("failed to set wake alarm: %w", err)
+	}
+	recordEvent(uc.eventRepo, uc.logger, entities.EventAlarmArmed, "RTC wake alarm armed",
+		"wake_time", wake.Format(time.RFC3339), "source", "sleep")
+
+	armed, err := uc.rtcRepo.GetWakeAlarm()
+	if err == nil && armed.Sub(wake).Abs() > time.Second {
+		err = fmt.Errorf("RTC reports %s, expected %s", armed.Format("2006-01-02 15:04:05"), wake.Format("2006-01-02 15:04:05"))
+	}
+	if err != nil {
+		if uc.rtcRepo.ClearWakeAlarm() == nil {
+			recordEvent(uc.eventRepo, uc.logger, entities.EventAlarmCleared, "RTC wake alarm cleared after verification failure", "source", "sleep")
+		}
+		recordEventWithLevel(uc.eventRepo, uc.logger, entities.EventError, entities.EventLevelError,
+			"Sleep aborted, wake alarm not verified", "wake_time", wake.Format(time.RFC3339), "error", err)
+		return nil, fmt.Errorf("%w: %v", ErrWakeAlarmMismatch, err)
+	}
+
+	message := fmt.Sprintf("Wake alarm armed for %s", wake.Format("2006-01-02 15:04:05"))
+	if input.TestMode {
+		uc.logger.Info("Test mode, not suspending", "action", action)
+		return &SleepNowOutput{
+			WakeAt:   wake,
+			Action:   action,
+			TestMode: true,
+			Message:  message + fmt.Sprintf(" (test mode, %s skipped)", action),
+		}, nil
+	}
+
+	// Un apagado que venza mientras duerme se ejecutaría al reanudar
+	if err := uc.schedulerRepo.CancelShutdown(); err != nil {
+		uc.logger.Warn("Failed to cancel pending shutdowns", "error", err)
+	} else {
+		recordEvent(uc.eventRepo, uc.logger, entities.EventShutdownCancelled, "Scheduled shutdowns cancelled", "source", "sleep")
+	}
+
+	recordEvent(uc.eventRepo, uc.logger, entities.EventShutdownExecuted, fmt.Sprintf("Executing system %s until %s", action, wake.Format("15:04")),
+		"action", action, "wake_time", wake.Format(time.RFC3339), "source", "sleep", "by", input.By)
+
+	if err := uc.powerRepo.Execute(action); err != nil {
+		uc.logger.Error("Failed to execute power action", "action", action, "error", err)
+		recordEventWithLevel(uc.eventRepo, uc.logger, entities.EventError, entities.EventLevelError,
+			fmt.Sprintf("Failed to execute system %s", action), "action", action, "error", err)
+		return nil, err
+	}
+
+	return &SleepNowOutput{
+		WakeAt:  wake,
+		Action:  action,
+		Message: message + fmt.Sprintf(", system %s executed", action),
+	}, nil
+}
+
+// sleepWakeTime calcula la hora de despertar a partir de una duración o una
+// hora, redondeada al segundo (la resolución del RTC)
+func sleepWakeTime(forValue, untilValue string, now time.Time) (time.Time, error) {
+	var wake time.Time
+	switch {
+	case (forValue == "") == (untilValue == ""):
+		return time.Time{}, ErrSleepTimeRequired
+	case forValue != "":
+		d, err := timeparse.ParseDuration(forValue)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid sleep duration %q (use e.g. 30m, 8h or 1d)", forValue)
+		}
+		wake = now.Add(d)
+	default:
+		until, err := timeparse.Parse(untilValue, now)
+		if err != nil {
+			return time.Time{}, err
+		}
+		wake = until.Time
+	}
+
+	wake = wake.Truncate(time.Second)
+	if wake.Sub(now) < minSleep {
+		return time.Time{}, fmt.Errorf("%w (wake at %s)", ErrSleepTooShort, wake.Format("2006-01-02 15:04:05"))
+	}
+	return wake, nil
+}
\ No newline at end of file
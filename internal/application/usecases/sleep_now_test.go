@@ -0,0 +1,95 @@
+// internal/application/usecases/sleep_now_test.go
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type memoryConfigRepository struct{ config *entities.Config }
+
+func (r *memoryConfigRepository) Load() (*entities.Config, error) { return r.config, nil }
+func (r *memoryConfigRepository) Save(c *entities.Config) error   { r.config = c; return nil }
+func (r *memoryConfigRepository) Delete() error                   { r.config = nil; return nil }
+func (r *memoryConfigRepository) Exists() bool                    { return r.config != nil }
+func (r *memoryConfigRepository) CreateDefault() error            { return nil }
+
+type fakeRTC struct{ alarm time.Time }
+
+func (r *fakeRTC) SetWakeAlarm(t time.Time) error     { r.alarm = t; return nil }
+func (r *fakeRTC) GetWakeAlarm() (time.Time, error)   { return r.alarm, nil }
+func (r *fakeRTC) ClearWakeAlarm() error              { r.alarm = time.Time{}; return nil }
+func (r *fakeRTC) GetCurrentTime() (time.Time, error) { return time.Now(), nil }
+func (r *fakeRTC) IsAvailable() bool                  { return true }
+
+type fakeScheduler struct{ shutdown time.Time }
+
+func (s *fakeScheduler) ScheduleShutdown(t time.Time) error { s.shutdown = t; return nil }
+func (s *fakeScheduler) CancelShutdown() error              { s.shutdown = time.Time{}; return nil }
+func (s *fakeScheduler) IsAvailable() bool                  { return true }
+func (s *fakeScheduler) Backend() string                    { return "fake" }
+func (s *fakeScheduler) ShutdownAction() entities.PowerAction {
+	return entities.PowerActionSuspend
+}
+func (s *fakeScheduler) ListScheduledJobs() ([]*repositories.ShutdownJob, error) {
+	return nil, nil
+}
+
+type fakePower struct{ executed []entities.PowerAction }
+
+func (p *fakePower) Execute(action entities.PowerAction) error {
+	p.executed = append(p.executed, action)
+	return nil
+}
+func (p *fakePower) IsAvailable() bool                          { return true }
+func (p *fakePower) Supports(action entities.PowerAction) error { return nil }
+
+// Suspender con -sleep-for cancela el apagado diario; la unidad de
+// reanudación corre -run-service al volver y lo programa de nuevo
+func TestSleepNowShutdownRearmedOnResume(t *testing.T) {
+	config, _ := entities.NewConfig("07:00", "23:00", true)
+	configRepo := &memoryConfigRepository{config: config}
+	rtc := &fakeRTC{}
+	scheduler := &fakeScheduler{}
+	power := &fakePower{}
+	log := logger.NewWithLevel(logger.ErrorLevel)
+
+	service := NewRunServiceUseCase(configRepo, rtc, scheduler, nil, nil, nil, nil, nil, nil, nil, nil, log)
+	if _, err := service.Execute(&RunServiceInput{}); err != nil {
+		t.Fatal(err)
+	}
+	daily := scheduler.shutdown
+	if daily.IsZero() {
+		t.Fatal("the service did not schedule the daily shutdown")
+	}
+
+	sleep := NewSleepNowUseCase(rtc, scheduler, power, nil, log)
+	output, err := sleep.Execute(&SleepNowInput{For: "2h", By: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(power.executed) != 1 || power.executed[0] != entities.PowerActionSuspend {
+		t.Errorf("executed %v, want one suspend", power.executed)
+	}
+	if !rtc.alarm.Equal(output.WakeAt) {
+		t.Errorf("alarm = %s, want the sleep wake %s", rtc.alarm, output.WakeAt)
+	}
+	if !scheduler.shutdown.IsZero() {
+		t.Errorf("shutdown %s still pending while asleep", scheduler.shutdown)
+	}
+
+	// Reanudación
+	if _, err := service.Execute(&RunServiceInput{}); err != nil {
+		t.Fatal(err)
+	}
+	if !scheduler.shutdown.Equal(daily) {
+		t.Errorf("shutdown = %s after resume, want the daily %s", scheduler.shutdown, daily)
+	}
+	if rtc.alarm.IsZero() || rtc.alarm.Equal(output.WakeAt) {
+		t.Errorf("alarm = %s after resume, want the daily wake", rtc.alarm)
+	}
+}
\ No newline at end of file
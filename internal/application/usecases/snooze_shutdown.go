@@ -0,0 +1,184 @@
+// internal/application/usecases/snooze_shutdown.go
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+	"rtc-scheduler/pkg/timeparse"
+)
+
+var ErrNoPendingShutdown = errors.New("no pending shutdown to snooze")
+
+type SnoozeShutdownInput struct {
+	// Duration es la extensión ("1h", "30m", "+2d") o la hora hasta la que se
+	// pospone ("23:30", "tomorrow 01:00")
+	Duration string
+	// By identifica a quién pidió el snooze (usuario, "mqtt", ...)
+	By string
+}
+
+type SnoozeShutdownOutput struct {
+	ShutdownAt time.Time
+	Remaining  time.Duration
+	Message    string
+}
+
+// SnoozeShutdownUseCase pospone el apagado pendiente sin modificar la configuración
+type SnoozeShutdownUseCase struct {
+	configRepo    repositories.ConfigRepository
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	snoozeRepo    repositories.SnoozeRepository
+	eventRepo     repositories.EventRepository
+	logger        logger.Logger
+}
+
+func NewSnoozeShutdownUseCase(
+	config repositories.ConfigRepository,
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	snooze repositories.SnoozeRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *SnoozeShutdownUseCase {
+	return &SnoozeShutdownUseCase{
+		configRepo:    config,
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		snoozeRepo:    snooze,
+		eventRepo:     events,
+		logger:        log,
+	}
+}
+
+func (uc *SnoozeShutdownUseCase) Execute(input *SnoozeShutdownInput) (*SnoozeShutdownOutput, error) {
+	by := input.By
+	if by == "" {
+		by = "unknown"
+	}
+
+	uc.logger.Info("Snoozing shutdown", "duration", input.Duration, "by", by)
+
+	limit := entities.DefaultMaxSnoozePerDay
+	if uc.configRepo.Exists() {
+		config, err := uc.configRepo.Load()
+		if err != nil {
+			return nil, err
+		}
+		limit = config.SnoozeLimit()
+	}
+
+	pending, err := uc.pendingShutdown()
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := snoozeDuration(input.Duration, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	// La alarma RTC no se toca: el apagado pospuesto debe seguir ocurriendo antes de ella
+	var wakeAlarm time.Time
+	if uc.rtcRepo.IsAvailable() {
+		if alarm, err := uc.rtcRepo.GetWakeAlarm(); err == nil {
+			wakeAlarm = alarm
+		}
+	}
+
+	current, err := uc.snoozeRepo.LoadSnooze()
+	if err != nil {
+		uc.logger.Warn("Failed to load snooze state, starting a new one", "error", err)
+		current = nil
+	}
+
+	now := time.Now()
+	next, err := current.Extend(duration, limit, pending, wakeAlarm, now, by)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.schedulerRepo.CancelShutdown(); err != nil {
+		uc.logger.Error("Failed to cancel pending shutdown", "error", err)
+		return nil, err
+	}
+	if err := uc.schedulerRepo.ScheduleShutdown(next.ShutdownAt); err != nil {
+		uc.logger.Error("Failed to reschedule shutdown, restoring the previous one", "error", err)
+		if restoreErr := uc.schedulerRepo.ScheduleShutdown(pending); restoreErr != nil {
+			recordEventWithLevel(uc.eventRepo, uc.logger, entities.EventError, entities.EventLevelError,
+				"Shutdown lost while snoozing", "shutdown_time", pending.Format(time.RFC3339), "error", restoreErr)
+		}
+		return nil, err
+	}
+
+	if err := uc.snoozeRepo.SaveSnooze(next); err != nil {
+		uc.logger.Warn("Failed to save snooze state", "error", err)
+	}
+
+	recordEvent(uc.eventRepo, uc.logger, entities.EventShutdownSnoozed,
+		fmt.Sprintf("Shutdown snoozed by %s until %s", by, next.ShutdownAt.Format("15:04")),
+		"shutdown_time", next.ShutdownAt.Format(time.RFC3339),
+		"previous_shutdown", pending.Format(time.RFC3339),
+		"duration", duration, "by", by)
+
+	remaining := limit - next.Extended
+	return &SnoozeShutdownOutput{
+		ShutdownAt: next.ShutdownAt,
+		Remaining:  remaining,
+		Message: fmt.Sprintf("Shutdown snoozed until %s (%s of snooze left today)",
+			next.ShutdownAt.Format("2006-01-02 15:04"), remaining),
+	}, nil
+}
+
+// snoozeDuration interpreta la extensión: una duración ("30m", "+1h", "2d") o
+// la hora hasta la que se pospone ("23:30", "tomorrow 01:00"), contada desde
+// el apagado pendiente
+func snoozeDuration(value string, pending time.Time) (time.Duration, error) {
+	if d, err := timeparse.ParseDuration(value); err == nil {
+		return d, nil
+	}
+
+	until, err := timeparse.Parse(value, pending)
+	if err != nil {
+		return 0, fmt.Errorf("invalid snooze %q (use e.g. 30m, 1h or a time like 23:30)", value)
+	}
+	if !until.Time.After(pending) {
+		return 0, fmt.Errorf("%w: %s is not after the pending shutdown at %s",
+			entities.ErrInvalidSnooze, until.Time.Format("2006-01-02 15:04"), pending.Format("2006-01-02 15:04"))
+	}
+	return until.Time.Sub(pending), nil
+}
+
+// pendingShutdown retorna el próximo apagado programado
+func (uc *SnoozeShutdownUseCase) pendingShutdown() (time.Time, error) {
+	if !uc.schedulerRepo.IsAvailable() {
+		return time.Time{}, ErrNoPendingShutdown
+	}
+
+	jobs, err := uc.schedulerRepo.ListScheduledJobs()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return nextJobTime(jobs, time.Now())
+}
+
+// nextJobTime retorna el trabajo futuro más cercano
+func nextJobTime(jobs []*repositories.ShutdownJob, now time.Time) (time.Time, error) {
+	var next time.Time
+	for _, job := range jobs {
+		if job.ScheduledAt.After(now) && (next.IsZero() || job.ScheduledAt.Before(next)) {
+			next = job.ScheduledAt
+		}
+	}
+
+	if next.IsZero() {
+		return time.Time{}, ErrNoPendingShutdown
+	}
+	return next, nil
+}
\ No newline at end of file
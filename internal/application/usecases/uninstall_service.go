@@ -2,6 +2,7 @@
 package usecases
 
 import (
+	"rtc-scheduler/internal/domain/entities"
 	"rtc-scheduler/internal/domain/repositories"
 	"rtc-scheduler/pkg/logger"
 )
@@ -21,6 +22,7 @@ type UninstallServiceUseCase struct {
 	configRepo    repositories.ConfigRepository
 	serviceRepo   repositories.ServiceRepository
 	schedulerRepo repositories.SchedulerRepository
+	eventRepo     repositories.EventRepository
 	logger        logger.Logger
 }
 
@@ -29,6 +31,7 @@ func NewUninstallServiceUseCase(
 	config repositories.ConfigRepository,
 	service repositories.ServiceRepository,
 	scheduler repositories.SchedulerRepository,
+	events repositories.EventRepository,
 	log logger.Logger,
 ) *UninstallServiceUseCase {
 	return &UninstallServiceUseCase{
@@ -36,6 +39,7 @@ func NewUninstallServiceUseCase(
 		configRepo:    config,
 		serviceRepo:   service,
 		schedulerRepo: scheduler,
+		eventRepo:     events,
 		logger:        log,
 	}
 }
@@ -72,6 +76,7 @@ func (uc *UninstallServiceUseCase) Execute(input *UninstallServiceInput) (*Unins
 	} else {
 		output.AlarmsCleared = true
 		uc.logger.Info("RTC wake alarm cleared")
+		recordEvent(uc.eventRepo, uc.logger, entities.EventAlarmCleared, "RTC wake alarm cleared", "source", "uninstall")
 	}
 
 	// 3. Cancelar tareas de apagado programadas
@@ -79,6 +84,7 @@ func (uc *UninstallServiceUseCase) Execute(input *UninstallServiceInput) (*Unins
 		uc.logger.Warn("Failed to cancel scheduled shutdowns", "error", err)
 	} else {
 		uc.logger.Info("Scheduled shutdowns cancelled")
+		recordEvent(uc.eventRepo, uc.logger, entities.EventShutdownCancelled, "Scheduled shutdowns cancelled", "source", "uninstall")
 	}
 
 	// 4. Eliminar configuración
@@ -0,0 +1,113 @@
+// internal/application/usecases/validate_config.go
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+type ValidateConfigInput struct {
+	// Path es el archivo a revisar (vacío = el instalado, con sus fragmentos)
+	Path string
+	// HostChecks revisa además el RTC, el backend y la acción de apagado de este equipo
+	HostChecks bool
+	// Privileged indica si corre como root; sin root, lo que no se puede
+	// comprobar (el spool de at) queda como advertencia
+	Privileged bool
+}
+
+type ValidateConfigOutput struct {
+	Problems []entities.ConfigProblem
+	// HostProblems son los problemas del equipo, no del archivo
+	HostProblems []entities.ConfigProblem
+	// HostWarnings son las revisiones del equipo que necesitan root y no se
+	// pudieron confirmar; no invalidan la configuración
+	HostWarnings []entities.ConfigProblem
+}
+
+// Valid indica si no se encontró ningún problema (las advertencias no cuentan)
+func (o *ValidateConfigOutput) Valid() bool {
+	return len(o.Problems) == 0 && len(o.HostProblems) == 0
+}
+
+// ValidateConfigUseCase revisa una configuración sin aplicarla: formato,
+// Config.Check (horarios, ventanas, cambios de hora, integraciones) y,
+// opcionalmente, que este equipo pueda cumplirla
+type ValidateConfigUseCase struct {
+	checkRepo     repositories.ConfigCheckRepository
+	rtcRepo       repositories.RTCRepository
+	schedulerRepo repositories.SchedulerRepository
+	powerRepo     repositories.PowerRepository
+	logger        logger.Logger
+}
+
+func NewValidateConfigUseCase(
+	check repositories.ConfigCheckRepository,
+	rtc repositories.RTCRepository,
+	scheduler repositories.SchedulerRepository,
+	power repositories.PowerRepository,
+	log logger.Logger,
+) *ValidateConfigUseCase {
+	return &ValidateConfigUseCase{
+		checkRepo:     check,
+		rtcRepo:       rtc,
+		schedulerRepo: scheduler,
+		powerRepo:     power,
+		logger:        log,
+	}
+}
+
+func (uc *ValidateConfigUseCase) Execute(input *ValidateConfigInput) (*ValidateConfigOutput, error) {
+	config, problems, err := uc.checkRepo.Inspect(input.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	output := &ValidateConfigOutput{Problems: problems}
+	if config != nil {
+		output.Problems = append(output.Problems, config.Check(time.Local, time.Now())...)
+	}
+	if input.HostChecks {
+		output.HostProblems, output.HostWarnings = uc.checkHost(input.Privileged)
+	}
+
+	uc.logger.Debug("Configuration checked", "path", input.Path, "problems", len(output.Problems), "host_problems", len(output.HostProblems), "host_warnings", len(output.HostWarnings))
+	return output, nil
+}
+
+// checkHost verifica que este equipo tenga RTC, un backend de apagado y que
+// admita la acción que ese backend ejecuta. Sin root el spool de at no es
+// escribible, así que un backend ausente es solo una advertencia
+func (uc *ValidateConfigUseCase) checkHost(privileged bool) (problems, warnings []entities.ConfigProblem) {
+	if !uc.rtcRepo.IsAvailable() {
+		problems = append(problems, entities.ConfigProblem{Path: "rtc.device", Message: "no RTC wake alarm on this host"})
+	}
+
+	if !uc.schedulerRepo.IsAvailable() {
+		if !privileged {
+			warnings = append(warnings, entities.ConfigProblem{
+				Path:    "scheduler.type",
+				Message: "shutdown backend not checked: the at spool is only writable by root (run with sudo to check)",
+			})
+			return problems, warnings
+		}
+		problems = append(problems, entities.ConfigProblem{
+			Path:    "scheduler.type",
+			Message: "no shutdown backend on this host (need at with a writable spool, or systemd-run)",
+		})
+		return problems, warnings
+	}
+
+	action := uc.schedulerRepo.ShutdownAction()
+	if err := uc.powerRepo.Supports(action); err != nil {
+		problems = append(problems, entities.ConfigProblem{
+			Path:    "scheduler.type",
+			Message: fmt.Sprintf("backend %s runs %s: %v", uc.schedulerRepo.Backend(), action, err),
+		})
+	}
+	return problems, warnings
+}
\ No newline at end of file
@@ -0,0 +1,42 @@
+// internal/application/usecases/validate_config_test.go
+package usecases
+
+import (
+	"testing"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/pkg/logger"
+)
+
+type fakeConfigCheck struct{ config *entities.Config }
+
+func (c *fakeConfigCheck) Inspect(path string) (*entities.Config, []entities.ConfigProblem, error) {
+	return c.config, nil, nil
+}
+
+// noScheduler no tiene backend utilizable, como at sin el spool escribible
+type noScheduler struct{ fakeScheduler }
+
+func (s *noScheduler) IsAvailable() bool { return false }
+
+func TestValidateConfigHostChecksWithoutRoot(t *testing.T) {
+	config, _ := entities.NewConfig("07:00", "23:00", true)
+	uc := NewValidateConfigUseCase(&fakeConfigCheck{config: config}, &fakeRTC{}, &noScheduler{}, &fakePower{}, logger.NewWithLevel(logger.ErrorLevel))
+
+	// Sin root el backend no se puede confirmar: advertencia, no problema
+	output, err := uc.Execute(&ValidateConfigInput{HostChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !output.Valid() || len(output.HostWarnings) != 1 || output.HostWarnings[0].Path != "scheduler.type" {
+		t.Errorf("unprivileged: problems %v, warnings %v; want one scheduler warning", output.HostProblems, output.HostWarnings)
+	}
+
+	output, err = uc.Execute(&ValidateConfigInput{HostChecks: true, Privileged: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.Valid() || len(output.HostProblems) != 1 || len(output.HostWarnings) != 0 {
+		t.Errorf("privileged: problems %v, warnings %v; want one scheduler problem", output.HostProblems, output.HostWarnings)
+	}
+}
\ No newline at end of file
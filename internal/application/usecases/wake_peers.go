@@ -0,0 +1,170 @@
+// internal/application/usecases/wake_peers.go
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+)
+
+var ErrNoWakePeers = errors.New("no wake peers configured (\"wake_peers\" in the configuration)")
+
+// sleep espera entre peers; las pruebas la reemplazan
+var sleep = time.Sleep
+
+type WakePeersInput struct {
+	// Peer despierta solo ese equipo, sin esperas ni dependencias (vacío = todos en orden)
+	Peer string
+}
+
+type WakePeersOutput struct {
+	Woken   []string
+	Failed  []string
+	Skipped []string
+	Message string
+}
+
+// WakePeersUseCase envía paquetes Wake-on-LAN a los equipos configurados
+type WakePeersUseCase struct {
+	configRepo repositories.ConfigRepository
+	wolRepo    repositories.WakeOnLANRepository
+	eventRepo  repositories.EventRepository
+	logger     logger.Logger
+}
+
+func NewWakePeersUseCase(
+	config repositories.ConfigRepository,
+	wol repositories.WakeOnLANRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+) *WakePeersUseCase {
+	return &WakePeersUseCase{
+		configRepo: config,
+		wolRepo:    wol,
+		eventRepo:  events,
+		logger:     log,
+	}
+}
+
+func (uc *WakePeersUseCase) Execute(input *WakePeersInput) (*WakePeersOutput, error) {
+	config, err := uc.configRepo.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.WakePeers) == 0 {
+		return nil, ErrNoWakePeers
+	}
+
+	if input.Peer == "" {
+		output, err := wakePeers(config.WakePeers, uc.wolRepo, uc.eventRepo, uc.logger, "manual")
+		if err != nil {
+			return nil, err
+		}
+		if len(output.Failed) > 0 {
+			return output, fmt.Errorf("failed to wake %d of %d peers", len(output.Failed), len(config.WakePeers))
+		}
+		return output, nil
+	}
+
+	peer, err := config.FindWakePeer(input.Peer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sendWakePacket(peer, uc.wolRepo, uc.eventRepo, uc.logger, "manual"); err != nil {
+		return nil, err
+	}
+
+	return &WakePeersOutput{
+		Woken:   []string{peer.Name},
+		Message: fmt.Sprintf("Magic packet sent to %s (%s)", peer.Name, peer.MAC),
+	}, nil
+}
+
+// wakePeers despierta los peers en orden de dependencias, esperando el delay
+// de cada uno antes de enviar su paquete, hasta MaxWakePeersDelay en total. Si
+// falla el envío a un peer, los que dependen de él no se despiertan.
+func wakePeers(
+	peers []entities.WakePeer,
+	wol repositories.WakeOnLANRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+	source string,
+) (*WakePeersOutput, error) {
+	ordered, err := entities.OrderWakePeers(peers)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &WakePeersOutput{}
+	unavailable := make(map[string]bool)
+	var waited time.Duration
+
+	for i := range ordered {
+		peer := &ordered[i]
+
+		if blocker := firstUnavailable(peer.DependsOn, unavailable); blocker != "" {
+			log.Warn("Skipping wake peer, dependency was not woken", "peer", peer.Name, "dependency", blocker)
+			unavailable[peer.Name] = true
+			output.Skipped = append(output.Skipped, peer.Name)
+			continue
+		}
+
+		if delay := peer.Delay(); delay > 0 {
+			if waited+delay > entities.MaxWakePeersDelay {
+				delay = entities.MaxWakePeersDelay - waited
+				log.Warn("Wake peer delays exceed the limit, shortening the wait",
+					"peer", peer.Name, "delay", delay, "limit", entities.MaxWakePeersDelay)
+			}
+			log.Debug("Waiting before waking peer", "peer", peer.Name, "delay", delay)
+			sleep(delay)
+			waited += delay
+		}
+
+		if err := sendWakePacket(peer, wol, events, log, source); err != nil {
+			unavailable[peer.Name] = true
+			output.Failed = append(output.Failed, peer.Name)
+			continue
+		}
+		output.Woken = append(output.Woken, peer.Name)
+	}
+
+	output.Message = fmt.Sprintf("Woke %d of %d peers", len(output.Woken), len(ordered))
+	return output, nil
+}
+
+// sendWakePacket envía el paquete mágico a un peer y registra el resultado
+func sendWakePacket(
+	peer *entities.WakePeer,
+	wol repositories.WakeOnLANRepository,
+	events repositories.EventRepository,
+	log logger.Logger,
+	source string,
+) error {
+	if err := wol.SendMagicPacket(peer); err != nil {
+		log.Error("Failed to send Wake-on-LAN packet", "peer", peer.Name, "mac", peer.MAC, "error", err)
+		recordEventWithLevel(events, log, entities.EventError, entities.EventLevelError,
+			fmt.Sprintf("Failed to wake peer %s", peer.Name), "peer", peer.Name, "mac", peer.MAC, "error", err, "source", source)
+		return err
+	}
+
+	log.Info("Wake-on-LAN packet sent", "peer", peer.Name, "mac", peer.MAC)
+	recordEvent(events, log, entities.EventPeerWoken, fmt.Sprintf("Wake-on-LAN packet sent to %s", peer.Name),
+		"peer", peer.Name, "mac", peer.MAC, "source", source)
+	return nil
+}
+
+// firstUnavailable retorna la primera dependencia que no pudo despertarse
+func firstUnavailable(dependencies []string, unavailable map[string]bool) string {
+	for _, dep := range dependencies {
+		if unavailable[dep] {
+			return dep
+		}
+	}
+	return ""
+}
\ No newline at end of file
@@ -0,0 +1,64 @@
+// internal/application/usecases/wake_peers_test.go
+package usecases
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/pkg/logger"
+)
+
+type fakeWakeOnLAN struct{ woken []string }
+
+func (w *fakeWakeOnLAN) SendMagicPacket(peer *entities.WakePeer) error {
+	w.woken = append(w.woken, peer.Name)
+	return nil
+}
+
+func TestWakePeersCapsTotalDelay(t *testing.T) {
+	var slept []time.Duration
+	sleep = func(d time.Duration) { slept = append(slept, d) }
+	defer func() { sleep = time.Sleep }()
+
+	peers := []entities.WakePeer{
+		{Name: "nas", MAC: "00:11:22:33:44:55", DelaySeconds: 120},
+		{Name: "db", MAC: "00:11:22:33:44:56", DelaySeconds: 150, DependsOn: []string{"nas"}},
+		{Name: "desk", MAC: "00:11:22:33:44:57", DelaySeconds: 120},
+		{Name: "tv", MAC: "00:11:22:33:44:58", DelaySeconds: 60},
+	}
+	wol := &fakeWakeOnLAN{}
+	output, err := wakePeers(peers, wol, nil, logger.NewWithLevel(logger.ErrorLevel), "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var total time.Duration
+	for _, d := range slept {
+		total += d
+	}
+	if total != entities.MaxWakePeersDelay {
+		t.Errorf("waited %s in total (%v), want the %s limit", total, slept, entities.MaxWakePeersDelay)
+	}
+	if got := strings.Join(wol.woken, " "); got != "nas db desk tv" || len(output.Woken) != 4 {
+		t.Errorf("woken = %q, want every peer in order", got)
+	}
+}
+
+func TestRunServiceWakePeersAfterExecute(t *testing.T) {
+	wol := &fakeWakeOnLAN{}
+	uc := NewRunServiceUseCase(nil, nil, nil, nil, nil, nil, wol, nil, nil, nil, nil, logger.NewWithLevel(logger.ErrorLevel))
+
+	// Sin peers pendientes (no fue un despertar por la alarma) no hace nada
+	uc.WakePeers(&RunServiceOutput{RunID: "test"})
+	uc.WakePeers(nil)
+	if len(wol.woken) != 0 {
+		t.Fatalf("woken = %v, want none", wol.woken)
+	}
+
+	uc.WakePeers(&RunServiceOutput{RunID: "test", peers: []entities.WakePeer{{Name: "nas", MAC: "00:11:22:33:44:55"}}})
+	if got := strings.Join(wol.woken, " "); got != "nas" {
+		t.Errorf("woken = %q, want nas", got)
+	}
+}
\ No newline at end of file
@@ -3,13 +3,24 @@ package entities
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
 var (
 	ErrEmptyWakeTime     = errors.New("wake time cannot be empty")
 	ErrEmptyShutdownTime = errors.New("shutdown time cannot be empty")
-	ErrInvalidTimeFormat = errors.New("invalid time format, use HH:MM")
+	ErrInvalidTimeFormat = errors.New("invalid time format, use HH:MM or a solar time like sunset-30m")
+	ErrInvalidRetention  = errors.New("history retention values cannot be negative")
+	ErrInvalidGrace      = errors.New("shutdown grace period cannot be negative")
+	ErrInvalidSnoozeCap  = errors.New("snooze limit cannot be negative")
+)
+
+const (
+	// DefaultHistoryRetentionDays es la antigüedad máxima de eventos si no se configura otra
+	DefaultHistoryRetentionDays = 90
+	// DefaultHistoryMaxEvents es la cantidad máxima de eventos si no se configura otra
+	DefaultHistoryMaxEvents = 5000
 )
 
 // Config representa la configuración del sistema
@@ -19,6 +30,43 @@ type Config struct {
 	Enabled      bool
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+
+	// Retención del historial de eventos (0 = valor por defecto)
+	HistoryRetentionDays int
+	HistoryMaxEvents     int
+	// Versiones anteriores de la configuración a conservar (0 = valor por defecto)
+	ConfigHistoryVersions int
+
+	// Notificaciones HTTP de eventos de energía
+	Webhooks []WebhookConfig
+	// Segundos entre el aviso "shutdown_imminent" y la acción de apagado
+	ShutdownGraceSeconds int
+	// Minutos que se puede posponer el apagado por día (0 = valor por defecto)
+	MaxSnoozeMinutes int
+
+	// Integración MQTT (nil = deshabilitada)
+	MQTT *MQTTConfig
+
+	// Equipos a despertar por Wake-on-LAN tras un despertar por alarma
+	WakePeers []WakePeer
+
+	// UPS monitoreada por NUT (nil = deshabilitada)
+	UPS *UPSConfig
+
+	// Horarios con nombre; WakeTime/ShutdownTime reflejan el perfil activo
+	Profiles        []Profile
+	ActiveProfile   string
+	ProfileSwitches []ProfileSwitch
+
+	// Ubicación para los horarios solares (nil = solo se aceptan HH:MM)
+	Location *Coordinates
+
+	// Segundos que se adelanta la alarma RTC para estar usable a la hora de
+	// encendido; con LearnWakeLead se ajusta según lo que tardó en estar
+	// listo (ReadyUnit activa, vacío = multi-user.target) en los últimos despertares
+	WakeLeadSeconds int
+	LearnWakeLead   bool
+	ReadyUnit       string
 }
 
 // NewConfig crea una nueva configuración con validación
@@ -48,18 +96,123 @@ func (c *Config) Validate() error {
 		return ErrEmptyShutdownTime
 	}
 
-	// Validar formato HH:MM
-	if !isValidTimeFormat(c.WakeTime) {
+	// Validar formato HH:MM o horario solar
+	if !isValidScheduleTime(c.WakeTime) {
 		return ErrInvalidTimeFormat
 	}
 
-	if !isValidTimeFormat(c.ShutdownTime) {
+	if !isValidScheduleTime(c.ShutdownTime) {
 		return ErrInvalidTimeFormat
 	}
 
+	if c.HistoryRetentionDays < 0 || c.HistoryMaxEvents < 0 {
+		return ErrInvalidRetention
+	}
+
+	if c.ConfigHistoryVersions < 0 {
+		return ErrInvalidConfigHistory
+	}
+
+	if c.ShutdownGraceSeconds < 0 {
+		return ErrInvalidGrace
+	}
+
+	if c.MaxSnoozeMinutes < 0 {
+		return ErrInvalidSnoozeCap
+	}
+
+	if err := c.validateWakeLead(); err != nil {
+		return err
+	}
+
+	for i := range c.Webhooks {
+		if err := c.Webhooks[i].Validate(); err != nil {
+			return fmt.Errorf("webhook %d: %w", i+1, err)
+		}
+	}
+
+	if c.MQTT != nil {
+		if err := c.MQTT.Validate(); err != nil {
+			return fmt.Errorf("mqtt: %w", err)
+		}
+	}
+
+	for i := range c.WakePeers {
+		if err := c.WakePeers[i].Validate(); err != nil {
+			return fmt.Errorf("wake peer %d: %w", i+1, err)
+		}
+	}
+	if _, err := OrderWakePeers(c.WakePeers); err != nil {
+		return err
+	}
+
+	if c.UPS != nil {
+		if err := c.UPS.Validate(); err != nil {
+			return fmt.Errorf("ups: %w", err)
+		}
+	}
+
+	if err := c.validateProfiles(); err != nil {
+		return err
+	}
+
+	if c.Location != nil {
+		if err := c.Location.Validate(); err != nil {
+			return fmt.Errorf("location: %w", err)
+		}
+	} else if c.usesSolarTimes() {
+		return ErrLocationRequired
+	}
+
 	return nil
 }
 
+// FindWakePeer busca un peer por nombre
+func (c *Config) FindWakePeer(name string) (*WakePeer, error) {
+	for i := range c.WakePeers {
+		if c.WakePeers[i].Name == name {
+			return &c.WakePeers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownPeer, name)
+}
+
+// HistoryRetention retorna la antigüedad y cantidad máxima de eventos a conservar
+func (c *Config) HistoryRetention() (time.Duration, int) {
+	days := c.HistoryRetentionDays
+	if days == 0 {
+		days = DefaultHistoryRetentionDays
+	}
+
+	maxEvents := c.HistoryMaxEvents
+	if maxEvents == 0 {
+		maxEvents = DefaultHistoryMaxEvents
+	}
+
+	return time.Duration(days) * 24 * time.Hour, maxEvents
+}
+
+// ConfigHistoryLimit retorna cuántas versiones de la configuración se conservan
+func (c *Config) ConfigHistoryLimit() int {
+	if c.ConfigHistoryVersions == 0 {
+		return DefaultConfigHistoryVersions
+	}
+	return c.ConfigHistoryVersions
+}
+
+// ShutdownGrace retorna la espera entre el aviso de apagado y la acción
+func (c *Config) ShutdownGrace() time.Duration {
+	return time.Duration(c.ShutdownGraceSeconds) * time.Second
+}
+
+// SnoozeLimit retorna cuánto se puede posponer el apagado por día
+func (c *Config) SnoozeLimit() time.Duration {
+	if c.MaxSnoozeMinutes == 0 {
+		return DefaultMaxSnoozePerDay
+	}
+	return time.Duration(c.MaxSnoozeMinutes) * time.Minute
+}
+
 // Update actualiza el timestamp de modificación
 func (c *Config) Update() {
 	c.UpdatedAt = time.Now()
@@ -83,36 +236,21 @@ func isValidTimeFormat(timeStr string) bool {
 	return err == nil
 }
 
-// ParseToSchedule convierte la configuración en un Schedule
+// ParseToSchedule convierte la configuración en un Schedule. Los horarios
+// solares se resuelven para el día de cada ocurrencia.
 func (c *Config) ParseToSchedule() (*Schedule, error) {
 	now := time.Now()
 
-	// Parsear wake time
-	wakeTime, err := time.ParseInLocation("15:04", c.WakeTime, time.Local)
+	wakeTime, err := c.nextTime(c.WakeTime, now)
 	if err != nil {
 		return nil, err
 	}
-	wakeTime = time.Date(now.Year(), now.Month(), now.Day(),
-		wakeTime.Hour(), wakeTime.Minute(), 0, 0, time.Local)
-	if wakeTime.Before(now) {
-		wakeTime = wakeTime.Add(24 * time.Hour)
-	}
 
-	// Parsear shutdown time
-	shutdownTime, err := time.ParseInLocation("15:04", c.ShutdownTime, time.Local)
+	// El apagado es la primera ocurrencia desde el encendido
+	shutdownTime, err := c.nextTime(c.ShutdownTime, wakeTime)
 	if err != nil {
 		return nil, err
 	}
-	shutdownTime = time.Date(now.Year(), now.Month(), now.Day(),
-		shutdownTime.Hour(), shutdownTime.Minute(), 0, 0, time.Local)
-	if shutdownTime.Before(now) {
-		shutdownTime = shutdownTime.Add(24 * time.Hour)
-	}
-
-	// Ajustar si shutdown es antes de wake
-	if shutdownTime.Before(wakeTime) {
-		shutdownTime = shutdownTime.Add(24 * time.Hour)
-	}
 
 	return NewSchedule(wakeTime, shutdownTime)
 }
\ No newline at end of file
@@ -0,0 +1,311 @@
+// internal/domain/entities/config_check.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MinScheduleWindow es el mínimo entre encendido y apagado (y entre apagado y
+// el siguiente encendido): por debajo, la alarma o el apagado pueden quedar en
+// el pasado antes de que el equipo termine de suspender o arrancar
+const MinScheduleWindow = 15 * time.Minute
+
+// ConfigProblem es un problema encontrado al revisar una configuración
+type ConfigProblem struct {
+	// Path ubica el valor, p. ej. "profiles[2].shutdown_time" (vacío = todo el archivo)
+	Path    string
+	Message string
+}
+
+func (p ConfigProblem) String() string {
+	if p.Path == "" {
+		return p.Message
+	}
+	return p.Path + ": " + p.Message
+}
+
+// problemFields indica qué campo señala cada error de los validadores
+var problemFields = []struct {
+	err   error
+	field string
+}{
+	{ErrInvalidWebhookURL, "url"},
+	{ErrUnknownWebhookEvent, "events"},
+	{ErrInvalidMQTTBroker, "broker"},
+	{ErrInvalidPeerName, "name"},
+	{ErrInvalidPeerMAC, "mac"},
+	{ErrInvalidPeerBroadcast, "broadcast"},
+	{ErrInvalidPeerDelay, "delay_seconds"},
+	{ErrUnknownPeer, "depends_on"},
+	{ErrInvalidUPSName, "name"},
+	{ErrInvalidUPSAddress, "address"},
+	{ErrInvalidLatitude, "latitude"},
+	{ErrInvalidLongitude, "longitude"},
+}
+
+// Check revisa la configuración completa y retorna todos los problemas
+// encontrados (Validate se detiene en el primero). Además de lo que exige
+// Validate detecta horarios con encendido igual al apagado, ventanas más
+// cortas que MinScheduleWindow y horas que no existen en loc algún día del
+// año siguiente a now por el cambio de horario. Los horarios solares se
+// revisan resueltos para cada día de ese año.
+func (c *Config) Check(loc *time.Location, now time.Time) []ConfigProblem {
+	var problems []ConfigProblem
+	add := func(path, format string, args ...interface{}) {
+		problems = append(problems, ConfigProblem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	problems = append(problems, c.checkSchedule("", c.WakeTime, c.ShutdownTime, loc, now)...)
+
+	if c.Location != nil {
+		if err := c.Location.Validate(); err != nil {
+			add(problemPath("location", err), "%v", err)
+		}
+	}
+
+	if c.HistoryRetentionDays < 0 {
+		add("history_retention_days", "%v", ErrInvalidRetention)
+	}
+	if c.HistoryMaxEvents < 0 {
+		add("history_max_events", "%v", ErrInvalidRetention)
+	}
+	if c.ConfigHistoryVersions < 0 {
+		add("config_history_versions", "%v", ErrInvalidConfigHistory)
+	}
+	if c.ShutdownGraceSeconds < 0 {
+		add("shutdown_grace_seconds", "%v", ErrInvalidGrace)
+	}
+	if c.MaxSnoozeMinutes < 0 {
+		add("max_snooze_minutes", "%v", ErrInvalidSnoozeCap)
+	}
+	if err := checkWakeLeadSeconds(c.WakeLeadSeconds); err != nil {
+		add("wake_lead_seconds", "%v", err)
+	}
+	if err := checkReadyUnit(c.ReadyUnit); err != nil {
+		add("ready_unit", "%v", err)
+	}
+
+	for i := range c.Webhooks {
+		if err := c.Webhooks[i].Validate(); err != nil {
+			add(problemPath(fmt.Sprintf("webhooks[%d]", i), err), "%v", err)
+		}
+	}
+
+	if c.MQTT != nil {
+		if err := c.MQTT.Validate(); err != nil {
+			add(problemPath("mqtt", err), "%v", err)
+		}
+	}
+
+	peers := make(map[string]int, len(c.WakePeers))
+	for i := range c.WakePeers {
+		path := fmt.Sprintf("wake_peers[%d]", i)
+		if err := c.WakePeers[i].Validate(); err != nil {
+			add(problemPath(path, err), "%v", err)
+		}
+		if first, ok := peers[c.WakePeers[i].Name]; ok {
+			add(path+".name", "%v: %q (also wake_peers[%d])", ErrDuplicatePeer, c.WakePeers[i].Name, first)
+		}
+		peers[c.WakePeers[i].Name] = i
+	}
+	for i, peer := range c.WakePeers {
+		for _, dep := range peer.DependsOn {
+			if _, ok := peers[dep]; !ok {
+				add(fmt.Sprintf("wake_peers[%d].depends_on", i), "%v: %q", ErrUnknownPeer, dep)
+			}
+		}
+	}
+	if _, err := OrderWakePeers(c.WakePeers); errors.Is(err, ErrPeerDependencyCycle) {
+		add("wake_peers", "%v", err)
+	}
+
+	if c.UPS != nil {
+		if err := c.UPS.Validate(); err != nil {
+			add(problemPath("ups", err), "%v", err)
+		}
+	}
+
+	profiles := make(map[string]int, len(c.Profiles))
+	for i := range c.Profiles {
+		path := fmt.Sprintf("profiles[%d]", i)
+		profile := &c.Profiles[i]
+		if !profileNamePattern.MatchString(profile.Name) {
+			add(path+".name", "%v: %q", ErrInvalidProfileName, profile.Name)
+		}
+		if first, ok := profiles[profile.Name]; ok {
+			add(path+".name", "%v: %q (also profiles[%d])", ErrDuplicateProfile, profile.Name, first)
+		}
+		profiles[profile.Name] = i
+		problems = append(problems, c.checkSchedule(path+".", profile.WakeTime, profile.ShutdownTime, loc, now)...)
+	}
+	if _, ok := profiles[c.ActiveProfile]; c.ActiveProfile != "" && !ok {
+		add("active_profile", "%v: %q", ErrUnknownProfile, c.ActiveProfile)
+	}
+	for i, change := range c.ProfileSwitches {
+		if _, ok := profiles[change.Profile]; !ok {
+			add(fmt.Sprintf("profile_switches[%d].profile", i), "%v: %q", ErrUnknownProfile, change.Profile)
+		}
+	}
+
+	// Red de seguridad: todo lo que rechaza Validate tiene que aparecer
+	if err := c.Validate(); err != nil && len(problems) == 0 {
+		add("", "%v", err)
+	}
+	return problems
+}
+
+// checkSchedule revisa un par encendido/apagado; prefix es "" o "profiles[N]."
+func (c *Config) checkSchedule(prefix, wakeTime, shutdownTime string, loc *time.Location, now time.Time) []ConfigProblem {
+	var problems []ConfigProblem
+	add := func(field, format string, args ...interface{}) {
+		problems = append(problems, ConfigProblem{Path: prefix + field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	wake, wakeErr := checkClock(wakeTime, ErrEmptyWakeTime)
+	if wakeErr != nil {
+		add("wake_time", "%v", wakeErr)
+	}
+	shutdown, shutdownErr := checkClock(shutdownTime, ErrEmptyShutdownTime)
+	if shutdownErr != nil {
+		add("shutdown_time", "%v", shutdownErr)
+	}
+	if wakeErr != nil || shutdownErr != nil {
+		return problems
+	}
+
+	if IsSolarTime(wakeTime) || IsSolarTime(shutdownTime) {
+		field := "wake_time"
+		if !IsSolarTime(wakeTime) {
+			field = "shutdown_time"
+		}
+		if c.Location == nil {
+			add(field, "%v", ErrLocationRequired)
+			return problems
+		}
+		if c.Location.Validate() != nil {
+			return problems
+		}
+		return append(problems, c.checkSolarSchedule(prefix, wakeTime, shutdownTime, loc, now)...)
+	}
+
+	if field, message := checkWindow(wake, shutdown, wakeTime); message != "" {
+		add(field, "%s", message)
+	}
+
+	if loc != nil {
+		if day, ok := missingLocalTime(wakeTime, loc, now); ok {
+			add("wake_time", "%s does not exist on %s in %s (clock change)", wakeTime, day.Format("2006-01-02"), loc)
+		}
+		if day, ok := missingLocalTime(shutdownTime, loc, now); ok {
+			add("shutdown_time", "%s does not exist on %s in %s (clock change)", shutdownTime, day.Format("2006-01-02"), loc)
+		}
+	}
+	return problems
+}
+
+// checkSolarSchedule resuelve el horario para cada día del año siguiente a
+// now y reporta el primer día con una ventana demasiado corta y el primero
+// en que un evento solar no ocurre (noche o día polar)
+func (c *Config) checkSolarSchedule(prefix, wakeTime, shutdownTime string, loc *time.Location, now time.Time) []ConfigProblem {
+	var problems []ConfigProblem
+	if loc == nil {
+		loc = time.Local
+	}
+
+	times := []struct{ field, value string }{{"wake_time", wakeTime}, {"shutdown_time", shutdownTime}}
+	missing := make(map[string]bool, len(times))
+	windowChecked := false
+
+	start := now.In(loc)
+	for i := 0; i < 366; i++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()+i, 0, 0, 0, 0, loc)
+		var offsets [2]time.Duration
+		resolved := true
+		for j, entry := range times {
+			t, err := c.TimeOn(entry.value, day)
+			if err != nil {
+				resolved = false
+				if !missing[entry.field] {
+					missing[entry.field] = true
+					problems = append(problems, ConfigProblem{Path: prefix + entry.field,
+						Message: fmt.Sprintf("%v; days without it are skipped", err)})
+				}
+				continue
+			}
+			offsets[j] = t.Sub(day)
+		}
+		if !resolved || windowChecked {
+			continue
+		}
+		if field, message := checkWindow(offsets[0], offsets[1], wakeTime); message != "" {
+			windowChecked = true
+			problems = append(problems, ConfigProblem{Path: prefix + field,
+				Message: fmt.Sprintf("%s (on %s)", message, day.Format("2006-01-02"))})
+		}
+	}
+	return problems
+}
+
+// checkWindow revisa las ventanas encendido → apagado y apagado → encendido
+// en un día de 24 h; wake y shutdown son desplazamientos desde medianoche
+func checkWindow(wake, shutdown time.Duration, wakeTime string) (string, string) {
+	const day = 24 * time.Hour
+	awake := ((shutdown-wake)%day + day) % day
+	asleep := day - awake
+	switch {
+	case awake == 0:
+		return "shutdown_time", fmt.Sprintf("equals wake_time (%s); the machine would never stay on", wakeTime)
+	case awake < MinScheduleWindow:
+		return "shutdown_time", fmt.Sprintf("only %s after wake_time; at least %s needed", awake, MinScheduleWindow)
+	case asleep < MinScheduleWindow:
+		return "wake_time", fmt.Sprintf("only %s after shutdown_time; at least %s needed", asleep, MinScheduleWindow)
+	}
+	return "", ""
+}
+
+// checkClock interpreta "HH:MM" como desplazamiento desde medianoche; un
+// horario solar es válido pero depende del día (retorna 0)
+func checkClock(value string, empty error) (time.Duration, error) {
+	if value == "" {
+		return 0, empty
+	}
+	if IsSolarTime(value) {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTimeFormat, value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// missingLocalTime busca, en el año siguiente a now, un día en que la hora
+// "HH:MM" no existe en loc (el reloj la salta al adelantarse)
+func missingLocalTime(value string, loc *time.Location, now time.Time) (time.Time, bool) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	start := now.In(loc)
+	for i := 0; i < 366; i++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()+i, 0, 0, 0, 0, loc)
+		t := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+		if t.Hour() != clock.Hour() || t.Minute() != clock.Minute() {
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// problemPath agrega a base el campo que señala err, si se conoce
+func problemPath(base string, err error) string {
+	for _, known := range problemFields {
+		if errors.Is(err, known.err) {
+			return base + "." + known.field
+		}
+	}
+	return base
+}
\ No newline at end of file
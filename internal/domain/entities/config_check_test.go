@@ -0,0 +1,81 @@
+// internal/domain/entities/config_check_test.go
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigCheckReportsEveryProblemWithPath(t *testing.T) {
+	config := &Config{
+		WakeTime:         "07:00",
+		ShutdownTime:     "07:00",
+		MaxSnoozeMinutes: -1,
+		Webhooks:         []WebhookConfig{{URL: "https://ok.example.com"}, {URL: "ftp://nope"}},
+		WakePeers: []WakePeer{
+			{Name: "nas", MAC: "00:11:22:33:44:55", DependsOn: []string{"router"}},
+			{Name: "nas", MAC: "bad"},
+		},
+		Profiles: []Profile{
+			{Name: "work", WakeTime: "07:00", ShutdownTime: "23:00"},
+			{Name: "late", WakeTime: "23:50", ShutdownTime: "23:55"},
+			{Name: "nap", WakeTime: "08:00", ShutdownTime: "07:50"},
+		},
+		ActiveProfile:   "holiday",
+		ProfileSwitches: []ProfileSwitch{{Profile: "work"}, {Profile: "exams"}},
+	}
+
+	got := make(map[string]string)
+	for _, problem := range config.Check(time.UTC, time.Now()) {
+		got[problem.Path] = problem.Message
+	}
+
+	for _, path := range []string{
+		"shutdown_time",
+		"max_snooze_minutes",
+		"webhooks[1].url",
+		"wake_peers[0].depends_on",
+		"wake_peers[1].mac",
+		"wake_peers[1].name",
+		"profiles[1].shutdown_time",
+		"profiles[2].wake_time",
+		"active_profile",
+		"profile_switches[1].profile",
+	} {
+		if _, ok := got[path]; !ok {
+			t.Errorf("missing problem at %s (got %v)", path, got)
+		}
+	}
+	if _, ok := got["webhooks[0].url"]; ok {
+		t.Error("valid webhook reported")
+	}
+	if !strings.Contains(got["shutdown_time"], "equals wake_time") {
+		t.Errorf("shutdown_time: %q", got["shutdown_time"])
+	}
+}
+
+func TestConfigCheckValidConfig(t *testing.T) {
+	config, _ := NewConfig("07:00", "23:00", true)
+	if problems := config.Check(time.UTC, time.Now()); len(problems) != 0 {
+		t.Errorf("unexpected problems %v", problems)
+	}
+}
+
+func TestConfigCheckMissingLocalTime(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+
+	// 02:30 no existe el último domingo de marzo en Madrid
+	config, _ := NewConfig("02:30", "23:00", true)
+	problems := config.Check(madrid, time.Date(2026, 1, 1, 0, 0, 0, 0, madrid))
+	if len(problems) != 1 || problems[0].Path != "wake_time" || !strings.Contains(problems[0].Message, "2026-03-29") {
+		t.Errorf("expected the 2026-03-29 gap, got %v", problems)
+	}
+
+	if problems := config.Check(time.UTC, time.Now()); len(problems) != 0 {
+		t.Errorf("UTC has no clock changes: %v", problems)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,44 @@
+// internal/domain/entities/config_version.go
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrUnknownConfigVersion = errors.New("unknown configuration version")
+	ErrInvalidConfigHistory = errors.New("config history versions cannot be negative")
+)
+
+// DefaultConfigHistoryVersions es la cantidad de versiones conservadas si no se configura otra
+const DefaultConfigHistoryVersions = 20
+
+// ConfigVersion es una copia fechada de la configuración guardada
+type ConfigVersion struct {
+	ID      int
+	SavedAt time.Time
+	// By es el usuario que hizo el cambio (SUDO_USER) o vacío si se desconoce
+	By string
+	// Content es el JSON tal como se escribió
+	Content string
+	// Config es la versión interpretada (nil si no se pudo leer)
+	Config *Config
+}
+
+// Summary resume el horario de la versión para listados
+func (v *ConfigVersion) Summary() string {
+	if v.Config == nil {
+		return "unreadable"
+	}
+
+	state := "disabled"
+	if v.Config.Enabled {
+		state = "enabled"
+	}
+	summary := v.Config.WakeTime + "-" + v.Config.ShutdownTime + " " + state
+	if v.Config.ActiveProfile != "" {
+		summary += " (profile " + v.Config.ActiveProfile + ")"
+	}
+	return summary
+}
\ No newline at end of file
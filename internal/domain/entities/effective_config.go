@@ -0,0 +1,19 @@
+// internal/domain/entities/effective_config.go
+package entities
+
+// ConfigValue es un valor de la configuración efectiva y el archivo que lo definió
+type ConfigValue struct {
+	Path   string // ruta del valor, p. ej. "mqtt.broker"
+	Value  string // valor en JSON
+	Source string // archivo de donde salió
+}
+
+// EffectiveConfig es la configuración principal con los fragmentos aplicados
+type EffectiveConfig struct {
+	// Files son el archivo principal y los fragmentos, en el orden en que se aplican
+	Files []string
+	// Content es el JSON combinado
+	Content string
+	// Values son los valores finales (hojas), ordenados por ruta
+	Values []ConfigValue
+}
\ No newline at end of file
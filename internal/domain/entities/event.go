@@ -0,0 +1,73 @@
+// internal/domain/entities/event.go
+package entities
+
+import "time"
+
+// EventType identifica el tipo de evento de energía registrado en el historial
+type EventType string
+
+const (
+	EventAlarmArmed        EventType = "armed"
+	EventAlarmCleared      EventType = "cleared"
+	EventShutdownScheduled EventType = "scheduled"
+	EventShutdownCancelled EventType = "cancelled"
+	EventShutdownSnoozed   EventType = "snoozed"
+	EventOverrideChanged   EventType = "override"
+	EventProfileSwitched   EventType = "profile_switched"
+	EventConfigChanged     EventType = "config_changed"
+	EventResumed           EventType = "resumed"
+	EventBooted            EventType = "booted"
+	EventWakeDetected      EventType = "wake_detected"
+	EventWakeMissed        EventType = "wake_missed"
+	EventWakeReady         EventType = "wake_ready"
+	EventShutdownImminent  EventType = "shutdown_imminent"
+	EventShutdownExecuted  EventType = "shutdown_executed"
+	EventError             EventType = "error"
+	EventPeerWoken         EventType = "peer_woken"
+	EventUPSShutdown       EventType = "ups_shutdown"
+	EventUPSWakeRefused    EventType = "ups_wake_refused"
+)
+
+// EventLevel indica la severidad de un evento
+type EventLevel string
+
+const (
+	EventLevelInfo    EventLevel = "info"
+	EventLevelWarning EventLevel = "warning"
+	EventLevelError   EventLevel = "error"
+)
+
+// PowerEvent representa algo que realmente ocurrió en el sistema (alarma armada, arranque, etc.)
+type PowerEvent struct {
+	Type      EventType
+	Level     EventLevel
+	Timestamp time.Time
+	Message   string
+	Fields    map[string]string
+}
+
+// NewPowerEvent crea un evento informativo con la hora actual
+func NewPowerEvent(eventType EventType, message string, fields map[string]string) *PowerEvent {
+	if fields == nil {
+		fields = make(map[string]string)
+	}
+
+	return &PowerEvent{
+		Type:      eventType,
+		Level:     EventLevelInfo,
+		Timestamp: time.Now(),
+		Message:   message,
+		Fields:    fields,
+	}
+}
+
+// IsWithin verifica si el evento cae dentro del rango [from, to]; un límite cero no restringe
+func (e *PowerEvent) IsWithin(from, to time.Time) bool {
+	if !from.IsZero() && e.Timestamp.Before(from) {
+		return false
+	}
+	if !to.IsZero() && e.Timestamp.After(to) {
+		return false
+	}
+	return true
+}
\ No newline at end of file
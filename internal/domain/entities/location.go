@@ -0,0 +1,102 @@
+// internal/domain/entities/location.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"rtc-scheduler/pkg/solar"
+)
+
+var (
+	ErrInvalidLatitude  = errors.New("latitude must be between -90 and 90")
+	ErrInvalidLongitude = errors.New("longitude must be between -180 and 180")
+	ErrLocationRequired = errors.New("solar times (sunrise, sunset, dawn, dusk) need a location with latitude and longitude")
+	ErrNoSolarTime      = errors.New("solar time does not occur within a year at this location")
+)
+
+// Coordinates es la ubicación del equipo, usada para calcular los horarios
+// solares. Latitud norte y longitud este son positivas, en grados.
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Validate verifica que las coordenadas estén en rango
+func (c *Coordinates) Validate() error {
+	if c.Latitude < -90 || c.Latitude > 90 {
+		return fmt.Errorf("%w: %v", ErrInvalidLatitude, c.Latitude)
+	}
+	if c.Longitude < -180 || c.Longitude > 180 {
+		return fmt.Errorf("%w: %v", ErrInvalidLongitude, c.Longitude)
+	}
+	return nil
+}
+
+// IsSolarTime indica si value es un horario solar ("sunset-30m") y no HH:MM
+func IsSolarTime(value string) bool {
+	return solar.IsAnchor(value)
+}
+
+// isValidScheduleTime acepta HH:MM o un horario solar
+func isValidScheduleTime(value string) bool {
+	return isValidTimeFormat(value) || IsSolarTime(value)
+}
+
+// usesSolarTimes indica si el horario o algún perfil usa horarios solares
+func (c *Config) usesSolarTimes() bool {
+	if IsSolarTime(c.WakeTime) || IsSolarTime(c.ShutdownTime) {
+		return true
+	}
+	for _, profile := range c.Profiles {
+		if IsSolarTime(profile.WakeTime) || IsSolarTime(profile.ShutdownTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeOn resuelve value (HH:MM o un horario solar) para el día de day, en la
+// zona de day. Un horario solar con desplazamiento puede caer en el día
+// anterior o el siguiente; si el evento no ocurre ese día (noche o día
+// polar) retorna un error que envuelve solar.ErrNoEvent.
+func (c *Config) TimeOn(value string, day time.Time) (time.Time, error) {
+	if clock, err := time.Parse("15:04", value); err == nil {
+		return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location()), nil
+	}
+
+	anchor, err := solar.ParseAnchor(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimeFormat, value)
+	}
+	if c.Location == nil {
+		return time.Time{}, ErrLocationRequired
+	}
+	t, err := anchor.On(day, c.Location.Latitude, c.Location.Longitude)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s on %s: %w", anchor, day.Format("2006-01-02"), err)
+	}
+	// Las alarmas van al minuto, como los horarios HH:MM
+	return t.Round(time.Minute), nil
+}
+
+// nextTime retorna la primera ocurrencia de value que no sea anterior a from,
+// en la zona de from. Los días sin el evento solar se saltean.
+func (c *Config) nextTime(value string, from time.Time) (time.Time, error) {
+	// Desde el día anterior: "dusk+3h" de ayer puede caer hoy pasada la medianoche
+	for i := -1; i <= 366; i++ {
+		day := time.Date(from.Year(), from.Month(), from.Day()+i, 0, 0, 0, 0, from.Location())
+		t, err := c.TimeOn(value, day)
+		if errors.Is(err, solar.ErrNoEvent) {
+			continue
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !t.Before(from) {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %s", ErrNoSolarTime, value)
+}
\ No newline at end of file
@@ -0,0 +1,128 @@
+// internal/domain/entities/location_test.go
+package entities
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	london  = &Coordinates{Latitude: 51.5074, Longitude: -0.1278}
+	tromso  = &Coordinates{Latitude: 69.6492, Longitude: 18.9553}
+	bst     = time.FixedZone("BST", 3600)
+	cetZone = time.FixedZone("CET", 3600)
+)
+
+func TestConfigValidateSolarTimes(t *testing.T) {
+	config := &Config{WakeTime: "sunset-30m", ShutdownTime: "sunrise"}
+	if err := config.Validate(); !errors.Is(err, ErrLocationRequired) {
+		t.Errorf("no location: %v, want ErrLocationRequired", err)
+	}
+
+	config.Location = london
+	if err := config.Validate(); err != nil {
+		t.Errorf("with location: %v", err)
+	}
+
+	config.Location = &Coordinates{Latitude: 91}
+	if err := config.Validate(); !errors.Is(err, ErrInvalidLatitude) {
+		t.Errorf("latitude 91: %v", err)
+	}
+
+	config = &Config{WakeTime: "sunset-30", ShutdownTime: "23:00", Location: london}
+	if err := config.Validate(); !errors.Is(err, ErrInvalidTimeFormat) {
+		t.Errorf("bad offset: %v", err)
+	}
+
+	// Un perfil solar también necesita la ubicación
+	config = &Config{WakeTime: "07:00", ShutdownTime: "23:00",
+		Profiles: []Profile{{Name: "signage", WakeTime: "dusk", ShutdownTime: "dawn"}}}
+	if err := config.Validate(); !errors.Is(err, ErrLocationRequired) {
+		t.Errorf("solar profile without location: %v", err)
+	}
+}
+
+func TestConfigTimeOn(t *testing.T) {
+	config := &Config{Location: london}
+	day := time.Date(2024, 6, 20, 0, 0, 0, 0, bst)
+
+	got, err := config.TimeOn("07:30", day)
+	if err != nil || !got.Equal(time.Date(2024, 6, 20, 7, 30, 0, 0, bst)) {
+		t.Errorf("TimeOn(07:30) = %v, %v", got, err)
+	}
+
+	// Puesta del sol en Londres el 20/06/2024: 21:21 BST
+	got, err = config.TimeOn("sunset-30m", day)
+	if err != nil || !got.Equal(time.Date(2024, 6, 20, 20, 51, 0, 0, bst)) {
+		t.Errorf("TimeOn(sunset-30m) = %v, %v", got, err)
+	}
+
+	if _, err := (&Config{}).TimeOn("sunrise", day); !errors.Is(err, ErrLocationRequired) {
+		t.Errorf("no location: %v", err)
+	}
+}
+
+func TestNextTimeSolar(t *testing.T) {
+	config := &Config{WakeTime: "sunset-30m", ShutdownTime: "sunrise", Location: london}
+
+	// Después de la puesta: el encendido es mañana y el apagado, la salida siguiente
+	from := time.Date(2024, 6, 20, 22, 0, 0, 0, bst)
+	wake, err := config.nextTime(config.WakeTime, from)
+	if err != nil || wake.Day() != 21 || wake.Hour() != 20 {
+		t.Fatalf("wake = %v, %v", wake, err)
+	}
+	shutdown, err := config.nextTime(config.ShutdownTime, wake)
+	if err != nil || shutdown.Day() != 22 || shutdown.Hour() != 4 {
+		t.Errorf("shutdown = %v, %v", shutdown, err)
+	}
+
+	// Noche polar: no hay salida del sol hasta mediados de enero
+	config = &Config{WakeTime: "sunrise", ShutdownTime: "sunset", Location: tromso}
+	wake, err = config.nextTime(config.WakeTime, time.Date(2024, 12, 1, 12, 0, 0, 0, cetZone))
+	if err != nil || wake.Year() != 2025 || wake.Month() != time.January {
+		t.Errorf("polar night wake = %v, %v", wake, err)
+	}
+}
+
+func TestConfigCheckSolarSchedule(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, cetZone)
+
+	config := &Config{WakeTime: "sunset-30m", ShutdownTime: "sunrise"}
+	problems := config.Check(cetZone, now)
+	if len(problems) != 1 || problems[0].Path != "wake_time" || !strings.Contains(problems[0].Message, "location") {
+		t.Errorf("no location: %v", problems)
+	}
+
+	config.Location = london
+	if problems := config.Check(bst, now); len(problems) != 0 {
+		t.Errorf("valid solar schedule: %v", problems)
+	}
+
+	// En Tromsø no hay salida ni puesta parte del año
+	config.Location = tromso
+	got := make(map[string]string)
+	for _, problem := range config.Check(cetZone, now) {
+		got[problem.Path] = problem.Message
+	}
+	if !strings.Contains(got["wake_time"], "skipped") || !strings.Contains(got["shutdown_time"], "skipped") {
+		t.Errorf("polar days not reported: %v", got)
+	}
+
+	// Ventana de 10 minutos alrededor de la puesta
+	config = &Config{WakeTime: "sunset-5m", ShutdownTime: "sunset+5m", Location: london}
+	problems = config.Check(bst, now)
+	if len(problems) != 1 || problems[0].Path != "shutdown_time" || !strings.Contains(problems[0].Message, "only 10m0s") {
+		t.Errorf("short solar window: %v", problems)
+	}
+
+	config.Location = &Coordinates{Longitude: 200}
+	got = make(map[string]string)
+	for _, problem := range config.Check(bst, now) {
+		got[problem.Path] = problem.Message
+	}
+	if _, ok := got["location.longitude"]; !ok {
+		t.Errorf("bad longitude not reported: %v", got)
+	}
+}
\ No newline at end of file
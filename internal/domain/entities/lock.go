@@ -0,0 +1,38 @@
+// internal/domain/entities/lock.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrLockBusy = errors.New("another rtc-scheduler command is running")
+
+// LockHolder identifica al proceso que tiene tomado el lock de comandos
+type LockHolder struct {
+	PID     int
+	Command string
+	User    string
+	Since   time.Time
+}
+
+// String describe al poseedor para los mensajes de error
+func (h *LockHolder) String() string {
+	if h == nil || h.PID == 0 {
+		return "unknown process"
+	}
+
+	desc := fmt.Sprintf("pid %d", h.PID)
+	if h.Command != "" {
+		desc += " (" + h.Command
+		if h.User != "" {
+			desc += " by " + h.User
+		}
+		desc += ")"
+	}
+	if !h.Since.IsZero() {
+		desc += " since " + h.Since.Format("15:04:05")
+	}
+	return desc
+}
\ No newline at end of file
@@ -0,0 +1,80 @@
+// internal/domain/entities/mqtt.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrInvalidMQTTBroker = errors.New("MQTT broker must be a tcp://, mqtt://, ssl:// or mqtts:// URL")
+
+const (
+	// DefaultMQTTStatusInterval es cada cuánto se republica el estado
+	DefaultMQTTStatusInterval = 60 * time.Second
+	// DefaultMQTTDiscoveryPrefix es el prefijo de descubrimiento de Home Assistant
+	DefaultMQTTDiscoveryPrefix = "homeassistant"
+)
+
+// MQTTConfig configura la integración MQTT
+type MQTTConfig struct {
+	Broker   string
+	ClientID string
+	Username string
+	Password string
+	// TopicPrefix es la raíz de los tópicos (vacío = "rtc-scheduler/<hostname>")
+	TopicPrefix string
+	// Discovery publica la configuración de descubrimiento de Home Assistant
+	Discovery       bool
+	DiscoveryPrefix string
+	// StatusIntervalSeconds es cada cuánto se republica el estado (0 = 60s)
+	StatusIntervalSeconds int
+}
+
+// Validate verifica la URL del broker y los valores numéricos
+func (m *MQTTConfig) Validate() error {
+	u, err := url.Parse(m.Broker)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidMQTTBroker, m.Broker)
+	}
+	switch u.Scheme {
+	case "tcp", "mqtt", "ssl", "tls", "mqtts":
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidMQTTBroker, m.Broker)
+	}
+
+	if m.StatusIntervalSeconds < 0 {
+		return errors.New("MQTT status interval cannot be negative")
+	}
+	if strings.ContainsAny(m.TopicPrefix, "+#") {
+		return errors.New("MQTT topic prefix cannot contain wildcards")
+	}
+
+	return nil
+}
+
+// Prefix retorna la raíz de los tópicos para el equipo indicado
+func (m *MQTTConfig) Prefix(hostname string) string {
+	if m.TopicPrefix != "" {
+		return strings.TrimSuffix(m.TopicPrefix, "/")
+	}
+	return "rtc-scheduler/" + hostname
+}
+
+// StatusInterval retorna el intervalo de publicación del estado
+func (m *MQTTConfig) StatusInterval() time.Duration {
+	if m.StatusIntervalSeconds == 0 {
+		return DefaultMQTTStatusInterval
+	}
+	return time.Duration(m.StatusIntervalSeconds) * time.Second
+}
+
+// DiscoveryRoot retorna el prefijo de descubrimiento de Home Assistant
+func (m *MQTTConfig) DiscoveryRoot() string {
+	if m.DiscoveryPrefix != "" {
+		return m.DiscoveryPrefix
+	}
+	return DefaultMQTTDiscoveryPrefix
+}
\ No newline at end of file
@@ -0,0 +1,159 @@
+// internal/domain/entities/one_off.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidDateTime  = errors.New("invalid date and time, use YYYY-MM-DD HH:MM or RFC 3339")
+	ErrMixedTimeFormats = errors.New("wake and shutdown must both be HH:MM or both be a date and time")
+	ErrUnknownOneOff    = errors.New("no queued one-off with that ID")
+)
+
+// dateTimeLayout es el formato local de fecha y hora aceptado en modo manual
+const dateTimeLayout = "2006-01-02 15:04"
+
+// OneOff es un despertar y un apagado únicos en fechas concretas. Se combina
+// con el horario diario sin reemplazarlo: el equipo queda encendido mientras
+// esté dentro de cualquiera de las dos ventanas.
+type OneOff struct {
+	ID         string
+	WakeAt     time.Time
+	ShutdownAt time.Time
+	By         string
+}
+
+// NewOneOff crea una ventana única; el despertar debe ser futuro y anterior al apagado
+func NewOneOff(wakeAt, shutdownAt, now time.Time) (*OneOff, error) {
+	if !wakeAt.After(now) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidWakeTime, wakeAt.Format(dateTimeLayout))
+	}
+	if !shutdownAt.After(wakeAt) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidShutdownTime, shutdownAt.Format(dateTimeLayout))
+	}
+	return &OneOff{WakeAt: wakeAt, ShutdownAt: shutdownAt}, nil
+}
+
+// String retorna la ventana como "2026-12-24 06:00 → 2026-12-24 23:00"
+func (o *OneOff) String() string {
+	return o.WakeAt.Format(dateTimeLayout) + " → " + o.ShutdownAt.Format(dateTimeLayout)
+}
+
+// IsDateTime indica si value es una fecha con hora y no solo HH:MM o un
+// horario solar
+func IsDateTime(value string) bool {
+	return len(value) > len("15:04") && !IsSolarTime(value)
+}
+
+// ParseDateTime interpreta "YYYY-MM-DD HH:MM" (hora local) o RFC 3339
+func ParseDateTime(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation(dateTimeLayout, value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Local(), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDateTime, value)
+}
+
+// AddOneOff agrega una ventana a la cola con el siguiente ID libre
+func (o *ScheduleOverrides) AddOneOff(oneOff *OneOff) {
+	next := 1
+	for _, queued := range o.OneOffs {
+		if id, err := strconv.Atoi(queued.ID); err == nil && id >= next {
+			next = id + 1
+		}
+	}
+	oneOff.ID = strconv.Itoa(next)
+
+	o.OneOffs = append(o.OneOffs, oneOff)
+	sort.SliceStable(o.OneOffs, func(i, j int) bool { return o.OneOffs[i].WakeAt.Before(o.OneOffs[j].WakeAt) })
+}
+
+// CancelOneOff quita una ventana de la cola y la retorna
+func (o *ScheduleOverrides) CancelOneOff(id string) (*OneOff, error) {
+	for i, queued := range o.OneOffs {
+		if queued.ID == id {
+			o.OneOffs = append(o.OneOffs[:i], o.OneOffs[i+1:]...)
+			return queued, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownOneOff, id)
+}
+
+// Merge combina el próximo ciclo del horario diario (ya ajustado con Apply;
+// ceros si no hay horario) con las ventanas únicas y retorna el próximo
+// despertar y apagado. El apagado es el fin del período encendido actual: la
+// ventana en curso se extiende con las que se superponen. El despertar es el
+// primero posterior a ese apagado. Si no hay horario ni ventanas, retorna ceros.
+func (o *ScheduleOverrides) Merge(wake, shutdown, now time.Time) (time.Time, time.Time) {
+	if o == nil || len(o.OneOffs) == 0 {
+		return wake, shutdown
+	}
+
+	type window struct{ start, stop time.Time }
+	var windows []window
+	end := shutdown
+	active := false
+	for _, oneOff := range o.OneOffs {
+		if !oneOff.ShutdownAt.After(now) {
+			continue
+		}
+		windows = append(windows, window{oneOff.WakeAt, oneOff.ShutdownAt})
+		if !oneOff.WakeAt.After(now) && (!active || oneOff.ShutdownAt.After(end)) {
+			// Dentro de una ventana única: el horario diario solo cuenta si se superpone
+			end = oneOff.ShutdownAt
+			active = true
+		}
+	}
+
+	// La ventana diaria en curso (el apagado llega antes que el despertar)
+	if !wake.IsZero() && shutdown.Before(wake) {
+		windows = append(windows, window{now, shutdown})
+	}
+
+	if end.IsZero() {
+		// Sin horario diario y fuera de toda ventana: se sigue encendido hasta el fin de la próxima
+		if len(windows) == 0 {
+			return time.Time{}, time.Time{}
+		}
+		sort.Slice(windows, func(i, j int) bool { return windows[i].start.Before(windows[j].start) })
+		end = windows[0].stop
+	}
+
+	// Extender el período con las ventanas que empiezan antes de que termine,
+	// incluidas las diarias de los días siguientes (sin contemplar cambios de perfil)
+	for extended := true; extended; {
+		extended = false
+		for _, w := range windows {
+			if !w.start.After(end) && w.stop.After(end) {
+				end = w.stop
+				extended = true
+			}
+		}
+		for !wake.IsZero() && !wake.After(end) {
+			stop := shutdown
+			for !stop.After(wake) {
+				stop = stop.AddDate(0, 0, 1)
+			}
+			if stop.After(end) {
+				end = stop
+				extended = true
+			}
+			wake, _ = o.Apply(wake.AddDate(0, 0, 1), shutdown)
+		}
+	}
+
+	next := wake
+	for _, oneOff := range o.OneOffs {
+		if oneOff.WakeAt.After(end) && (next.IsZero() || oneOff.WakeAt.Before(next)) {
+			next = oneOff.WakeAt
+		}
+	}
+	return next, end
+}
\ No newline at end of file
@@ -0,0 +1,164 @@
+// internal/domain/entities/one_off_test.go
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// oneOffs crea una cola con ventanas [wake, shutdown)
+func oneOffs(windows ...[2]time.Time) *ScheduleOverrides {
+	overrides := &ScheduleOverrides{}
+	for _, w := range windows {
+		overrides.AddOneOff(&OneOff{WakeAt: w[0], ShutdownAt: w[1]})
+	}
+	return overrides
+}
+
+func TestMergeOneOffs(t *testing.T) {
+	tests := []struct {
+		name             string
+		overrides        *ScheduleOverrides
+		now              time.Time
+		wake, shutdown   time.Time // ciclo diario 07:00-22:00
+		wantWake, wantSD time.Time
+	}{
+		{
+			name:      "no one-offs keeps the daily cycle",
+			overrides: &ScheduleOverrides{},
+			now:       at(1, 12, 0), wake: at(2, 7, 0), shutdown: at(1, 22, 0),
+			wantWake: at(2, 7, 0), wantSD: at(1, 22, 0),
+		},
+		{
+			name:      "earlier one-off wake replaces the alarm",
+			overrides: oneOffs([2]time.Time{at(2, 6, 0), at(2, 23, 0)}),
+			now:       at(1, 12, 0), wake: at(2, 7, 0), shutdown: at(1, 22, 0),
+			wantWake: at(2, 6, 0), wantSD: at(1, 22, 0),
+		},
+		{
+			name:      "one-off in progress extends past the daily shutdown",
+			overrides: oneOffs([2]time.Time{at(2, 6, 0), at(2, 23, 0)}),
+			now:       at(2, 6, 1), wake: at(2, 7, 0), shutdown: at(2, 22, 0),
+			wantWake: at(3, 7, 0), wantSD: at(2, 23, 0),
+		},
+		{
+			name:      "short one-off before the daily window shuts down in between",
+			overrides: oneOffs([2]time.Time{at(2, 6, 0), at(2, 6, 30)}),
+			now:       at(2, 6, 1), wake: at(2, 7, 0), shutdown: at(2, 22, 0),
+			wantWake: at(2, 7, 0), wantSD: at(2, 6, 30),
+		},
+		{
+			name:      "overlapping one-off extends the daily window",
+			overrides: oneOffs([2]time.Time{at(1, 21, 0), at(1, 23, 30)}),
+			now:       at(1, 12, 0), wake: at(2, 7, 0), shutdown: at(1, 22, 0),
+			wantWake: at(2, 7, 0), wantSD: at(1, 23, 30),
+		},
+		{
+			name:      "one-off bridging into the next day keeps the machine on",
+			overrides: oneOffs([2]time.Time{at(1, 21, 0), at(2, 8, 0)}),
+			now:       at(1, 12, 0), wake: at(2, 7, 0), shutdown: at(1, 22, 0),
+			wantWake: at(3, 7, 0), wantSD: at(2, 22, 0),
+		},
+		{
+			name:      "one-offs only, outside any window",
+			overrides: oneOffs([2]time.Time{at(5, 6, 0), at(5, 9, 0)}, [2]time.Time{at(9, 6, 0), at(9, 9, 0)}),
+			now:       at(1, 12, 0),
+			wantWake:  at(9, 6, 0), wantSD: at(5, 9, 0),
+		},
+		{
+			name:      "one-offs only, inside a window",
+			overrides: oneOffs([2]time.Time{at(5, 6, 0), at(5, 9, 0)}, [2]time.Time{at(9, 6, 0), at(9, 9, 0)}),
+			now:       at(5, 6, 1),
+			wantWake:  at(9, 6, 0), wantSD: at(5, 9, 0),
+		},
+		{
+			name:      "past one-offs are ignored",
+			overrides: oneOffs([2]time.Time{at(1, 6, 0), at(1, 9, 0)}),
+			now:       at(1, 12, 0), wake: at(2, 7, 0), shutdown: at(1, 22, 0),
+			wantWake: at(2, 7, 0), wantSD: at(1, 22, 0),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wake, shutdown := tc.overrides.Merge(tc.wake, tc.shutdown, tc.now)
+			if !wake.Equal(tc.wantWake) || !shutdown.Equal(tc.wantSD) {
+				t.Errorf("Merge = %s / %s, want %s / %s", wake, shutdown, tc.wantWake, tc.wantSD)
+			}
+		})
+	}
+}
+
+func TestMergeNothingToArm(t *testing.T) {
+	wake, shutdown := (&ScheduleOverrides{}).Merge(time.Time{}, time.Time{}, at(1, 12, 0))
+	if !wake.IsZero() || !shutdown.IsZero() {
+		t.Errorf("Merge = %s / %s, want zeros", wake, shutdown)
+	}
+}
+
+func TestMergeSkipsVacationWhenAdvancingDailyWake(t *testing.T) {
+	vacation, _ := NewVacation("2025-07-03", "2025-07-04", at(1, 12, 0))
+	overrides := oneOffs([2]time.Time{at(2, 6, 0), at(2, 23, 0)})
+	overrides.Vacation = vacation
+
+	wake, _ := overrides.Merge(at(2, 7, 0), at(2, 22, 0), at(2, 6, 1))
+	if !wake.Equal(at(5, 7, 0)) {
+		t.Errorf("wake = %s, want the first daily wake after the vacation", wake)
+	}
+}
+
+func TestOneOffQueue(t *testing.T) {
+	overrides := &ScheduleOverrides{}
+	later := &OneOff{WakeAt: at(9, 6, 0), ShutdownAt: at(9, 9, 0)}
+	sooner := &OneOff{WakeAt: at(5, 6, 0), ShutdownAt: at(5, 9, 0)}
+	overrides.AddOneOff(later)
+	overrides.AddOneOff(sooner)
+
+	if later.ID != "1" || sooner.ID != "2" || overrides.OneOffs[0] != sooner {
+		t.Errorf("queue = %v %v, want IDs in order of creation and entries by wake", overrides.OneOffs[0], overrides.OneOffs[1])
+	}
+
+	if _, err := overrides.CancelOneOff("7"); !errors.Is(err, ErrUnknownOneOff) {
+		t.Errorf("cancel unknown: err = %v", err)
+	}
+	if cancelled, err := overrides.CancelOneOff("1"); err != nil || cancelled != later || len(overrides.OneOffs) != 1 {
+		t.Errorf("cancel 1: %v %v", cancelled, err)
+	}
+
+	if !overrides.Prune(at(5, 9, 0)) || !overrides.IsEmpty() {
+		t.Error("finished one-off should be pruned")
+	}
+}
+
+func TestNewOneOff(t *testing.T) {
+	now := at(1, 12, 0)
+	if _, err := NewOneOff(at(1, 11, 0), at(1, 23, 0), now); !errors.Is(err, ErrInvalidWakeTime) {
+		t.Errorf("past wake: err = %v", err)
+	}
+	if _, err := NewOneOff(at(2, 7, 0), at(2, 7, 0), now); !errors.Is(err, ErrInvalidShutdownTime) {
+		t.Errorf("shutdown not after wake: err = %v", err)
+	}
+	if oneOff, err := NewOneOff(at(2, 7, 0), at(2, 9, 0), now); err != nil || oneOff.String() != "2025-07-02 07:00 → 2025-07-02 09:00" {
+		t.Errorf("NewOneOff = %v, %v", oneOff, err)
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	local, err := ParseDateTime("2026-12-24 06:00")
+	if err != nil || !local.Equal(time.Date(2026, 12, 24, 6, 0, 0, 0, time.Local)) {
+		t.Errorf("local: %v %v", local, err)
+	}
+
+	rfc, err := ParseDateTime("2026-12-24T06:00:00Z")
+	if err != nil || !rfc.Equal(time.Date(2026, 12, 24, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("RFC 3339: %v %v", rfc, err)
+	}
+
+	if _, err := ParseDateTime("24/12/2026 06:00"); !errors.Is(err, ErrInvalidDateTime) {
+		t.Errorf("bad format: err = %v", err)
+	}
+	if IsDateTime("06:00") || !IsDateTime("2026-12-24 06:00") {
+		t.Error("IsDateTime misclassifies")
+	}
+}
\ No newline at end of file
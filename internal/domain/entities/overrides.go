@@ -0,0 +1,171 @@
+// internal/domain/entities/overrides.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidVacation  = errors.New("invalid vacation range, use YYYY-MM-DD YYYY-MM-DD")
+	ErrVacationTooLong  = errors.New("vacation cannot be longer than a year")
+	ErrVacationInPast   = errors.New("vacation already ended")
+	ErrNoScheduleToSkip = errors.New("no upcoming wake to skip (service disabled or not configured)")
+)
+
+const (
+	// MaxVacationDays limita la duración de un modo vacaciones
+	MaxVacationDays = 366
+	// vacationDateLayout es el formato de las fechas de -vacation
+	vacationDateLayout = "2006-01-02"
+)
+
+// SkipNext omite una sola vez el próximo despertar y, opcionalmente, el próximo apagado
+type SkipNext struct {
+	// WakeAt es el despertar que se omite
+	WakeAt time.Time
+	// SkipShutdown indica si también se omite el apagado ShutdownAt
+	SkipShutdown bool
+	ShutdownAt   time.Time
+	By           string
+	CreatedAt    time.Time
+}
+
+// Expired indica si los eventos omitidos ya pasaron
+func (s *SkipNext) Expired(now time.Time) bool {
+	last := s.WakeAt
+	if s.SkipShutdown && s.ShutdownAt.After(last) {
+		last = s.ShutdownAt
+	}
+	return now.After(last.Add(time.Minute))
+}
+
+// Vacation mantiene el equipo apagado entre dos fechas (ambas incluidas)
+type Vacation struct {
+	// From y To son medianoche local del primer y último día
+	From      time.Time
+	To        time.Time
+	By        string
+	CreatedAt time.Time
+}
+
+// NewVacation crea un rango de vacaciones a partir de fechas YYYY-MM-DD
+func NewVacation(from, to string, now time.Time) (*Vacation, error) {
+	start, err := time.ParseInLocation(vacationDateLayout, from, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidVacation, from)
+	}
+	end, err := time.ParseInLocation(vacationDateLayout, to, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidVacation, to)
+	}
+
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: %s is before %s", ErrInvalidVacation, to, from)
+	}
+	if end.Sub(start) >= MaxVacationDays*24*time.Hour {
+		return nil, ErrVacationTooLong
+	}
+
+	vacation := &Vacation{From: start, To: end, CreatedAt: now}
+	if vacation.Expired(now) {
+		return nil, ErrVacationInPast
+	}
+	return vacation, nil
+}
+
+// End retorna el instante en que termina el rango (medianoche tras el último día)
+func (v *Vacation) End() time.Time {
+	return v.To.AddDate(0, 0, 1)
+}
+
+// Contains indica si t cae dentro de las vacaciones
+func (v *Vacation) Contains(t time.Time) bool {
+	return !t.Before(v.From) && t.Before(v.End())
+}
+
+// Expired indica si las vacaciones ya terminaron
+func (v *Vacation) Expired(now time.Time) bool {
+	return !now.Before(v.End())
+}
+
+// String retorna el rango como "YYYY-MM-DD → YYYY-MM-DD"
+func (v *Vacation) String() string {
+	return v.From.Format(vacationDateLayout) + " → " + v.To.Format(vacationDateLayout)
+}
+
+// ScheduleOverrides son excepciones temporales al horario diario. Se eliminan
+// solas al vencer, sin necesidad de volver a habilitar el servicio.
+type ScheduleOverrides struct {
+	Skip     *SkipNext
+	Vacation *Vacation
+	// OneOffs es la cola de ventanas únicas, ordenada por despertar
+	OneOffs []*OneOff
+}
+
+// IsEmpty indica si no hay ninguna excepción
+func (o *ScheduleOverrides) IsEmpty() bool {
+	return o == nil || (o.Skip == nil && o.Vacation == nil && len(o.OneOffs) == 0)
+}
+
+// Prune elimina las excepciones vencidas; retorna true si hubo cambios
+func (o *ScheduleOverrides) Prune(now time.Time) bool {
+	if o == nil {
+		return false
+	}
+
+	changed := false
+	if o.Skip != nil && o.Skip.Expired(now) {
+		o.Skip = nil
+		changed = true
+	}
+	if o.Vacation != nil && o.Vacation.Expired(now) {
+		o.Vacation = nil
+		changed = true
+	}
+
+	var pending []*OneOff
+	for _, oneOff := range o.OneOffs {
+		if oneOff.ShutdownAt.After(now) {
+			pending = append(pending, oneOff)
+		}
+	}
+	if len(pending) != len(o.OneOffs) {
+		o.OneOffs = pending
+		changed = true
+	}
+	return changed
+}
+
+// Apply ajusta el próximo despertar y apagado del horario diario: el despertar
+// omitido pasa al día siguiente, y los que caen en vacaciones, al primer día
+// después de ellas. El apagado solo se mueve si se pidió omitirlo.
+func (o *ScheduleOverrides) Apply(wake, shutdown time.Time) (time.Time, time.Time) {
+	if o == nil {
+		return wake, shutdown
+	}
+
+	if o.Skip != nil {
+		if sameMinute(wake, o.Skip.WakeAt) {
+			wake = wake.AddDate(0, 0, 1)
+		}
+		if o.Skip.SkipShutdown && sameMinute(shutdown, o.Skip.ShutdownAt) {
+			shutdown = shutdown.AddDate(0, 0, 1)
+		}
+	}
+
+	if o.Vacation != nil {
+		for o.Vacation.Contains(wake) {
+			wake = wake.AddDate(0, 0, 1)
+		}
+	}
+
+	return wake, shutdown
+}
+
+// sameMinute compara dos instantes con la precisión de los planificadores
+func sameMinute(a, b time.Time) bool {
+	diff := a.Sub(b)
+	return diff > -time.Minute && diff < time.Minute
+}
\ No newline at end of file
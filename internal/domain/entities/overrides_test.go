@@ -0,0 +1,89 @@
+// internal/domain/entities/overrides_test.go
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func at(day, hour, minute int) time.Time {
+	return time.Date(2025, 7, day, hour, minute, 0, 0, time.Local)
+}
+
+func TestOverridesApplySkip(t *testing.T) {
+	overrides := &ScheduleOverrides{Skip: &SkipNext{WakeAt: at(2, 7, 0), ShutdownAt: at(1, 22, 0)}}
+
+	wake, shutdown := overrides.Apply(at(2, 7, 0), at(1, 22, 0))
+	if !wake.Equal(at(3, 7, 0)) || !shutdown.Equal(at(1, 22, 0)) {
+		t.Errorf("skip wake: got %s / %s", wake, shutdown)
+	}
+
+	overrides.Skip.SkipShutdown = true
+	wake, shutdown = overrides.Apply(at(2, 7, 0), at(1, 22, 0))
+	if !wake.Equal(at(3, 7, 0)) || !shutdown.Equal(at(2, 22, 0)) {
+		t.Errorf("skip wake and shutdown: got %s / %s", wake, shutdown)
+	}
+
+	// Un despertar distinto del omitido no se toca
+	wake, _ = overrides.Apply(at(3, 7, 0), at(2, 22, 0))
+	if !wake.Equal(at(3, 7, 0)) {
+		t.Errorf("later wake should not move: got %s", wake)
+	}
+}
+
+func TestOverridesApplyVacation(t *testing.T) {
+	vacation, err := NewVacation("2025-07-05", "2025-07-10", at(1, 12, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	overrides := &ScheduleOverrides{Vacation: vacation}
+
+	wake, shutdown := overrides.Apply(at(5, 7, 0), at(4, 22, 0))
+	if !wake.Equal(at(11, 7, 0)) || !shutdown.Equal(at(4, 22, 0)) {
+		t.Errorf("vacation: got %s / %s", wake, shutdown)
+	}
+
+	wake, _ = overrides.Apply(at(4, 7, 0), at(4, 22, 0))
+	if !wake.Equal(at(4, 7, 0)) {
+		t.Errorf("wake before vacation should not move: got %s", wake)
+	}
+}
+
+func TestOverridesPrune(t *testing.T) {
+	vacation, _ := NewVacation("2025-07-05", "2025-07-10", at(1, 12, 0))
+	overrides := &ScheduleOverrides{Skip: &SkipNext{WakeAt: at(2, 7, 0)}, Vacation: vacation}
+
+	if overrides.Prune(at(2, 6, 0)) {
+		t.Error("nothing should expire before the skipped wake")
+	}
+	if !overrides.Prune(at(2, 8, 0)) || overrides.Skip != nil || overrides.Vacation == nil {
+		t.Errorf("skip should expire after its wake: %+v", overrides)
+	}
+	if !overrides.Prune(at(11, 0, 0)) || !overrides.IsEmpty() {
+		t.Errorf("vacation should expire after its last day: %+v", overrides)
+	}
+}
+
+func TestNewVacationErrors(t *testing.T) {
+	now := at(15, 12, 0)
+	tests := []struct {
+		from, to string
+		want     error
+	}{
+		{"2025-07-20", "2025-07-18", ErrInvalidVacation},
+		{"20/07/2025", "2025-07-21", ErrInvalidVacation},
+		{"2025-07-01", "2025-07-14", ErrVacationInPast},
+		{"2025-07-20", "2026-08-01", ErrVacationTooLong},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewVacation(tt.from, tt.to, now); !errors.Is(err, tt.want) {
+			t.Errorf("NewVacation(%s, %s) = %v, want %v", tt.from, tt.to, err, tt.want)
+		}
+	}
+
+	if _, err := NewVacation("2025-07-10", "2025-07-15", now); err != nil {
+		t.Errorf("vacation ending today should be accepted: %v", err)
+	}
+}
\ No newline at end of file
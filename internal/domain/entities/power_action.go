@@ -0,0 +1,29 @@
+// internal/domain/entities/power_action.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUnknownPowerAction = errors.New("unknown power action (use suspend, poweroff or hibernate)")
+
+// PowerAction es la acción que se ejecuta a la hora de apagado
+type PowerAction string
+
+const (
+	PowerActionSuspend   PowerAction = "suspend"
+	PowerActionPoweroff  PowerAction = "poweroff"
+	PowerActionHibernate PowerAction = "hibernate"
+)
+
+// ParsePowerAction convierte un nombre en PowerAction
+func ParsePowerAction(value string) (PowerAction, error) {
+	switch action := PowerAction(strings.ToLower(strings.TrimSpace(value))); action {
+	case PowerActionSuspend, PowerActionPoweroff, PowerActionHibernate:
+		return action, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownPowerAction, value)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,265 @@
+// internal/domain/entities/profile.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+)
+
+var (
+	ErrInvalidProfileName   = errors.New("profile name may only contain letters, digits, '-' and '_'")
+	ErrUnknownProfile       = errors.New("unknown profile")
+	ErrDuplicateProfile     = errors.New("profile already exists")
+	ErrActiveProfile        = errors.New("cannot delete the active profile")
+	ErrInvalidProfileSwitch = errors.New("invalid profile switch date, use YYYY-MM-DD")
+	ErrProfileSwitchPast    = errors.New("profile switch date must be in the future")
+)
+
+// profileNamePattern son los nombres de perfil aceptados
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// Profile es un horario con nombre ("work", "exams", ...) que se puede activar
+type Profile struct {
+	Name         string
+	WakeTime     string
+	ShutdownTime string
+}
+
+// Validate verifica el nombre y los horarios del perfil
+func (p *Profile) Validate() error {
+	if !profileNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("%w: %q", ErrInvalidProfileName, p.Name)
+	}
+	if p.WakeTime == "" {
+		return ErrEmptyWakeTime
+	}
+	if p.ShutdownTime == "" {
+		return ErrEmptyShutdownTime
+	}
+	if !isValidScheduleTime(p.WakeTime) || !isValidScheduleTime(p.ShutdownTime) {
+		return ErrInvalidTimeFormat
+	}
+	return nil
+}
+
+// ProfileSwitch activa un perfil automáticamente al comenzar un día
+type ProfileSwitch struct {
+	Profile string
+	// At es la medianoche local del día del cambio
+	At time.Time
+}
+
+// NewProfileSwitch crea un cambio de perfil a partir de una fecha YYYY-MM-DD
+func NewProfileSwitch(profile, date string, now time.Time) (*ProfileSwitch, error) {
+	at, err := time.ParseInLocation(vacationDateLayout, date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidProfileSwitch, date)
+	}
+	if !at.After(now) {
+		return nil, fmt.Errorf("%w: %s", ErrProfileSwitchPast, date)
+	}
+	return &ProfileSwitch{Profile: profile, At: at}, nil
+}
+
+// String retorna el cambio como "YYYY-MM-DD → perfil"
+func (s *ProfileSwitch) String() string {
+	return fmt.Sprintf("%s → %s", s.At.Format(vacationDateLayout), s.Profile)
+}
+
+// FindProfile busca un perfil por nombre
+func (c *Config) FindProfile(name string) (*Profile, error) {
+	for i := range c.Profiles {
+		if c.Profiles[i].Name == name {
+			return &c.Profiles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownProfile, name)
+}
+
+// AddProfile agrega un perfil nuevo
+func (c *Config) AddProfile(profile Profile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	if c.Location == nil && (IsSolarTime(profile.WakeTime) || IsSolarTime(profile.ShutdownTime)) {
+		return ErrLocationRequired
+	}
+	if _, err := c.FindProfile(profile.Name); err == nil {
+		return fmt.Errorf("%w: %q", ErrDuplicateProfile, profile.Name)
+	}
+
+	c.Profiles = append(c.Profiles, profile)
+	c.Update()
+	return nil
+}
+
+// DeleteProfile elimina un perfil inactivo y los cambios programados hacia él
+func (c *Config) DeleteProfile(name string) error {
+	if _, err := c.FindProfile(name); err != nil {
+		return err
+	}
+	if c.ActiveProfile == name {
+		return fmt.Errorf("%w: %q", ErrActiveProfile, name)
+	}
+
+	profiles := c.Profiles[:0]
+	for _, p := range c.Profiles {
+		if p.Name != name {
+			profiles = append(profiles, p)
+		}
+	}
+	c.Profiles = profiles
+
+	switches := c.ProfileSwitches[:0]
+	for _, s := range c.ProfileSwitches {
+		if s.Profile != name {
+			switches = append(switches, s)
+		}
+	}
+	c.ProfileSwitches = switches
+
+	c.Update()
+	return nil
+}
+
+// UseProfile activa un perfil: su horario pasa a ser el horario diario
+func (c *Config) UseProfile(name string) error {
+	profile, err := c.FindProfile(name)
+	if err != nil {
+		return err
+	}
+
+	c.ActiveProfile = profile.Name
+	c.WakeTime = profile.WakeTime
+	c.ShutdownTime = profile.ShutdownTime
+	c.Update()
+	return nil
+}
+
+// SetSchedule cambia el horario diario; si hay un perfil activo, también se actualiza
+func (c *Config) SetSchedule(wakeTime, shutdownTime string) {
+	c.WakeTime = wakeTime
+	c.ShutdownTime = shutdownTime
+
+	if profile, err := c.FindProfile(c.ActiveProfile); err == nil {
+		profile.WakeTime = wakeTime
+		profile.ShutdownTime = shutdownTime
+	}
+	c.Update()
+}
+
+// ScheduleProfileSwitch programa un cambio de perfil; reemplaza otro del mismo día
+func (c *Config) ScheduleProfileSwitch(change ProfileSwitch) error {
+	if _, err := c.FindProfile(change.Profile); err != nil {
+		return err
+	}
+
+	switches := c.ProfileSwitches[:0]
+	for _, s := range c.ProfileSwitches {
+		if !s.At.Equal(change.At) {
+			switches = append(switches, s)
+		}
+	}
+	c.ProfileSwitches = append(switches, change)
+	sort.Slice(c.ProfileSwitches, func(i, j int) bool {
+		return c.ProfileSwitches[i].At.Before(c.ProfileSwitches[j].At)
+	})
+
+	c.Update()
+	return nil
+}
+
+// NextProfileSwitch retorna el próximo cambio de perfil pendiente (nil si no hay)
+func (c *Config) NextProfileSwitch() *ProfileSwitch {
+	if len(c.ProfileSwitches) == 0 {
+		return nil
+	}
+	next := c.ProfileSwitches[0]
+	for _, s := range c.ProfileSwitches[1:] {
+		if s.At.Before(next.At) {
+			next = s
+		}
+	}
+	return &next
+}
+
+// ApplyDueProfileSwitches activa, en orden, los cambios cuya fecha ya llegó y
+// los elimina de la lista. Retorna el último aplicado (nil si no hubo ninguno).
+func (c *Config) ApplyDueProfileSwitches(now time.Time) (*ProfileSwitch, error) {
+	var applied *ProfileSwitch
+	for {
+		next := c.NextProfileSwitch()
+		if next == nil || next.At.After(now) {
+			return applied, nil
+		}
+
+		if err := c.UseProfile(next.Profile); err != nil {
+			return applied, err
+		}
+
+		switches := c.ProfileSwitches[:0]
+		for _, s := range c.ProfileSwitches {
+			if !s.At.Equal(next.At) {
+				switches = append(switches, s)
+			}
+		}
+		c.ProfileSwitches = switches
+		applied = next
+	}
+}
+
+// UpcomingSchedule retorna el próximo ciclo. Si un cambio de perfil pendiente
+// ya estará vigente al despertar, el ciclo se calcula con ese perfil.
+func (c *Config) UpcomingSchedule() (*Schedule, error) {
+	schedule, err := c.ParseToSchedule()
+	if err != nil {
+		return nil, err
+	}
+
+	next := c.NextProfileSwitch()
+	if next == nil || schedule.WakeTime.Before(next.At) {
+		return schedule, nil
+	}
+
+	profile, err := c.FindProfile(next.Profile)
+	if err != nil {
+		return schedule, nil
+	}
+
+	switched := *c
+	switched.WakeTime = profile.WakeTime
+	switched.ShutdownTime = profile.ShutdownTime
+	upcoming, err := switched.ParseToSchedule()
+	if err != nil || upcoming.WakeTime.Before(next.At) {
+		return schedule, nil
+	}
+	return upcoming, nil
+}
+
+// validateProfiles verifica los perfiles, el perfil activo y los cambios programados
+func (c *Config) validateProfiles() error {
+	seen := make(map[string]bool, len(c.Profiles))
+	for i := range c.Profiles {
+		if err := c.Profiles[i].Validate(); err != nil {
+			return fmt.Errorf("profile %d: %w", i+1, err)
+		}
+		if seen[c.Profiles[i].Name] {
+			return fmt.Errorf("%w: %q", ErrDuplicateProfile, c.Profiles[i].Name)
+		}
+		seen[c.Profiles[i].Name] = true
+	}
+
+	if c.ActiveProfile != "" && !seen[c.ActiveProfile] {
+		return fmt.Errorf("active profile: %w: %q", ErrUnknownProfile, c.ActiveProfile)
+	}
+
+	for _, s := range c.ProfileSwitches {
+		if !seen[s.Profile] {
+			return fmt.Errorf("profile switch %s: %w: %q", s.At.Format(vacationDateLayout), ErrUnknownProfile, s.Profile)
+		}
+	}
+	return nil
+}
\ No newline at end of file
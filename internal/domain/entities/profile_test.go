@@ -0,0 +1,162 @@
+// internal/domain/entities/profile_test.go
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func profileConfig(t *testing.T) *Config {
+	t.Helper()
+	config, err := NewConfig("07:00", "23:00", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []Profile{
+		{Name: "work", WakeTime: "07:00", ShutdownTime: "23:00"},
+		{Name: "exams", WakeTime: "06:00", ShutdownTime: "23:30"},
+	} {
+		if err := config.AddProfile(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return config
+}
+
+func TestProfileValidate(t *testing.T) {
+	cases := []struct {
+		profile Profile
+		err     error
+	}{
+		{Profile{Name: "work", WakeTime: "07:00", ShutdownTime: "23:00"}, nil},
+		{Profile{Name: "exam period", WakeTime: "07:00", ShutdownTime: "23:00"}, ErrInvalidProfileName},
+		{Profile{Name: "", WakeTime: "07:00", ShutdownTime: "23:00"}, ErrInvalidProfileName},
+		{Profile{Name: "work", ShutdownTime: "23:00"}, ErrEmptyWakeTime},
+		{Profile{Name: "work", WakeTime: "7am", ShutdownTime: "23:00"}, ErrInvalidTimeFormat},
+	}
+
+	for _, tc := range cases {
+		if err := tc.profile.Validate(); !errors.Is(err, tc.err) {
+			t.Errorf("%+v: got %v, want %v", tc.profile, err, tc.err)
+		}
+	}
+}
+
+func TestConfigProfiles(t *testing.T) {
+	config := profileConfig(t)
+
+	if err := config.AddProfile(Profile{Name: "work", WakeTime: "08:00", ShutdownTime: "22:00"}); !errors.Is(err, ErrDuplicateProfile) {
+		t.Errorf("duplicate profile: got %v", err)
+	}
+
+	if err := config.UseProfile("exams"); err != nil {
+		t.Fatal(err)
+	}
+	if config.ActiveProfile != "exams" || config.WakeTime != "06:00" || config.ShutdownTime != "23:30" {
+		t.Errorf("use exams: got %q %s %s", config.ActiveProfile, config.WakeTime, config.ShutdownTime)
+	}
+
+	// Cambiar el horario diario actualiza el perfil activo
+	config.SetSchedule("05:30", "23:30")
+	if p, _ := config.FindProfile("exams"); p.WakeTime != "05:30" {
+		t.Errorf("active profile not updated: %+v", p)
+	}
+
+	if err := config.DeleteProfile("exams"); !errors.Is(err, ErrActiveProfile) {
+		t.Errorf("delete active: got %v", err)
+	}
+	if err := config.UseProfile("missing"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("use missing: got %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("valid config: %v", err)
+	}
+
+	config.ActiveProfile = "missing"
+	if err := config.Validate(); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("unknown active profile: got %v", err)
+	}
+}
+
+func TestProfileSwitches(t *testing.T) {
+	config := profileConfig(t)
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.Local)
+
+	if _, err := NewProfileSwitch("exams", "2025-09-01", now); !errors.Is(err, ErrProfileSwitchPast) {
+		t.Errorf("switch today: got %v", err)
+	}
+	if _, err := NewProfileSwitch("exams", "01/09/2025", now); !errors.Is(err, ErrInvalidProfileSwitch) {
+		t.Errorf("bad date: got %v", err)
+	}
+
+	for _, s := range []struct{ profile, date string }{{"work", "2025-10-01"}, {"exams", "2025-09-15"}} {
+		change, err := NewProfileSwitch(s.profile, s.date, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := config.ScheduleProfileSwitch(*change); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := config.ScheduleProfileSwitch(ProfileSwitch{Profile: "missing", At: now.Add(48 * time.Hour)}); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("switch to unknown profile: got %v", err)
+	}
+
+	if next := config.NextProfileSwitch(); next == nil || next.Profile != "exams" {
+		t.Fatalf("next switch: got %+v", next)
+	}
+
+	// Nada vence antes de la fecha
+	if applied, _ := config.ApplyDueProfileSwitches(now); applied != nil {
+		t.Errorf("nothing due yet, applied %+v", applied)
+	}
+
+	// Ambos vencidos: se aplican en orden y queda el último
+	applied, err := config.ApplyDueProfileSwitches(time.Date(2025, 10, 2, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied == nil || applied.Profile != "work" || config.ActiveProfile != "work" || len(config.ProfileSwitches) != 0 {
+		t.Errorf("apply due: got %+v, active %q, pending %d", applied, config.ActiveProfile, len(config.ProfileSwitches))
+	}
+}
+
+func TestDeleteProfileDropsSwitches(t *testing.T) {
+	config := profileConfig(t)
+	config.ProfileSwitches = []ProfileSwitch{{Profile: "exams", At: time.Now().Add(72 * time.Hour)}}
+
+	if err := config.DeleteProfile("exams"); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Profiles) != 1 || len(config.ProfileSwitches) != 0 {
+		t.Errorf("after delete: %d profiles, %d switches", len(config.Profiles), len(config.ProfileSwitches))
+	}
+}
+
+func TestUpcomingScheduleUsesPendingProfile(t *testing.T) {
+	config := profileConfig(t)
+	if err := config.UseProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Un cambio lejano no afecta el próximo ciclo
+	config.ProfileSwitches = []ProfileSwitch{{Profile: "exams", At: time.Now().Add(30 * 24 * time.Hour)}}
+	schedule, err := config.UpcomingSchedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.WakeTime.Format("15:04") != "07:00" {
+		t.Errorf("far switch: wake at %s", schedule.WakeTime)
+	}
+
+	// Un cambio ya vigente al despertar usa el horario del nuevo perfil
+	config.ProfileSwitches = []ProfileSwitch{{Profile: "exams", At: time.Now().Add(-time.Minute)}}
+	schedule, err = config.UpcomingSchedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.WakeTime.Format("15:04") != "06:00" {
+		t.Errorf("due switch: wake at %s", schedule.WakeTime)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,90 @@
+// internal/domain/entities/snooze.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidSnooze      = errors.New("snooze duration must be positive")
+	ErrSnoozeLimitReached = errors.New("daily snooze limit reached")
+	ErrSnoozePastWake     = errors.New("snooze would move the shutdown past the next wake alarm")
+)
+
+const (
+	// DefaultMaxSnoozePerDay es la extensión total permitida por día si no se configura otra
+	DefaultMaxSnoozePerDay = 3 * time.Hour
+	// SnoozeWakeMargin es la separación mínima entre el apagado pospuesto y la alarma RTC
+	SnoozeWakeMargin = 10 * time.Minute
+)
+
+// SnoozeState registra el apagado pospuesto y cuánto se extendió en el día
+type SnoozeState struct {
+	// Day es la fecha local (YYYY-MM-DD) a la que corresponde Extended
+	Day string
+	// Extended es la extensión acumulada en Day
+	Extended time.Duration
+	// OriginalShutdown es el apagado que estaba programado antes del primer snooze
+	OriginalShutdown time.Time
+	// ShutdownAt es el apagado vigente tras el último snooze
+	ShutdownAt time.Time
+	// By es quién pidió el último snooze (usuario, "mqtt", ...)
+	By string
+	// At es cuándo se pidió el último snooze
+	At time.Time
+}
+
+// Active indica si el apagado pospuesto todavía no ocurrió
+func (s *SnoozeState) Active(now time.Time) bool {
+	return s != nil && s.ShutdownAt.After(now)
+}
+
+// Used retorna la extensión ya usada en el día de now
+func (s *SnoozeState) Used(now time.Time) time.Duration {
+	if s == nil || s.Day != snoozeDay(now) {
+		return 0
+	}
+	return s.Extended
+}
+
+// Extend pospone el apagado pendiente en d. pending es el apagado programado
+// actualmente y wakeAlarm la alarma RTC armada (cero si no hay ninguna).
+// Retorna el nuevo estado sin modificar el receptor, que puede ser nil.
+func (s *SnoozeState) Extend(d, limit time.Duration, pending, wakeAlarm, now time.Time, by string) (*SnoozeState, error) {
+	if d <= 0 {
+		return nil, ErrInvalidSnooze
+	}
+
+	used := s.Used(now)
+	if used+d > limit {
+		return nil, fmt.Errorf("%w: %s of %s already used today", ErrSnoozeLimitReached, used, limit)
+	}
+
+	shutdownAt := pending.Add(d)
+	if !wakeAlarm.IsZero() && wakeAlarm.After(pending) && shutdownAt.After(wakeAlarm.Add(-SnoozeWakeMargin)) {
+		return nil, fmt.Errorf("%w (%s)", ErrSnoozePastWake, wakeAlarm.Format("2006-01-02 15:04"))
+	}
+
+	// Los planificadores redondean al minuto: si el apagado pendiente es el del
+	// snooze anterior, se conserva el apagado original
+	original := pending
+	if s.Active(now) && s.ShutdownAt.Sub(pending) < time.Minute && pending.Sub(s.ShutdownAt) < time.Minute {
+		original = s.OriginalShutdown
+	}
+
+	return &SnoozeState{
+		Day:              snoozeDay(now),
+		Extended:         used + d,
+		OriginalShutdown: original,
+		ShutdownAt:       shutdownAt,
+		By:               by,
+		At:               now,
+	}, nil
+}
+
+// snoozeDay retorna la fecha local usada para el límite diario
+func snoozeDay(t time.Time) string {
+	return t.Local().Format("2006-01-02")
+}
\ No newline at end of file
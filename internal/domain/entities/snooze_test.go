@@ -0,0 +1,59 @@
+// internal/domain/entities/snooze_test.go
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSnoozeExtend(t *testing.T) {
+	now := time.Date(2025, 3, 10, 21, 30, 0, 0, time.Local)
+	pending := time.Date(2025, 3, 10, 22, 0, 0, 0, time.Local)
+	wake := time.Date(2025, 3, 11, 7, 0, 0, 0, time.Local)
+
+	var state *SnoozeState
+	state, err := state.Extend(time.Hour, 2*time.Hour, pending, wake, now, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.ShutdownAt.Equal(pending.Add(time.Hour)) || state.Extended != time.Hour || state.By != "alice" {
+		t.Errorf("unexpected state %+v", state)
+	}
+
+	// Segundo snooze sobre el apagado ya pospuesto: conserva el original
+	second, err := state.Extend(time.Hour, 2*time.Hour, state.ShutdownAt, wake, now.Add(10*time.Minute), "mqtt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !second.OriginalShutdown.Equal(pending) || second.Extended != 2*time.Hour {
+		t.Errorf("unexpected state %+v", second)
+	}
+
+	if _, err := second.Extend(time.Minute, 2*time.Hour, second.ShutdownAt, wake, now.Add(20*time.Minute), "bob"); !errors.Is(err, ErrSnoozeLimitReached) {
+		t.Errorf("expected limit error, got %v", err)
+	}
+
+	// Al día siguiente el límite se reinicia
+	tomorrow := now.Add(24 * time.Hour)
+	if _, err := second.Extend(time.Hour, 2*time.Hour, pending.Add(24*time.Hour), wake.Add(24*time.Hour), tomorrow, "bob"); err != nil {
+		t.Errorf("limit should reset on a new day: %v", err)
+	}
+}
+
+func TestSnoozeRespectsWakeAlarm(t *testing.T) {
+	now := time.Date(2025, 3, 10, 5, 0, 0, 0, time.Local)
+	pending := time.Date(2025, 3, 10, 5, 30, 0, 0, time.Local)
+	wake := time.Date(2025, 3, 10, 7, 0, 0, 0, time.Local)
+
+	var state *SnoozeState
+	if _, err := state.Extend(85*time.Minute, 3*time.Hour, pending, wake, now, "alice"); !errors.Is(err, ErrSnoozePastWake) {
+		t.Errorf("expected ErrSnoozePastWake, got %v", err)
+	}
+	if _, err := state.Extend(time.Hour, 3*time.Hour, pending, wake, now, "alice"); err != nil {
+		t.Errorf("snooze ending 30 minutes before the wake should be allowed: %v", err)
+	}
+	if _, err := state.Extend(0, 3*time.Hour, pending, wake, now, "alice"); !errors.Is(err, ErrInvalidSnooze) {
+		t.Errorf("expected ErrInvalidSnooze, got %v", err)
+	}
+}
\ No newline at end of file
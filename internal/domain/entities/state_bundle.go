@@ -0,0 +1,14 @@
+// internal/domain/entities/state_bundle.go
+package entities
+
+import "time"
+
+// StateBundle es el estado completo de un equipo tal como se exporta e importa:
+// la configuración (horario, perfiles, webhooks, MQTT, peers y UPS) y las
+// excepciones al horario (skip-next y vacaciones)
+type StateBundle struct {
+	Config    *Config
+	Overrides *ScheduleOverrides
+	// ExportedAt es cero cuando el archivo era una configuración suelta
+	ExportedAt time.Time
+}
\ No newline at end of file
@@ -0,0 +1,192 @@
+// internal/domain/entities/ups.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidUPSName      = errors.New("UPS name cannot be empty")
+	ErrInvalidUPSAddress   = errors.New("UPS address must be host:port")
+	ErrInvalidUPSThreshold = errors.New("UPS battery thresholds must be between 0 and 100")
+	ErrInvalidUPSInterval  = errors.New("UPS intervals cannot be negative")
+)
+
+const (
+	// DefaultUPSAddress es la dirección por defecto de upsd
+	DefaultUPSAddress = "localhost:3493"
+	// DefaultUPSPollInterval es cada cuánto se consulta el estado
+	DefaultUPSPollInterval = 30 * time.Second
+	// DefaultUPSShutdownCharge es la carga bajo la cual se suspende estando en batería
+	DefaultUPSShutdownCharge = 50
+	// DefaultUPSResumeCharge es la carga mínima para aceptar un despertar
+	DefaultUPSResumeCharge = 80
+	// DefaultUPSRecoveryWake es cuánto después de un apagado por batería se vuelve a despertar
+	DefaultUPSRecoveryWake = time.Hour
+	// UPSShutdownLead es la espera mínima antes del apagado anticipado
+	UPSShutdownLead = time.Minute
+)
+
+// UPSConfig configura la consulta a un servidor NUT (upsd)
+type UPSConfig struct {
+	// Name es el nombre de la UPS en upsd (p.ej. "ups" en "ups@localhost")
+	Name string
+	// Address es "host:puerto" de upsd (vacío = localhost:3493)
+	Address  string
+	Username string
+	Password string
+	// PollIntervalSeconds es cada cuánto se consulta el estado (0 = 30s)
+	PollIntervalSeconds int
+	// ShutdownCharge es el porcentaje bajo el cual se suspende en batería (0 = 50)
+	ShutdownCharge int
+	// ResumeCharge es el porcentaje mínimo para aceptar un despertar (0 = 80)
+	ResumeCharge int
+	// RecoveryWakeMinutes es la espera hasta el siguiente intento de despertar (0 = 60)
+	RecoveryWakeMinutes int
+}
+
+// Validate verifica el nombre, la dirección y los umbrales
+func (u *UPSConfig) Validate() error {
+	if strings.TrimSpace(u.Name) == "" {
+		return ErrInvalidUPSName
+	}
+
+	if u.Address != "" {
+		if _, _, err := net.SplitHostPort(u.Address); err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidUPSAddress, u.Address)
+		}
+	}
+
+	for _, value := range []int{u.ShutdownCharge, u.ResumeCharge} {
+		if value < 0 || value > 100 {
+			return ErrInvalidUPSThreshold
+		}
+	}
+	if u.ResumeThreshold() < u.ShutdownThreshold() {
+		return fmt.Errorf("%w: resume charge (%d) is below shutdown charge (%d)",
+			ErrInvalidUPSThreshold, u.ResumeThreshold(), u.ShutdownThreshold())
+	}
+
+	if u.PollIntervalSeconds < 0 || u.RecoveryWakeMinutes < 0 {
+		return ErrInvalidUPSInterval
+	}
+
+	return nil
+}
+
+// ServerAddress retorna la dirección de upsd
+func (u *UPSConfig) ServerAddress() string {
+	if u.Address == "" {
+		return DefaultUPSAddress
+	}
+	return u.Address
+}
+
+// PollInterval retorna el intervalo de consulta
+func (u *UPSConfig) PollInterval() time.Duration {
+	if u.PollIntervalSeconds == 0 {
+		return DefaultUPSPollInterval
+	}
+	return time.Duration(u.PollIntervalSeconds) * time.Second
+}
+
+// ShutdownThreshold retorna la carga bajo la cual se suspende en batería
+func (u *UPSConfig) ShutdownThreshold() int {
+	if u.ShutdownCharge == 0 {
+		return DefaultUPSShutdownCharge
+	}
+	return u.ShutdownCharge
+}
+
+// ResumeThreshold retorna la carga mínima para aceptar un despertar
+func (u *UPSConfig) ResumeThreshold() int {
+	if u.ResumeCharge == 0 {
+		return DefaultUPSResumeCharge
+	}
+	return u.ResumeCharge
+}
+
+// RecoveryWake retorna la espera hasta el siguiente intento de despertar
+func (u *UPSConfig) RecoveryWake() time.Duration {
+	if u.RecoveryWakeMinutes == 0 {
+		return DefaultUPSRecoveryWake
+	}
+	return time.Duration(u.RecoveryWakeMinutes) * time.Minute
+}
+
+// ShouldShutdown indica si el estado de la UPS exige un apagado anticipado:
+// en batería con la carga bajo el umbral, o con la bandera LB (batería baja)
+func (u *UPSConfig) ShouldShutdown(status *UPSStatus) (bool, string) {
+	if !status.OnBattery() {
+		return false, ""
+	}
+	if status.LowBattery() {
+		return true, "UPS reports low battery"
+	}
+	if status.HasCharge() && status.Charge < float64(u.ShutdownThreshold()) {
+		return true, fmt.Sprintf("battery at %.0f%% (below %d%%)", status.Charge, u.ShutdownThreshold())
+	}
+	return false, ""
+}
+
+// WakeAllowed indica si la energía y la batería se recuperaron lo suficiente
+// para aceptar un despertar programado
+func (u *UPSConfig) WakeAllowed(status *UPSStatus) (bool, string) {
+	if status.OnBattery() {
+		return false, "UPS is still on battery"
+	}
+	if status.HasCharge() && status.Charge < float64(u.ResumeThreshold()) {
+		return false, fmt.Sprintf("battery at %.0f%% (needs %d%%)", status.Charge, u.ResumeThreshold())
+	}
+	return true, ""
+}
+
+// UPSStatus es el estado informado por upsd
+type UPSStatus struct {
+	// Flags son los indicadores de ups.status (OL, OB, LB, CHRG, ...)
+	Flags []string
+	// Charge es battery.charge en porcentaje, o -1 si la UPS no lo informa
+	Charge float64
+}
+
+// ParseUPSFlags separa el valor de ups.status en indicadores
+func ParseUPSFlags(value string) []string {
+	return strings.Fields(strings.ToUpper(value))
+}
+
+// OnBattery indica si la UPS está alimentando desde la batería
+func (s *UPSStatus) OnBattery() bool {
+	return s.hasFlag("OB")
+}
+
+// LowBattery indica si la UPS informa batería baja
+func (s *UPSStatus) LowBattery() bool {
+	return s.hasFlag("LB")
+}
+
+// HasCharge indica si se conoce la carga de la batería
+func (s *UPSStatus) HasCharge() bool {
+	return s.Charge >= 0
+}
+
+// String retorna un resumen legible ("OB DISCHRG, 42%")
+func (s *UPSStatus) String() string {
+	summary := strings.Join(s.Flags, " ")
+	if s.HasCharge() {
+		summary += fmt.Sprintf(", %.0f%%", s.Charge)
+	}
+	return summary
+}
+
+func (s *UPSStatus) hasFlag(flag string) bool {
+	for _, f := range s.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
@@ -0,0 +1,68 @@
+// internal/domain/entities/ups_test.go
+package entities
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUPSShouldShutdown(t *testing.T) {
+	config := &UPSConfig{Name: "ups", ShutdownCharge: 40}
+
+	tests := []struct {
+		status UPSStatus
+		want   bool
+	}{
+		{UPSStatus{Flags: ParseUPSFlags("OL CHRG"), Charge: 10}, false},
+		{UPSStatus{Flags: ParseUPSFlags("OB DISCHRG"), Charge: 60}, false},
+		{UPSStatus{Flags: ParseUPSFlags("OB DISCHRG"), Charge: 39}, true},
+		{UPSStatus{Flags: ParseUPSFlags("ob lb"), Charge: -1}, true},
+		{UPSStatus{Flags: ParseUPSFlags("OB"), Charge: -1}, false},
+	}
+
+	for _, tt := range tests {
+		if got, reason := config.ShouldShutdown(&tt.status); got != tt.want {
+			t.Errorf("ShouldShutdown(%s) = %v (%s), want %v", tt.status.String(), got, reason, tt.want)
+		}
+	}
+}
+
+func TestUPSWakeAllowed(t *testing.T) {
+	config := &UPSConfig{Name: "ups"}
+
+	tests := []struct {
+		status UPSStatus
+		want   bool
+	}{
+		{UPSStatus{Flags: ParseUPSFlags("OL"), Charge: 95}, true},
+		{UPSStatus{Flags: ParseUPSFlags("OL CHRG"), Charge: 70}, false},
+		{UPSStatus{Flags: ParseUPSFlags("OB"), Charge: 100}, false},
+		{UPSStatus{Flags: ParseUPSFlags("OL"), Charge: -1}, true},
+	}
+
+	for _, tt := range tests {
+		if got, reason := config.WakeAllowed(&tt.status); got != tt.want {
+			t.Errorf("WakeAllowed(%s) = %v (%s), want %v", tt.status.String(), got, reason, tt.want)
+		}
+	}
+}
+
+func TestUPSValidate(t *testing.T) {
+	tests := []struct {
+		config UPSConfig
+		want   error
+	}{
+		{UPSConfig{Name: "ups", Address: "nas.lan:3493"}, nil},
+		{UPSConfig{Name: ""}, ErrInvalidUPSName},
+		{UPSConfig{Name: "ups", Address: "nas.lan"}, ErrInvalidUPSAddress},
+		{UPSConfig{Name: "ups", ShutdownCharge: 101}, ErrInvalidUPSThreshold},
+		{UPSConfig{Name: "ups", ShutdownCharge: 60, ResumeCharge: 50}, ErrInvalidUPSThreshold},
+		{UPSConfig{Name: "ups", PollIntervalSeconds: -1}, ErrInvalidUPSInterval},
+	}
+
+	for _, tt := range tests {
+		if err := tt.config.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("Validate(%+v) = %v, want %v", tt.config, err, tt.want)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,109 @@
+// internal/domain/entities/wake.go
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultWakeTolerance es la desviación máxima para considerar un despertar puntual
+	DefaultWakeTolerance = 2 * time.Minute
+	// DefaultLateWindow es el retraso máximo para considerar que la alarma RTC
+	// despertó al equipo tarde; pasado ese margen la alarma se da por perdida
+	DefaultLateWindow = 30 * time.Minute
+)
+
+// WakeKind distingue un arranque en frío de una reanudación desde suspensión
+type WakeKind string
+
+const (
+	WakeKindBoot   WakeKind = "boot"
+	WakeKindResume WakeKind = "resume"
+)
+
+// WakeOutcome es la clasificación de un despertar respecto a la alarma armada
+type WakeOutcome string
+
+const (
+	WakeOnTime WakeOutcome = "on-time"
+	WakeLate   WakeOutcome = "late"
+	WakeEarly  WakeOutcome = "early"
+	WakeMissed WakeOutcome = "missed"
+)
+
+// ArmedWake es la alarma RTC que se programó, persistida para verificarla tras despertar
+type ArmedWake struct {
+	WakeTime time.Time
+	ArmedAt  time.Time
+}
+
+// WakeSource describe la fuente de wakeup del kernel que despertó al equipo
+type WakeSource struct {
+	Name string
+	RTC  bool // true si la fuente corresponde al RTC (rtc0, alarmtimer, ...)
+}
+
+// WakeVerification es el resultado de comparar la alarma armada con el despertar real
+type WakeVerification struct {
+	Kind     WakeKind
+	ArmedFor time.Time
+	WokeAt   time.Time
+	Offset   time.Duration // WokeAt - ArmedFor (positivo = tarde)
+	Source   *WakeSource   // nil si el kernel no permite determinarla
+	Outcome  WakeOutcome
+}
+
+// VerifyWake clasifica un despertar ocurrido en wokeAt respecto a la alarma armada.
+//
+//   - early:   el equipo despertó antes de la alarma (otra fuente lo despertó)
+//   - on-time: dentro de la tolerancia
+//   - late:    después de la tolerancia pero dentro de la ventana de retraso,
+//     salvo que el kernel indique que la fuente no fue el RTC
+//   - missed:  la alarma no despertó al equipo
+func VerifyWake(armed *ArmedWake, kind WakeKind, wokeAt time.Time, source *WakeSource, tolerance, lateWindow time.Duration) *WakeVerification {
+	offset := wokeAt.Sub(armed.WakeTime)
+
+	verification := &WakeVerification{
+		Kind:     kind,
+		ArmedFor: armed.WakeTime,
+		WokeAt:   wokeAt,
+		Offset:   offset,
+		Source:   source,
+	}
+
+	sourceIsNotRTC := source != nil && !source.RTC
+
+	switch {
+	case offset < -tolerance:
+		verification.Outcome = WakeEarly
+	case offset <= tolerance:
+		verification.Outcome = WakeOnTime
+	case offset <= lateWindow && !sourceIsNotRTC:
+		verification.Outcome = WakeLate
+	default:
+		verification.Outcome = WakeMissed
+	}
+
+	return verification
+}
+
+// Summary retorna una descripción legible del resultado
+func (v *WakeVerification) Summary() string {
+	source := "unknown source"
+	if v.Source != nil {
+		source = "source " + v.Source.Name
+	}
+
+	return fmt.Sprintf("%s %s (%s from alarm at %s, %s)",
+		v.Kind, v.Outcome, formatOffset(v.Offset), v.ArmedFor.Format("2006-01-02 15:04:05"), source)
+}
+
+// formatOffset formatea un desfase con signo, redondeado a segundos
+func formatOffset(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d >= 0 {
+		return "+" + d.String()
+	}
+	return d.String()
+}
\ No newline at end of file
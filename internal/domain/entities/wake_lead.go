@@ -0,0 +1,145 @@
+// internal/domain/entities/wake_lead.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidWakeLead  = errors.New("wake lead must be between 0 and 1800 seconds")
+	ErrInvalidReadyUnit = errors.New("ready unit must be a systemd unit name like network-online.target")
+)
+
+const (
+	// MaxWakeLead es la anticipación máxima de la alarma, fija o aprendida
+	MaxWakeLead = 30 * time.Minute
+	// MaxWakeToReady descarta mediciones más largas (un fsck, un arranque manual)
+	MaxWakeToReady = time.Hour
+	// WakeLeadSamples es cuántas mediciones recientes se usan para aprender la anticipación
+	WakeLeadSamples = 10
+	// DefaultReadyUnit es la unidad que marca el equipo como usable
+	DefaultReadyUnit = "multi-user.target"
+
+	// wakeLeadStep redondea hacia arriba la anticipación aprendida
+	wakeLeadStep = 15 * time.Second
+)
+
+// readyUnitPattern son los nombres de unidad systemd aceptados
+var readyUnitPattern = regexp.MustCompile(`^[A-Za-z0-9:_.@\\-]+\.(target|service|mount|socket|path|device)$`)
+
+// WakeLead es la anticipación con que se arma la alarma RTC para que el
+// equipo esté usable a la hora configurada
+type WakeLead struct {
+	// Lead es la anticipación efectiva: la mayor entre Fixed y Learned
+	Lead    time.Duration
+	Fixed   time.Duration
+	Learned time.Duration
+	// Samples es la cantidad de mediciones usadas para aprender (0 = sin aprendizaje)
+	Samples int
+}
+
+// validateWakeLead verifica la anticipación fija y la unidad de "listo"
+func (c *Config) validateWakeLead() error {
+	if err := checkWakeLeadSeconds(c.WakeLeadSeconds); err != nil {
+		return err
+	}
+	return checkReadyUnit(c.ReadyUnit)
+}
+
+// checkWakeLeadSeconds verifica la anticipación fija
+func checkWakeLeadSeconds(seconds int) error {
+	if seconds < 0 || time.Duration(seconds)*time.Second > MaxWakeLead {
+		return fmt.Errorf("%w: %d", ErrInvalidWakeLead, seconds)
+	}
+	return nil
+}
+
+// checkReadyUnit verifica el nombre de la unidad de "listo" (vacío = por defecto)
+func checkReadyUnit(unit string) error {
+	if unit != "" && !readyUnitPattern.MatchString(unit) {
+		return fmt.Errorf("%w: %q", ErrInvalidReadyUnit, unit)
+	}
+	return nil
+}
+
+// ReadyTarget retorna la unidad que marca el equipo como usable
+func (c *Config) ReadyTarget() string {
+	if c.ReadyUnit == "" {
+		return DefaultReadyUnit
+	}
+	return c.ReadyUnit
+}
+
+// WakeLead calcula la anticipación de la alarma: la fija y, si se aprende,
+// la medición más larga entre samples (las más recientes, de la más vieja a
+// la más nueva), redondeada hacia arriba a 15 s. Se usa la mayor de las dos,
+// hasta MaxWakeLead.
+func (c *Config) WakeLead(samples []time.Duration) WakeLead {
+	lead := WakeLead{Fixed: time.Duration(c.WakeLeadSeconds) * time.Second}
+
+	if c.LearnWakeLead && len(samples) > 0 {
+		if len(samples) > WakeLeadSamples {
+			samples = samples[len(samples)-WakeLeadSamples:]
+		}
+		for _, sample := range samples {
+			if sample > lead.Learned {
+				lead.Learned = sample
+			}
+		}
+		if rest := lead.Learned % wakeLeadStep; rest != 0 {
+			lead.Learned += wakeLeadStep - rest
+		}
+		lead.Samples = len(samples)
+	}
+
+	lead.Lead = lead.Fixed
+	if lead.Learned > lead.Lead {
+		lead.Lead = lead.Learned
+	}
+	if lead.Lead > MaxWakeLead {
+		lead.Lead = MaxWakeLead
+	}
+	return lead
+}
+
+// AlarmFor retorna la hora de la alarma para estar usable en target. Si la
+// anticipación la dejaría en el pasado, la alarma queda en target.
+func (l WakeLead) AlarmFor(target, now time.Time) time.Time {
+	alarm := target.Add(-l.Lead)
+	if !alarm.After(now) {
+		return target
+	}
+	return alarm
+}
+
+// String describe la anticipación: "2m45s (learned from 6 wakes)"
+func (l WakeLead) String() string {
+	switch {
+	case l.Lead == 0:
+		return "none"
+	case l.Samples > 0 && l.Learned >= l.Fixed:
+		return fmt.Sprintf("%s (learned from %d wakes)", l.Lead, l.Samples)
+	}
+	return fmt.Sprintf("%s (fixed)", l.Lead)
+}
+
+// WakeReadySamples retorna los tiempos de alarma a "listo" registrados en
+// events (eventos wake_ready), del más viejo al más nuevo
+func WakeReadySamples(events []*PowerEvent) []time.Duration {
+	var samples []time.Duration
+	for _, event := range events {
+		if event.Type != EventWakeReady {
+			continue
+		}
+		seconds, err := strconv.ParseFloat(event.Fields["wake_to_ready_seconds"], 64)
+		if err != nil || seconds < 0 {
+			continue
+		}
+		samples = append(samples, time.Duration(seconds*float64(time.Second)))
+	}
+	return samples
+}
\ No newline at end of file
@@ -0,0 +1,120 @@
+// internal/domain/entities/wake_lead_test.go
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfigWakeLead(t *testing.T) {
+	samples := []time.Duration{10 * time.Minute, 50 * time.Second, 95 * time.Second, 70 * time.Second}
+
+	config := &Config{WakeLeadSeconds: 60}
+	if lead := config.WakeLead(samples); lead.Lead != time.Minute || lead.Samples != 0 || lead.String() != "1m0s (fixed)" {
+		t.Errorf("fixed lead = %+v (%s)", lead, lead)
+	}
+
+	// Sin la muestra más vieja (10 min), la más larga es 95 s: 1m45s
+	config.LearnWakeLead = true
+	recent := append(make([]time.Duration, 0, 12), samples...)
+	for len(recent) < 11 {
+		recent = append(recent, 30*time.Second)
+	}
+	lead := config.WakeLead(recent)
+	if lead.Learned != 105*time.Second || lead.Lead != 105*time.Second || lead.Samples != WakeLeadSamples {
+		t.Errorf("learned lead = %+v", lead)
+	}
+	if lead.String() != "1m45s (learned from 10 wakes)" {
+		t.Errorf("String() = %q", lead.String())
+	}
+
+	// La fija gana si es mayor
+	config.WakeLeadSeconds = 300
+	if lead := config.WakeLead(recent); lead.Lead != 5*time.Minute || lead.String() != "5m0s (fixed)" {
+		t.Errorf("fixed over learned = %+v (%s)", lead, lead)
+	}
+
+	// Nunca más que MaxWakeLead
+	config.WakeLeadSeconds = 0
+	if lead := config.WakeLead([]time.Duration{45 * time.Minute}); lead.Lead != MaxWakeLead {
+		t.Errorf("capped lead = %+v", lead)
+	}
+
+	if lead := (&Config{}).WakeLead(samples); lead.Lead != 0 || lead.String() != "none" {
+		t.Errorf("no lead = %+v (%s)", lead, lead)
+	}
+}
+
+func TestWakeLeadAlarmFor(t *testing.T) {
+	lead := WakeLead{Lead: 3 * time.Minute}
+	target := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	if got := lead.AlarmFor(target, target.Add(-time.Hour)); !got.Equal(target.Add(-3 * time.Minute)) {
+		t.Errorf("AlarmFor = %v", got)
+	}
+	// Si la alarma adelantada ya pasó, se arma a la hora objetivo
+	if got := lead.AlarmFor(target, target.Add(-time.Minute)); !got.Equal(target) {
+		t.Errorf("AlarmFor in the past = %v", got)
+	}
+}
+
+func TestWakeReadySamples(t *testing.T) {
+	events := []*PowerEvent{
+		{Type: EventWakeReady, Fields: map[string]string{"wake_to_ready_seconds": "84"}},
+		{Type: EventWakeDetected, Fields: map[string]string{"offset_seconds": "5"}},
+		{Type: EventWakeReady, Fields: map[string]string{"wake_to_ready_seconds": "oops"}},
+		{Type: EventWakeReady, Fields: map[string]string{"wake_to_ready_seconds": "-3"}},
+		{Type: EventWakeReady, Fields: map[string]string{"wake_to_ready_seconds": "121"}},
+	}
+
+	samples := WakeReadySamples(events)
+	if len(samples) != 2 || samples[0] != 84*time.Second || samples[1] != 121*time.Second {
+		t.Errorf("WakeReadySamples = %v", samples)
+	}
+}
+
+func TestConfigValidateWakeLead(t *testing.T) {
+	base := Config{WakeTime: "07:00", ShutdownTime: "23:00"}
+
+	tests := []struct {
+		name   string
+		lead   int
+		unit   string
+		expect error
+	}{
+		{"no lead", 0, "", nil},
+		{"fixed lead and unit", 120, "network-online.target", nil},
+		{"template unit", 0, "getty@tty1.service", nil},
+		{"negative lead", -1, "", ErrInvalidWakeLead},
+		{"lead too long", 1801, "", ErrInvalidWakeLead},
+		{"unit without type", 0, "network-online", ErrInvalidReadyUnit},
+		{"unit with spaces", 0, "my unit.service", ErrInvalidReadyUnit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := base
+			config.WakeLeadSeconds = tt.lead
+			config.ReadyUnit = tt.unit
+			if err := config.Validate(); !errors.Is(err, tt.expect) {
+				t.Errorf("Validate() = %v, want %v", err, tt.expect)
+			}
+		})
+	}
+
+	config := base
+	config.WakeLeadSeconds = 3600
+	config.ReadyUnit = "bad"
+	got := make(map[string]bool)
+	for _, problem := range config.Check(time.UTC, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)) {
+		got[problem.Path] = true
+	}
+	if !got["wake_lead_seconds"] || !got["ready_unit"] {
+		t.Errorf("Check() problems = %v", got)
+	}
+
+	if target := base.ReadyTarget(); target != DefaultReadyUnit {
+		t.Errorf("ReadyTarget() = %q", target)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,147 @@
+// internal/domain/entities/wake_peer.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidPeerName      = errors.New("wake peer name cannot be empty")
+	ErrDuplicatePeer        = errors.New("duplicate wake peer name")
+	ErrInvalidPeerMAC       = errors.New("invalid wake peer MAC address")
+	ErrInvalidPeerBroadcast = errors.New("invalid wake peer broadcast address")
+	ErrInvalidPeerDelay     = errors.New("wake peer delay cannot be negative")
+	ErrUnknownPeer          = errors.New("unknown wake peer")
+	ErrPeerDependencyCycle  = errors.New("wake peer dependency cycle")
+)
+
+const (
+	// DefaultWakeBroadcast es el destino de los paquetes mágicos si el peer no define otro
+	DefaultWakeBroadcast = "255.255.255.255:9"
+	// MaxWakePeersDelay limita la suma de las esperas al despertar todos los
+	// peers; las que la superan se acortan
+	MaxWakePeersDelay = 5 * time.Minute
+)
+
+// WakePeer es un equipo que se despierta por Wake-on-LAN después de este
+type WakePeer struct {
+	Name string
+	MAC  string
+	// Broadcast es "IP" o "IP:puerto" (vacío = DefaultWakeBroadcast)
+	Broadcast string
+	// DelaySeconds es la espera antes de enviar el paquete a este peer
+	DelaySeconds int
+	// DependsOn son los peers que deben despertarse antes que este
+	DependsOn []string
+}
+
+// Validate verifica el nombre, la MAC, la dirección de broadcast y la espera
+func (p *WakePeer) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return ErrInvalidPeerName
+	}
+
+	if _, err := p.HardwareAddr(); err != nil {
+		return err
+	}
+
+	if p.Broadcast != "" {
+		if _, err := p.BroadcastAddr(); err != nil {
+			return err
+		}
+	}
+
+	if p.DelaySeconds < 0 {
+		return ErrInvalidPeerDelay
+	}
+
+	return nil
+}
+
+// HardwareAddr retorna la MAC parseada; Wake-on-LAN solo admite MAC-48
+func (p *WakePeer) HardwareAddr() (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(p.MAC)
+	if err != nil || len(mac) != 6 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPeerMAC, p.MAC)
+	}
+	return mac, nil
+}
+
+// BroadcastAddr retorna el destino "IP:puerto", con el puerto 9 por defecto
+func (p *WakePeer) BroadcastAddr() (string, error) {
+	if p.Broadcast == "" {
+		return DefaultWakeBroadcast, nil
+	}
+
+	host, port, err := net.SplitHostPort(p.Broadcast)
+	if err != nil {
+		host, port = p.Broadcast, "9"
+	}
+	if net.ParseIP(host) == nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPeerBroadcast, p.Broadcast)
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
+
+// Delay retorna la espera antes de despertar al peer
+func (p *WakePeer) Delay() time.Duration {
+	return time.Duration(p.DelaySeconds) * time.Second
+}
+
+// OrderWakePeers retorna los peers en orden de dependencias: cada peer aparece
+// después de todos los que declara en DependsOn. Entre peers independientes se
+// respeta el orden de la configuración.
+func OrderWakePeers(peers []WakePeer) ([]WakePeer, error) {
+	index := make(map[string]int, len(peers))
+	for i, peer := range peers {
+		if _, exists := index[peer.Name]; exists {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicatePeer, peer.Name)
+		}
+		index[peer.Name] = i
+	}
+
+	for _, peer := range peers {
+		for _, dep := range peer.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("%w: %q (required by %q)", ErrUnknownPeer, dep, peer.Name)
+			}
+		}
+	}
+
+	// Recorrido en profundidad: 1 = visitando, 2 = ya ordenado
+	state := make([]int, len(peers))
+	ordered := make([]WakePeer, 0, len(peers))
+
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		switch state[i] {
+		case 2:
+			return nil
+		case 1:
+			return fmt.Errorf("%w: %s", ErrPeerDependencyCycle, strings.Join(append(path, peers[i].Name), " -> "))
+		}
+
+		state[i] = 1
+		for _, dep := range peers[i].DependsOn {
+			if err := visit(index[dep], append(path, peers[i].Name)); err != nil {
+				return err
+			}
+		}
+		state[i] = 2
+		ordered = append(ordered, peers[i])
+		return nil
+	}
+
+	for i := range peers {
+		if err := visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
\ No newline at end of file
@@ -0,0 +1,76 @@
+// internal/domain/entities/wake_peer_test.go
+package entities
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func peerNames(peers []WakePeer) string {
+	names := make([]string, len(peers))
+	for i, peer := range peers {
+		names[i] = peer.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestOrderWakePeers(t *testing.T) {
+	peers := []WakePeer{
+		{Name: "desk", DependsOn: []string{"db", "nas"}},
+		{Name: "printer"},
+		{Name: "db", DependsOn: []string{"nas"}},
+		{Name: "nas"},
+	}
+
+	ordered, err := OrderWakePeers(peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := peerNames(ordered); got != "nas,db,desk,printer" {
+		t.Errorf("order = %s, want nas,db,desk,printer", got)
+	}
+}
+
+func TestOrderWakePeersErrors(t *testing.T) {
+	tests := []struct {
+		peers []WakePeer
+		want  error
+	}{
+		{[]WakePeer{{Name: "a", DependsOn: []string{"b"}}, {Name: "b", DependsOn: []string{"a"}}}, ErrPeerDependencyCycle},
+		{[]WakePeer{{Name: "a", DependsOn: []string{"a"}}}, ErrPeerDependencyCycle},
+		{[]WakePeer{{Name: "a", DependsOn: []string{"ghost"}}}, ErrUnknownPeer},
+		{[]WakePeer{{Name: "a"}, {Name: "a"}}, ErrDuplicatePeer},
+	}
+
+	for _, tt := range tests {
+		if _, err := OrderWakePeers(tt.peers); !errors.Is(err, tt.want) {
+			t.Errorf("OrderWakePeers(%s) = %v, want %v", peerNames(tt.peers), err, tt.want)
+		}
+	}
+}
+
+func TestWakePeerValidate(t *testing.T) {
+	tests := []struct {
+		peer WakePeer
+		want error
+	}{
+		{WakePeer{Name: "desk", MAC: "00:11:22:aa:bb:cc", Broadcast: "192.168.1.255"}, nil},
+		{WakePeer{Name: "desk", MAC: "00:11:22:aa:bb:cc", Broadcast: "192.168.1.255:7"}, nil},
+		{WakePeer{Name: "", MAC: "00:11:22:aa:bb:cc"}, ErrInvalidPeerName},
+		{WakePeer{Name: "desk", MAC: "not-a-mac"}, ErrInvalidPeerMAC},
+		{WakePeer{Name: "desk", MAC: "00:11:22:aa:bb:cc", Broadcast: "lan"}, ErrInvalidPeerBroadcast},
+		{WakePeer{Name: "desk", MAC: "00:11:22:aa:bb:cc", DelaySeconds: -1}, ErrInvalidPeerDelay},
+	}
+
+	for _, tt := range tests {
+		if err := tt.peer.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("Validate(%+v) = %v, want %v", tt.peer, err, tt.want)
+		}
+	}
+
+	peer := WakePeer{Name: "desk", MAC: "00:11:22:aa:bb:cc", Broadcast: "10.0.0.255"}
+	if addr, _ := peer.BroadcastAddr(); addr != "10.0.0.255:9" {
+		t.Errorf("BroadcastAddr() = %s, want 10.0.0.255:9", addr)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,42 @@
+// internal/domain/entities/wake_test.go
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerifyWake(t *testing.T) {
+	armedFor := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local)
+	armed := &ArmedWake{WakeTime: armedFor, ArmedAt: armedFor.Add(-10 * time.Hour)}
+	rtcSource := &WakeSource{Name: "rtc0", RTC: true}
+	keyboard := &WakeSource{Name: "i8042", RTC: false}
+
+	tests := []struct {
+		name   string
+		wokeAt time.Time
+		source *WakeSource
+		want   WakeOutcome
+	}{
+		{"exact", armedFor, rtcSource, WakeOnTime},
+		{"within tolerance late", armedFor.Add(90 * time.Second), rtcSource, WakeOnTime},
+		{"within tolerance early", armedFor.Add(-90 * time.Second), nil, WakeOnTime},
+		{"woken early by keyboard", armedFor.Add(-1 * time.Hour), keyboard, WakeEarly},
+		{"late by rtc", armedFor.Add(10 * time.Minute), rtcSource, WakeLate},
+		{"late unknown source", armedFor.Add(10 * time.Minute), nil, WakeLate},
+		{"late but not rtc", armedFor.Add(10 * time.Minute), keyboard, WakeMissed},
+		{"beyond late window", armedFor.Add(2 * time.Hour), nil, WakeMissed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := VerifyWake(armed, WakeKindResume, tt.wokeAt, tt.source, DefaultWakeTolerance, DefaultLateWindow)
+			if got.Outcome != tt.want {
+				t.Errorf("outcome = %s, want %s (offset %s)", got.Outcome, tt.want, got.Offset)
+			}
+			if got.Offset != tt.wokeAt.Sub(armedFor) {
+				t.Errorf("offset = %s, want %s", got.Offset, tt.wokeAt.Sub(armedFor))
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,100 @@
+// internal/domain/entities/webhook.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"text/template"
+)
+
+var (
+	ErrInvalidWebhookURL   = errors.New("webhook URL must be an absolute http or https URL")
+	ErrUnknownWebhookEvent = errors.New("unknown webhook event")
+)
+
+// WebhookEventErrors selecciona cualquier evento de nivel error, sin importar su tipo
+const WebhookEventErrors = "error"
+
+// DefaultWebhookEvents son los eventos notificados cuando un webhook no define la lista
+var DefaultWebhookEvents = []string{
+	string(EventAlarmArmed),
+	string(EventShutdownImminent),
+	string(EventShutdownExecuted),
+	string(EventWakeDetected),
+	string(EventWakeMissed),
+	string(EventUPSShutdown),
+	string(EventUPSWakeRefused),
+	WebhookEventErrors,
+}
+
+// webhookEventNames son los valores aceptados en WebhookConfig.Events
+var webhookEventNames = map[string]bool{
+	string(EventAlarmArmed):        true,
+	string(EventAlarmCleared):      true,
+	string(EventShutdownScheduled): true,
+	string(EventShutdownCancelled): true,
+	string(EventShutdownSnoozed):   true,
+	string(EventOverrideChanged):   true,
+	string(EventProfileSwitched):   true,
+	string(EventConfigChanged):     true,
+	string(EventShutdownImminent):  true,
+	string(EventShutdownExecuted):  true,
+	string(EventResumed):           true,
+	string(EventBooted):            true,
+	string(EventWakeDetected):      true,
+	string(EventWakeMissed):        true,
+	string(EventWakeReady):         true,
+	string(EventPeerWoken):         true,
+	string(EventUPSShutdown):       true,
+	string(EventUPSWakeRefused):    true,
+	WebhookEventErrors:             true,
+}
+
+// WebhookConfig describe un destino HTTP que recibe eventos de energía
+type WebhookConfig struct {
+	URL string
+	// Events filtra los eventos enviados (vacío = DefaultWebhookEvents)
+	Events []string
+	// Template es una plantilla text/template para el cuerpo (vacío = JSON por defecto)
+	Template string
+	// Secret firma el cuerpo con HMAC-SHA256; "file:/ruta" lo lee desde un archivo
+	Secret string
+}
+
+// Validate verifica la URL, los eventos y que la plantilla compile
+func (w *WebhookConfig) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidWebhookURL, w.URL)
+	}
+
+	for _, name := range w.Events {
+		if !webhookEventNames[name] {
+			return fmt.Errorf("%w: %q", ErrUnknownWebhookEvent, name)
+		}
+	}
+
+	if w.Template != "" {
+		if _, err := template.New("webhook").Parse(w.Template); err != nil {
+			return fmt.Errorf("invalid webhook template: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Matches indica si el evento debe enviarse a este webhook
+func (w *WebhookConfig) Matches(event *PowerEvent) bool {
+	events := w.Events
+	if len(events) == 0 {
+		events = DefaultWebhookEvents
+	}
+
+	for _, name := range events {
+		if name == string(event.Type) || (name == WebhookEventErrors && event.Level == EventLevelError) {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
@@ -0,0 +1,47 @@
+// internal/domain/entities/webhook_test.go
+package entities
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWebhookMatches(t *testing.T) {
+	armed := NewPowerEvent(EventAlarmArmed, "armed", nil)
+	booted := NewPowerEvent(EventBooted, "booted", nil)
+	failure := NewPowerEvent(EventError, "failed", nil)
+	failure.Level = EventLevelError
+
+	defaults := &WebhookConfig{URL: "https://example.com/hook"}
+	if !defaults.Matches(armed) || !defaults.Matches(failure) || defaults.Matches(booted) {
+		t.Error("default event list should include armed and errors but not booted")
+	}
+
+	onlyErrors := &WebhookConfig{URL: "https://example.com/hook", Events: []string{WebhookEventErrors}}
+	if onlyErrors.Matches(armed) || !onlyErrors.Matches(failure) {
+		t.Error("\"error\" should select only error-level events")
+	}
+}
+
+func TestWebhookValidate(t *testing.T) {
+	tests := []struct {
+		hook WebhookConfig
+		want error
+	}{
+		{WebhookConfig{URL: "https://example.com/hook", Events: []string{"armed", "wake_missed"}}, nil},
+		{WebhookConfig{URL: "ftp://example.com"}, ErrInvalidWebhookURL},
+		{WebhookConfig{URL: "/relative"}, ErrInvalidWebhookURL},
+		{WebhookConfig{URL: "http://example.com", Events: []string{"exploded"}}, ErrUnknownWebhookEvent},
+	}
+
+	for _, tt := range tests {
+		if err := tt.hook.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("Validate(%+v) = %v, want %v", tt.hook, err, tt.want)
+		}
+	}
+
+	broken := WebhookConfig{URL: "http://example.com", Template: "{{.Message"}
+	if err := broken.Validate(); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,8 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type ConfigBackupRepository interface {
+	SaveBackup(config *entities.Config) error
+	LoadBackup() (*entities.Config, error)
+}
\ No newline at end of file
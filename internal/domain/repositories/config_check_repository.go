@@ -0,0 +1,7 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type ConfigCheckRepository interface {
+	Inspect(path string) (*entities.Config, []entities.ConfigProblem, error)
+}
\ No newline at end of file
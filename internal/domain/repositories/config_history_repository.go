@@ -0,0 +1,8 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type ConfigHistoryRepository interface {
+	ListVersions() ([]*entities.ConfigVersion, error)
+	LoadVersion(id int) (*entities.ConfigVersion, error)
+}
\ No newline at end of file
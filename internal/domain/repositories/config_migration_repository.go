@@ -0,0 +1,5 @@
+package repositories
+
+type ConfigMigrationRepository interface {
+	Migrate() (int, int, error)
+}
\ No newline at end of file
@@ -0,0 +1,7 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type ConfigSourceRepository interface {
+	LoadEffective() (*entities.EffectiveConfig, error)
+}
\ No newline at end of file
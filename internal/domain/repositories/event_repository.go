@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+type EventRepository interface {
+	Append(event *entities.PowerEvent) error
+	List(from, to time.Time) ([]*entities.PowerEvent, error)
+	Compact(maxAge time.Duration, maxEvents int) (int, error)
+}
\ No newline at end of file
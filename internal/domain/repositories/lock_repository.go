@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+type LockRepository interface {
+	Acquire(holder *entities.LockHolder, timeout time.Duration) (release func(), err error)
+}
\ No newline at end of file
@@ -0,0 +1,8 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type NotifierRepository interface {
+	Notify(event *entities.PowerEvent) error
+	Flush() error
+}
\ No newline at end of file
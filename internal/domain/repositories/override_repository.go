@@ -0,0 +1,8 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type OverrideRepository interface {
+	LoadOverrides() (*entities.ScheduleOverrides, error)
+	SaveOverrides(overrides *entities.ScheduleOverrides) error
+}
\ No newline at end of file
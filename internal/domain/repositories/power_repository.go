@@ -0,0 +1,9 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type PowerRepository interface {
+	Execute(action entities.PowerAction) error
+	IsAvailable() bool
+	Supports(action entities.PowerAction) error
+}
\ No newline at end of file
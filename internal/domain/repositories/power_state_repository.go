@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+type PowerStateRepository interface {
+	BootID() (string, error)
+	BootTime() (time.Time, error)
+	SuspendCount() (int, error)
+	LastWakeSource() (*entities.WakeSource, error)
+}
\ No newline at end of file
@@ -1,12 +1,18 @@
 package repositories
 
-import "time"
+import (
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
 
 type SchedulerRepository interface {
 	ScheduleShutdown(t time.Time) error
 	CancelShutdown() error
 	ListScheduledJobs() ([]*ShutdownJob, error)
 	IsAvailable() bool
+	Backend() string
+	ShutdownAction() entities.PowerAction
 }
 
 type ShutdownJob struct {
@@ -0,0 +1,8 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type SnoozeRepository interface {
+	LoadSnooze() (*entities.SnoozeState, error)
+	SaveSnooze(state *entities.SnoozeState) error
+}
\ No newline at end of file
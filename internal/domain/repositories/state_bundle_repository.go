@@ -0,0 +1,10 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type StateBundleRepository interface {
+	ReadBundle(path string) (*entities.StateBundle, []entities.ConfigProblem, error)
+	WriteBundle(path string, bundle *entities.StateBundle) error
+	FindSeed() (string, bool)
+	RetireSeed(imported bool) (string, error)
+}
\ No newline at end of file
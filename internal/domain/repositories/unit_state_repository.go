@@ -0,0 +1,5 @@
+package repositories
+
+type UnitStateRepository interface {
+	IsActive(unit string) (bool, error)
+}
\ No newline at end of file
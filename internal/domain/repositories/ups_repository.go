@@ -0,0 +1,7 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type UPSRepository interface {
+	Status(ups *entities.UPSConfig) (*entities.UPSStatus, error)
+}
\ No newline at end of file
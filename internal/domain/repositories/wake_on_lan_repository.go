@@ -0,0 +1,7 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type WakeOnLANRepository interface {
+	SendMagicPacket(peer *entities.WakePeer) error
+}
\ No newline at end of file
@@ -0,0 +1,9 @@
+package repositories
+
+import "rtc-scheduler/internal/domain/entities"
+
+type WakeStateRepository interface {
+	SaveArmedWake(wake *entities.ArmedWake) error
+	LoadArmedWake() (*entities.ArmedWake, error)
+	ClearArmedWake() error
+}
\ No newline at end of file
@@ -0,0 +1,112 @@
+// internal/infrastructure/config/atomic.go
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// writeFileAtomic escribe data en path sin dejar nunca un archivo a medias:
+// escribe un temporal en el mismo directorio, lo sincroniza y lo renombra.
+// Ante un corte de energía queda la versión anterior o la nueva completa.
+//
+// Dentro de la unidad systemd (ProtectSystem=strict) el directorio es de
+// solo lectura y el archivo está montado aparte (ReadWritePaths), así que ni
+// el temporal ni el rename son posibles: en ese caso se escribe en el lugar.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	err := replaceFile(path, data, perm, -1, -1)
+	if cannotReplace(err) {
+		return writeInPlace(path, data)
+	}
+	return err
+}
+
+// rewriteFileAtomic es writeFileAtomic conservando el modo y el dueño de path
+// si ya existe (un chmod 600 del administrador no se pierde); perm solo se
+// usa para un archivo nuevo
+func rewriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return writeFileAtomic(path, data, perm)
+	}
+
+	uid, gid := -1, -1
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		uid, gid = int(stat.Uid), int(stat.Gid)
+	}
+	err = replaceFile(path, data, info.Mode().Perm(), uid, gid)
+	if cannotReplace(err) {
+		return writeInPlace(path, data)
+	}
+	return err
+}
+
+// cannotReplace indica que el directorio no admite el temporal o el rename
+func cannotReplace(err error) bool {
+	return errors.Is(err, syscall.EROFS) || errors.Is(err, syscall.EBUSY) || errors.Is(err, syscall.EXDEV)
+}
+
+// replaceFile escribe un temporal junto a path con el modo y el dueño dados
+// (-1 = el del proceso) y lo renombra sobre él
+func replaceFile(path string, data []byte, perm os.FileMode, uid, gid int) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	// Si algo falla antes del rename, no dejar el temporal
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if uid != -1 || gid != -1 {
+		if err := tmp.Chown(uid, gid); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// Sincronizar el directorio para que el rename sobreviva a un corte
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// writeInPlace reemplaza el contenido de un archivo existente. No es atómico,
+// pero conserva el inodo, el modo y el dueño.
+func writeInPlace(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
\ No newline at end of file
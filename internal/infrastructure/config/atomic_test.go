@@ -0,0 +1,103 @@
+// internal/infrastructure/config/atomic_test.go
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rtc-scheduler.json")
+
+	if err := os.WriteFile(path, []byte(`{"old": true}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(path, []byte(`{"new": true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != `{"new": true}` {
+		t.Errorf("unexpected content %q (%v)", data, err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0644 {
+		t.Errorf("unexpected mode %v", info.Mode())
+	}
+
+	// No quedan temporales en el directorio
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected only the config file, found %d entries", len(entries))
+	}
+}
+
+func TestWriteFileAtomicMissingDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "rtc-scheduler.json")
+	if err := writeFileAtomic(path, []byte("{}"), 0644); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+func TestWriteInPlace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.json")
+	if err := os.WriteFile(path, []byte(`{"old": "a longer value"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := os.Stat(path)
+
+	if err := writeInPlace(path, []byte(`{"new": true}`)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != `{"new": true}` {
+		t.Errorf("unexpected content %q (%v)", data, err)
+	}
+	// El mismo archivo: sirve para un bind mount de ReadWritePaths
+	if after, _ := os.Stat(path); !os.SameFile(before, after) || after.Mode().Perm() != 0600 {
+		t.Errorf("file replaced or mode changed: %v", after.Mode())
+	}
+
+	// Sin el archivo no hay nada que reescribir
+	if err := writeInPlace(filepath.Join(t.TempDir(), "missing.json"), []byte("{}")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+func TestRewriteFileAtomicKeepsModeAndOwner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.json")
+	if err := os.WriteFile(path, []byte(`{"old": true}`), 0640); err != nil {
+		t.Fatal(err)
+	}
+	// Un dueño distinto solo se puede probar como root
+	uid, gid := os.Getuid(), os.Getgid()
+	if uid == 0 {
+		uid, gid = 1234, 5678
+		if err := os.Chown(path, uid, gid); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := rewriteFileAtomic(path, []byte(`{"new": true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("mode = %v, want the existing 0640", info.Mode())
+	}
+	if stat := info.Sys().(*syscall.Stat_t); int(stat.Uid) != uid || int(stat.Gid) != gid {
+		t.Errorf("owner = %d:%d, want %d:%d", stat.Uid, stat.Gid, uid, gid)
+	}
+
+	// Un archivo nuevo toma perm
+	fresh := filepath.Join(filepath.Dir(path), "fresh.json")
+	if err := rewriteFileAtomic(fresh, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(fresh); info.Mode().Perm() != 0644 {
+		t.Errorf("new file mode = %v, want 0644", info.Mode())
+	}
+}
\ No newline at end of file
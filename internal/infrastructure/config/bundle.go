@@ -0,0 +1,304 @@
+// internal/infrastructure/config/bundle.go
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"time"
+
+	appdto "rtc-scheduler/internal/application/dto"
+	"rtc-scheduler/internal/domain/entities"
+)
+
+// bundleFormat identifica los archivos generados por -export
+const bundleFormat = "rtc-scheduler-export"
+
+// Sufijos con los que se renombra la semilla de primer arranque
+const (
+	seedImportedSuffix = ".imported"
+	seedRejectedSuffix = ".rejected"
+)
+
+// bundleDTO es el archivo de -export: la configuración en el mismo formato que
+// /etc/rtc-scheduler.json y las excepciones al horario
+type bundleDTO struct {
+	Format     string              `json:"format"`
+	ExportedAt string              `json:"exported_at,omitempty"`
+	Config     json.RawMessage     `json:"config"`
+	Overrides  *bundleOverridesDTO `json:"overrides,omitempty"`
+}
+
+type bundleOverridesDTO struct {
+	SkipNext *bundleSkipDTO       `json:"skip_next,omitempty"`
+	Vacation *bundleVacationDTO   `json:"vacation,omitempty"`
+	OneOffs  []appdto.ScheduleDTO `json:"one_off,omitempty"`
+}
+
+type bundleSkipDTO struct {
+	WakeAt       string `json:"wake_at"`
+	SkipShutdown bool   `json:"skip_shutdown,omitempty"`
+	ShutdownAt   string `json:"shutdown_at,omitempty"`
+	By           string `json:"by,omitempty"`
+	CreatedAt    string `json:"created_at,omitempty"`
+}
+
+type bundleVacationDTO struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	By        string `json:"by,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// SetSeed habilita la semilla de primer arranque en path. Tras importarla se
+// renombra a <path>.imported, o se elimina con remove (puede contener secretos
+// en una partición que cualquiera lee). Una semilla inválida siempre se
+// renombra a <path>.rejected para poder revisarla.
+func (r *JSONConfigRepository) SetSeed(path string, remove bool) {
+	r.seedPath = path
+	r.seedRemove = remove
+}
+
+// FindSeed retorna la ruta de la semilla si está habilitada y presente
+func (r *JSONConfigRepository) FindSeed() (string, bool) {
+	if r.seedPath == "" {
+		return "", false
+	}
+	if _, err := os.Stat(r.seedPath); err != nil {
+		return "", false
+	}
+	return r.seedPath, true
+}
+
+// RetireSeed quita la semilla de su lugar para no volver a importarla en el
+// próximo arranque. Retorna la nueva ruta (vacía si se eliminó).
+func (r *JSONConfigRepository) RetireSeed(imported bool) (string, error) {
+	if imported && r.seedRemove {
+		if err := os.Remove(r.seedPath); err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		return "", nil
+	}
+
+	target := r.seedPath + seedRejectedSuffix
+	if imported {
+		target = r.seedPath + seedImportedSuffix
+	}
+	if err := os.Rename(r.seedPath, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// WriteBundle escribe el estado en path ("" o "-" = salida estándar). El
+// archivo solo lo lee root: incluye los secretos de webhooks, MQTT y NUT.
+func (r *JSONConfigRepository) WriteBundle(path string, bundle *entities.StateBundle) error {
+	config, err := encode(bundle.Config)
+	if err != nil {
+		return err
+	}
+
+	dto := &bundleDTO{
+		Format:     bundleFormat,
+		ExportedAt: bundle.ExportedAt.Format(time.RFC3339),
+		Config:     config,
+	}
+	if !bundle.Overrides.IsEmpty() {
+		dto.Overrides = encodeOverrides(bundle.Overrides)
+	}
+
+	data, err := json.MarshalIndent(dto, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if path == "" || path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return writeFileAtomic(path, data, 0600)
+}
+
+// ReadBundle lee un archivo de -export o una configuración suelta (como la que
+// se copia a la partición de arranque) desde path ("-" = entrada estándar).
+// Los problemas de formato se reportan con su ubicación, igual que Inspect; el
+// bundle retornado es nil si la configuración no se pudo interpretar.
+func (r *JSONConfigRepository) ReadBundle(path string) (*entities.StateBundle, []entities.ConfigProblem, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var head struct {
+		Format *string `json:"format"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil || head.Format == nil {
+		config, problems, err := r.inspect(data, false)
+		if config == nil || err != nil {
+			return nil, problems, err
+		}
+		return &entities.StateBundle{Config: config, Overrides: &entities.ScheduleOverrides{}}, problems, nil
+	}
+
+	problem := func(path, format string, args ...interface{}) entities.ConfigProblem {
+		return entities.ConfigProblem{Path: path, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if *head.Format != bundleFormat {
+		return nil, []entities.ConfigProblem{problem("format", "unknown format %q, expected %q", *head.Format, bundleFormat)}, nil
+	}
+
+	var doc map[string]interface{}
+	json.Unmarshal(data, &doc)
+	var problems []entities.ConfigProblem
+	for _, key := range unknownFields(doc, reflect.TypeOf(bundleDTO{}), "") {
+		problems = append(problems, problem(key, "unknown key"))
+	}
+
+	var dto bundleDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, append(problems, problem("", "%v", err)), nil
+	}
+
+	bundle := &entities.StateBundle{}
+	if dto.ExportedAt != "" {
+		if bundle.ExportedAt, err = time.Parse(time.RFC3339, dto.ExportedAt); err != nil {
+			problems = append(problems, problem("exported_at", "invalid timestamp %q, use RFC 3339", dto.ExportedAt))
+		}
+	}
+
+	overrides, overrideProblems := decodeOverrides(dto.Overrides)
+	problems = append(problems, overrideProblems...)
+	bundle.Overrides = overrides
+
+	if len(dto.Config) == 0 || string(dto.Config) == "null" {
+		return nil, append(problems, problem("config", "missing")), nil
+	}
+	config, configProblems, err := r.inspect(dto.Config, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	problems = append(problems, configProblems...)
+	if config == nil {
+		return nil, problems, nil
+	}
+	bundle.Config = config
+	return bundle, problems, nil
+}
+
+// encodeOverrides convierte las excepciones al formato de -export
+func encodeOverrides(overrides *entities.ScheduleOverrides) *bundleOverridesDTO {
+	dto := &bundleOverridesDTO{}
+	if skip := overrides.Skip; skip != nil {
+		dto.SkipNext = &bundleSkipDTO{
+			WakeAt:       skip.WakeAt.Format(time.RFC3339),
+			SkipShutdown: skip.SkipShutdown,
+			By:           skip.By,
+			CreatedAt:    skip.CreatedAt.Format(time.RFC3339),
+		}
+		if skip.SkipShutdown {
+			dto.SkipNext.ShutdownAt = skip.ShutdownAt.Format(time.RFC3339)
+		}
+	}
+	if vacation := overrides.Vacation; vacation != nil {
+		dto.Vacation = &bundleVacationDTO{
+			From:      vacation.From.Format("2006-01-02"),
+			To:        vacation.To.Format("2006-01-02"),
+			By:        vacation.By,
+			CreatedAt: vacation.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	dto.OneOffs = appdto.FromOneOffs(overrides.OneOffs)
+	return dto
+}
+
+// decodeOverrides interpreta las excepciones de un archivo de -export; las
+// que tienen fechas inválidas se descartan y se reportan
+func decodeOverrides(dto *bundleOverridesDTO) (*entities.ScheduleOverrides, []entities.ConfigProblem) {
+	overrides := &entities.ScheduleOverrides{}
+	if dto == nil {
+		return overrides, nil
+	}
+
+	var problems []entities.ConfigProblem
+	timestamp := func(path, value string, optional bool) time.Time {
+		if value == "" && optional {
+			return time.Time{}
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			problems = append(problems, entities.ConfigProblem{
+				Path:    path,
+				Message: fmt.Sprintf("invalid timestamp %q, use RFC 3339", value),
+			})
+		}
+		return t.Local()
+	}
+
+	if skip := dto.SkipNext; skip != nil {
+		before := len(problems)
+		overrides.Skip = &entities.SkipNext{
+			WakeAt:       timestamp("overrides.skip_next.wake_at", skip.WakeAt, false),
+			SkipShutdown: skip.SkipShutdown,
+			ShutdownAt:   timestamp("overrides.skip_next.shutdown_at", skip.ShutdownAt, !skip.SkipShutdown),
+			By:           skip.By,
+			CreatedAt:    timestamp("overrides.skip_next.created_at", skip.CreatedAt, true),
+		}
+		if len(problems) > before {
+			overrides.Skip = nil
+		}
+	}
+
+	if vacation := dto.Vacation; vacation != nil {
+		// Se valida igual que -vacation, salvo que ya haya vencido: Prune la descarta
+		from, err := time.ParseInLocation("2006-01-02", vacation.From, time.Local)
+		if err != nil {
+			problems = append(problems, entities.ConfigProblem{Path: "overrides.vacation.from", Message: fmt.Sprintf("%v: %q", entities.ErrInvalidVacation, vacation.From)})
+		}
+		to, err := time.ParseInLocation("2006-01-02", vacation.To, time.Local)
+		if err != nil {
+			problems = append(problems, entities.ConfigProblem{Path: "overrides.vacation.to", Message: fmt.Sprintf("%v: %q", entities.ErrInvalidVacation, vacation.To)})
+		}
+		if !from.IsZero() && !to.IsZero() {
+			switch {
+			case to.Before(from):
+				problems = append(problems, entities.ConfigProblem{Path: "overrides.vacation", Message: fmt.Sprintf("%v: %s is before %s", entities.ErrInvalidVacation, vacation.To, vacation.From)})
+			case to.Sub(from) >= entities.MaxVacationDays*24*time.Hour:
+				problems = append(problems, entities.ConfigProblem{Path: "overrides.vacation", Message: entities.ErrVacationTooLong.Error()})
+			default:
+				overrides.Vacation = &entities.Vacation{
+					From:      from,
+					To:        to,
+					By:        vacation.By,
+					CreatedAt: timestamp("overrides.vacation.created_at", vacation.CreatedAt, true),
+				}
+			}
+		}
+	}
+
+	if len(dto.OneOffs) > 0 {
+		oneOffs, err := appdto.ToOneOffs(dto.OneOffs)
+		if err != nil {
+			problems = append(problems, entities.ConfigProblem{Path: "overrides.one_off", Message: err.Error()})
+		}
+		for _, oneOff := range oneOffs {
+			if !oneOff.ShutdownAt.After(oneOff.WakeAt) {
+				problems = append(problems, entities.ConfigProblem{Path: "overrides.one_off", Message: fmt.Sprintf("%v: one-off %s ends before it starts", entities.ErrInvalidShutdownTime, oneOff.ID)})
+				oneOffs = nil
+				break
+			}
+		}
+		overrides.OneOffs = oneOffs
+	}
+
+	return overrides, problems
+}
\ No newline at end of file
@@ -0,0 +1,173 @@
+// internal/infrastructure/config/bundle_test.go
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	repo := NewJSONConfigRepository(filepath.Join(dir, "rtc-scheduler.json"))
+
+	config, _ := entities.NewConfig("07:00", "23:00", true)
+	config.Profiles = []entities.Profile{{Name: "work", WakeTime: "07:00", ShutdownTime: "23:00"}}
+	config.ActiveProfile = "work"
+	config.Webhooks = []entities.WebhookConfig{{URL: "https://hooks.example.com/a", Secret: "s3cret"}}
+	vacation, err := entities.NewVacation("2099-07-01", "2099-07-14", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	skip := &entities.SkipNext{WakeAt: time.Date(2099, 6, 1, 7, 0, 0, 0, time.Local), By: "ana"}
+	oneOff := &entities.OneOff{
+		ID:         "1",
+		WakeAt:     time.Date(2099, 12, 24, 6, 0, 0, 0, time.Local),
+		ShutdownAt: time.Date(2099, 12, 24, 23, 0, 0, 0, time.Local),
+		By:         "ana",
+	}
+
+	path := filepath.Join(dir, "state.json")
+	exported := &entities.StateBundle{
+		Config:     config,
+		Overrides:  &entities.ScheduleOverrides{Skip: skip, Vacation: vacation, OneOffs: []*entities.OneOff{oneOff}},
+		ExportedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if err := repo.WriteBundle(path, exported); err != nil {
+		t.Fatal(err)
+	}
+
+	// Incluye secretos: solo root puede leerlo
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("export mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	bundle, problems, err := repo.ReadBundle(path)
+	if err != nil || len(problems) != 0 {
+		t.Fatalf("ReadBundle: %v %v", err, problems)
+	}
+	if bundle.Config.ActiveProfile != "work" || len(bundle.Config.Profiles) != 1 || bundle.Config.Webhooks[0].Secret != "s3cret" {
+		t.Errorf("config not preserved: %+v", bundle.Config)
+	}
+	if !bundle.ExportedAt.Equal(exported.ExportedAt) {
+		t.Errorf("exported_at = %v", bundle.ExportedAt)
+	}
+	if bundle.Overrides.Vacation == nil || bundle.Overrides.Vacation.String() != vacation.String() {
+		t.Errorf("vacation = %v, want %v", bundle.Overrides.Vacation, vacation)
+	}
+	if bundle.Overrides.Skip == nil || !bundle.Overrides.Skip.WakeAt.Equal(skip.WakeAt) || bundle.Overrides.Skip.By != "ana" {
+		t.Errorf("skip = %+v", bundle.Overrides.Skip)
+	}
+	if len(bundle.Overrides.OneOffs) != 1 || bundle.Overrides.OneOffs[0].String() != oneOff.String() || bundle.Overrides.OneOffs[0].By != "ana" {
+		t.Errorf("one-offs = %v, want %v", bundle.Overrides.OneOffs, oneOff)
+	}
+}
+
+func TestReadBundleAcceptsPlainConfig(t *testing.T) {
+	repo := NewJSONConfigRepository(filepath.Join(t.TempDir(), "installed.json"))
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.json")
+	if err := os.WriteFile(path, []byte(validCandidate), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, problems, err := repo.ReadBundle(path)
+	if err != nil || len(problems) != 0 {
+		t.Fatalf("ReadBundle: %v %v", err, problems)
+	}
+	if bundle.Config.WakeTime != "07:00" || !bundle.Overrides.IsEmpty() || !bundle.ExportedAt.IsZero() {
+		t.Errorf("bundle = %+v", bundle)
+	}
+}
+
+func TestReadBundleReportsProblemPaths(t *testing.T) {
+	repo := NewJSONConfigRepository(filepath.Join(t.TempDir(), "installed.json"))
+	path := filepath.Join(t.TempDir(), "state.json")
+	content := `{
+  "format": "rtc-scheduler-export",
+  "config": {"wake_time": "07:00", "shutdown_time": "23:00", "created_at": "2025-01-01T00:00:00Z", "updated_at": "2025-01-01T00:00:00Z", "mqtt": {"brokr": "x"}},
+  "overrides": {"vacation": {"from": "2099-07-14", "to": "2099-07-01"}, "skip_next": {"wake_at": "tomorrow"},
+    "one_off": [{"id": "1", "time": "2099-12-24T06:00:00Z", "action": "wake", "enabled": true}]},
+  "comment": "lab"
+}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, problems, err := repo.ReadBundle(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(problemPaths(problems), " ")
+	for _, want := range []string{"comment", "overrides.vacation", "overrides.skip_next.wake_at", "overrides.one_off", "mqtt.brokr"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("problems %q missing %s", got, want)
+		}
+	}
+}
+
+func TestReadBundleRejectsUnknownFormat(t *testing.T) {
+	repo := NewJSONConfigRepository(filepath.Join(t.TempDir(), "installed.json"))
+	path := filepath.Join(t.TempDir(), "state.json")
+	os.WriteFile(path, []byte(`{"format": "something-else", "config": {}}`), 0644)
+
+	bundle, problems, err := repo.ReadBundle(path)
+	if err != nil || bundle != nil || len(problems) != 1 || problems[0].Path != "format" {
+		t.Errorf("ReadBundle = %v, %v, %v; want one format problem", bundle, problems, err)
+	}
+}
+
+func TestRetireSeed(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		remove   bool
+		imported bool
+		want     string // sufijo del archivo resultante, vacío = eliminado
+	}{
+		{"imported", false, true, seedImportedSuffix},
+		{"imported and removed", true, true, ""},
+		{"rejected", false, false, seedRejectedSuffix},
+		{"rejected is kept even with remove", true, false, seedRejectedSuffix},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			seed := filepath.Join(t.TempDir(), "rtc-scheduler.json")
+			os.WriteFile(seed, []byte(validCandidate), 0644)
+
+			repo := NewJSONConfigRepository(filepath.Join(t.TempDir(), "installed.json"))
+			repo.SetSeed(seed, tc.remove)
+			if path, ok := repo.FindSeed(); !ok || path != seed {
+				t.Fatalf("FindSeed = %q, %v", path, ok)
+			}
+
+			retired, err := repo.RetireSeed(tc.imported)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := repo.FindSeed(); ok {
+				t.Error("seed still present after RetireSeed")
+			}
+			want := ""
+			if tc.want != "" {
+				want = seed + tc.want
+			}
+			if retired != want {
+				t.Errorf("retired to %q, want %q", retired, want)
+			}
+			if want != "" {
+				if _, err := os.Stat(want); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
+
+func TestFindSeedDisabled(t *testing.T) {
+	repo := NewJSONConfigRepository(filepath.Join(t.TempDir(), "installed.json"))
+	if _, ok := repo.FindSeed(); ok {
+		t.Error("FindSeed found a seed with no seed path configured")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,176 @@
+// internal/infrastructure/config/check.go
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+// Inspect lee una configuración sin aplicarla y reporta los problemas de
+// formato con su ubicación. Con path vacío revisa el archivo instalado con sus
+// fragmentos; con una ruta revisa solo ese archivo. El Config retornado es nil
+// si el archivo no se pudo interpretar.
+func (r *JSONConfigRepository) Inspect(path string) (*entities.Config, []entities.ConfigProblem, error) {
+	withDropIns := path == ""
+	if withDropIns {
+		path = r.filePath
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrConfigNotFound, path)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.inspect(data, withDropIns)
+}
+
+// inspect es Inspect sobre un documento ya leído
+func (r *JSONConfigRepository) inspect(data []byte, withDropIns bool) (*entities.Config, []entities.ConfigProblem, error) {
+	problem := func(path, format string, args ...interface{}) []entities.ConfigProblem {
+		return []entities.ConfigProblem{{Path: path, Message: fmt.Sprintf(format, args...)}}
+	}
+
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			line, column := position(data, syntax.Offset)
+			return nil, problem("", "line %d, column %d: %v", line, column, err), nil
+		}
+		return nil, problem("", "%v", err), nil
+	}
+	if _, ok := raw.(map[string]interface{}); !ok {
+		return nil, problem("", "configuration must be a JSON object"), nil
+	}
+
+	migrated, _, err := migrate(data, time.Now())
+	if err != nil {
+		return nil, problem("schema_version", "%v", err), nil
+	}
+
+	if withDropIns {
+		fragments, err := r.fragments()
+		if err != nil {
+			return nil, problem("", "%v", err), nil
+		}
+		l, err := r.combine(migrated, fragments)
+		if err != nil {
+			return nil, problem("", "%v", err), nil
+		}
+		if migrated, err = json.Marshal(l.merged); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var doc map[string]interface{}
+	json.Unmarshal(migrated, &doc)
+
+	var problems []entities.ConfigProblem
+	for _, key := range unknownFields(doc, reflect.TypeOf(configDTO{}), "") {
+		problems = append(problems, problem(key, "unknown key")...)
+	}
+
+	var dto configDTO
+	if err := json.Unmarshal(migrated, &dto); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, append(problems, problem(typeErr.Field, "expected %s, got %s", typeErr.Type, typeErr.Value)...), nil
+		}
+		return nil, append(problems, problem("", "%v", err)...), nil
+	}
+
+	// Los valores ilegibles se reportan y se reemplazan por uno neutro para que
+	// Config.Check pueda revisar el resto del archivo
+	for field, value := range map[string]string{"created_at": dto.CreatedAt, "updated_at": dto.UpdatedAt} {
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			problems = append(problems, problem(field, "invalid timestamp %q, use RFC 3339", value)...)
+			doc[field] = time.Time{}.Format(time.RFC3339)
+		}
+	}
+	var switches []interface{}
+	for i, change := range dto.ProfileSwitches {
+		if _, err := time.Parse("2006-01-02", change.Date); err != nil {
+			problems = append(problems, problem(fmt.Sprintf("profile_switches[%d].date", i), "%v: %q", entities.ErrInvalidProfileSwitch, change.Date)...)
+			continue
+		}
+		switches = append(switches, doc["profile_switches"].([]interface{})[i])
+	}
+	if len(switches) != len(dto.ProfileSwitches) {
+		doc["profile_switches"] = switches
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Path < problems[j].Path })
+
+	if migrated, err = json.Marshal(doc); err != nil {
+		return nil, nil, err
+	}
+	config, err := decodeDTO(migrated)
+	if err != nil {
+		if len(problems) == 0 {
+			problems = problem("", "%v", err)
+		}
+		return nil, problems, nil
+	}
+	return config, problems, nil
+}
+
+// unknownFields retorna, ordenadas, las claves de doc que no existen en el DTO
+func unknownFields(doc interface{}, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var unknown []string
+	switch value := doc.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+		fields := make(map[string]reflect.Type, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			fields[name] = t.Field(i).Type
+		}
+		for key, nested := range value {
+			fieldType, ok := fields[key]
+			if !ok {
+				unknown = append(unknown, joinPath(path, key))
+				continue
+			}
+			unknown = append(unknown, unknownFields(nested, fieldType, joinPath(path, key))...)
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice {
+			return nil
+		}
+		for i, item := range value {
+			unknown = append(unknown, unknownFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// position convierte el offset de un json.SyntaxError en la línea y columna
+// (desde 1) del carácter inválido, que es el último leído
+func position(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset > 0 {
+		offset--
+	}
+	before := string(data[:offset])
+	line := strings.Count(before, "\n") + 1
+	column := len(before) - strings.LastIndex(before, "\n")
+	return line, column
+}
\ No newline at end of file
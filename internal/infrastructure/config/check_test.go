@@ -0,0 +1,161 @@
+// internal/infrastructure/config/check_test.go
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+// inspectFile escribe content en un archivo temporal y lo revisa
+func inspectFile(t *testing.T, content string) (*entities.Config, []entities.ConfigProblem) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "candidate.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewJSONConfigRepository(filepath.Join(t.TempDir(), "installed.json"))
+	config, problems, err := repo.Inspect(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config, problems
+}
+
+// problemPaths retorna las rutas reportadas, en orden
+func problemPaths(problems []entities.ConfigProblem) []string {
+	paths := make([]string, len(problems))
+	for i, problem := range problems {
+		paths[i] = problem.Path
+	}
+	return paths
+}
+
+const validCandidate = `{
+  "schema_version": 1,
+  "wake_time": "07:00",
+  "shutdown_time": "23:00",
+  "enabled": true,
+  "created_at": "2025-01-01T00:00:00Z",
+  "updated_at": "2025-01-01T00:00:00Z"
+}`
+
+func TestInspectValidFile(t *testing.T) {
+	config, problems := inspectFile(t, validCandidate)
+
+	if len(problems) != 0 {
+		t.Errorf("problems = %v, want none", problems)
+	}
+	if config == nil || config.WakeTime != "07:00" {
+		t.Errorf("config = %+v, want the decoded file", config)
+	}
+}
+
+func TestInspectSolarSchedule(t *testing.T) {
+	content := strings.Replace(validCandidate, `"wake_time": "07:00",
+  "shutdown_time": "23:00",`, `"wake_time": "sunset-30m",
+  "shutdown_time": "sunrise",
+  "location": {"latitude": 51.5074, "longitude": -0.1278},`, 1)
+	config, problems := inspectFile(t, content)
+
+	if len(problems) != 0 {
+		t.Errorf("problems = %v, want none", problems)
+	}
+	if config == nil || config.Location == nil || config.Location.Latitude != 51.5074 {
+		t.Errorf("config = %+v, want the location decoded", config)
+	}
+
+	_, problems = inspectFile(t, strings.Replace(content, `"latitude"`, `"lat"`, 1))
+	if got := strings.Join(problemPaths(problems), " "); !strings.Contains(got, "location.lat") {
+		t.Errorf("problems %q missing location.lat", got)
+	}
+}
+
+func TestInspectWakeLead(t *testing.T) {
+	content := strings.Replace(validCandidate, `"enabled": true,`, `"enabled": true,
+  "wake_lead_seconds": 90,
+  "learn_wake_lead": true,
+  "ready_unit": "network-online.target",`, 1)
+	config, problems := inspectFile(t, content)
+
+	if len(problems) != 0 {
+		t.Errorf("problems = %v, want none", problems)
+	}
+	if config == nil || config.WakeLeadSeconds != 90 || !config.LearnWakeLead || config.ReadyUnit != "network-online.target" {
+		t.Errorf("config = %+v, want the wake lead decoded", config)
+	}
+
+	_, problems = inspectFile(t, strings.Replace(content, `90`, `"90s"`, 1))
+	if got := strings.Join(problemPaths(problems), " "); got != "wake_lead_seconds" {
+		t.Errorf("problems = %q, want wake_lead_seconds", got)
+	}
+}
+
+func TestInspectReportsSyntaxErrorPosition(t *testing.T) {
+	config, problems := inspectFile(t, "{\n  \"wake_time\": \"07:00\",\n  \"enabled\": tru\n}")
+
+	if config != nil {
+		t.Error("config should be nil for unparseable JSON")
+	}
+	if len(problems) != 1 || !strings.HasPrefix(problems[0].Message, "line 3, column") {
+		t.Errorf("problems = %v, want one at line 3", problems)
+	}
+}
+
+func TestInspectReportsUnknownNestedKeys(t *testing.T) {
+	_, problems := inspectFile(t, `{
+  "wake_time": "07:00",
+  "shutdown_time": "23:00",
+  "created_at": "2025-01-01T00:00:00Z",
+  "updated_at": "2025-01-01T00:00:00Z",
+  "mqtt": {"brokr": "tcp://x:1883"},
+  "webhooks": [{"url": "https://a.example.com"}, {"ur": "https://b.example.com"}],
+  "wakeup": "07:00"
+}`)
+
+	got := strings.Join(problemPaths(problems), " ")
+	for _, want := range []string{"mqtt.brokr", "wakeup", "webhooks[1].ur"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("problems %q missing %s", got, want)
+		}
+	}
+}
+
+func TestInspectReportsTypeErrors(t *testing.T) {
+	config, problems := inspectFile(t, `{"wake_time": "07:00", "max_snooze_minutes": "ten"}`)
+
+	if config != nil {
+		t.Error("config should be nil when a field has the wrong type")
+	}
+	if len(problems) != 1 || problems[0].Path != "max_snooze_minutes" {
+		t.Errorf("problems = %v, want max_snooze_minutes", problems)
+	}
+}
+
+func TestInspectKeepsCheckingAfterBadValues(t *testing.T) {
+	content := strings.Replace(validCandidate, `"updated_at": "2025-01-01T00:00:00Z"`,
+		`"updated_at": "yesterday", "profile_switches": [{"date": "2025-13-01", "profile": "x"}]`, 1)
+	config, problems := inspectFile(t, content)
+
+	want := []string{"profile_switches[0].date", "updated_at"}
+	if got := problemPaths(problems); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("problem paths = %v, want %v", got, want)
+	}
+	// El resto del archivo sigue disponible para Config.Check
+	if config == nil || config.ShutdownTime != "23:00" {
+		t.Errorf("config = %+v, want the decoded file", config)
+	}
+}
+
+func TestInspectMissingFile(t *testing.T) {
+	repo := NewJSONConfigRepository(filepath.Join(t.TempDir(), "installed.json"))
+
+	if _, _, err := repo.Inspect(""); !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("err = %v, want ErrConfigNotFound", err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,359 @@
+// internal/infrastructure/config/dropin.go
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+var ErrSetByDropIn = errors.New("value is set by a drop-in file")
+
+// mainOnlyKeys son claves que mantiene el propio programa y que un fragmento no puede fijar
+var mainOnlyKeys = map[string]bool{"schema_version": true, "created_at": true, "updated_at": true}
+
+// fragment es un archivo <dir>/*.json que se aplica sobre la configuración principal
+type fragment struct {
+	path string
+	doc  map[string]interface{}
+}
+
+// layers es la configuración principal combinada con los fragmentos
+type layers struct {
+	main   map[string]interface{}
+	merged map[string]interface{}
+	// origins indica, por ruta ("mqtt.broker"), el archivo que definió el valor final
+	origins map[string]string
+}
+
+// SetDropInDir habilita los fragmentos <dir>/*.json. Se aplican en orden
+// léxico sobre el archivo principal: los objetos se combinan clave a clave,
+// cualquier otro valor (también las listas) reemplaza al anterior y null
+// elimina la clave. Lo que define un fragmento no se puede cambiar desde los
+// comandos; Save deja el archivo principal sin esos valores.
+func (r *JSONConfigRepository) SetDropInDir(dir string) {
+	r.dropInDir = dir
+}
+
+// LoadEffective retorna la configuración combinada y de qué archivo salió cada valor
+func (r *JSONConfigRepository) LoadEffective() (*entities.EffectiveConfig, error) {
+	if !r.Exists() {
+		return nil, ErrConfigNotFound
+	}
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		return nil, err
+	}
+	migrated, _, err := migrate(data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	fragments, err := r.fragments()
+	if err != nil {
+		return nil, err
+	}
+	l, err := r.combine(migrated, fragments)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.MarshalIndent(l.merged, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decodeDTO(content); err != nil {
+		return nil, fmt.Errorf("merged configuration: %w", err)
+	}
+
+	effective := &entities.EffectiveConfig{
+		Files:   []string{r.filePath},
+		Content: string(content),
+	}
+	for _, f := range fragments {
+		effective.Files = append(effective.Files, f.path)
+	}
+	for _, path := range leafPaths(l.merged, "") {
+		value, _ := lookupPath(l.merged, path)
+		encoded, _ := json.Marshal(value)
+		effective.Values = append(effective.Values, entities.ConfigValue{
+			Path:   path,
+			Value:  string(encoded),
+			Source: l.origins[path],
+		})
+	}
+	return effective, nil
+}
+
+// applyDropIns retorna config con los fragmentos aplicados (config si no hay ninguno).
+// data es el archivo principal del que salió config.
+func (r *JSONConfigRepository) applyDropIns(data []byte, config *entities.Config) (*entities.Config, error) {
+	fragments, err := r.fragments()
+	if err != nil || len(fragments) == 0 {
+		return config, err
+	}
+
+	migrated, _, err := migrate(data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	l, err := r.combine(migrated, fragments)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := json.Marshal(l.merged)
+	if err != nil {
+		return nil, err
+	}
+
+	effective, err := decodeDTO(merged)
+	if err != nil {
+		return nil, fmt.Errorf("configuration with drop-ins from %s: %w", r.dropInDir, err)
+	}
+	return effective, nil
+}
+
+// withoutDropIns prepara el archivo principal a partir de la configuración
+// efectiva serializada: falla si se cambió un valor que define un fragmento y
+// deja en esas rutas lo que tenía el archivo principal
+func (r *JSONConfigRepository) withoutDropIns(data []byte) ([]byte, error) {
+	fragments, err := r.fragments()
+	if err != nil || len(fragments) == 0 {
+		return data, err
+	}
+
+	current := []byte("{}")
+	exists := r.Exists()
+	if exists {
+		raw, err := os.ReadFile(r.filePath)
+		if err != nil {
+			return nil, err
+		}
+		if current, _, err = migrate(raw, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	l, err := r.combine(current, fragments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Se compara contra la configuración efectiva normalizada por encode
+	before := l.merged
+	if merged, err := json.Marshal(l.merged); err == nil {
+		if config, err := decodeDTO(merged); err == nil {
+			if normalized, err := encode(config); err == nil {
+				json.Unmarshal(normalized, &before)
+			}
+		}
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(l.origins))
+	for path, source := range l.origins {
+		if source != r.filePath {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		// Sin archivo principal (instalación) no hay un valor anterior que proteger
+		if exists {
+			newValue, hasNew := lookupPath(doc, path)
+			oldValue, hasOld := lookupPath(before, path)
+			if hasNew != hasOld || !reflect.DeepEqual(newValue, oldValue) {
+				return nil, fmt.Errorf("%w: %s is set by %s; change it there", ErrSetByDropIn, path, l.origins[path])
+			}
+		}
+
+		if value, ok := lookupPath(l.main, path); ok {
+			setPath(doc, path, value)
+		} else {
+			deletePath(doc, path)
+		}
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// fragments lee los fragmentos en orden léxico
+func (r *JSONConfigRepository) fragments() ([]fragment, error) {
+	if r.dropInDir == "" {
+		return nil, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(r.dropInDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	fragments := make([]fragment, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w: %v", path, ErrInvalidConfig, err)
+		}
+		if err := validateFragment(doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		fragments = append(fragments, fragment{path: path, doc: doc})
+	}
+	return fragments, nil
+}
+
+// validateFragment rechaza claves desconocidas (typos) y las que mantiene el programa
+func validateFragment(doc map[string]interface{}) error {
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if mainOnlyKeys[key] {
+			return fmt.Errorf("%w: %q can only be set in the main file", ErrInvalidConfig, key)
+		}
+	}
+	if unknown := unknownFields(doc, reflect.TypeOf(configDTO{}), ""); len(unknown) > 0 {
+		return fmt.Errorf("%w: unknown key %q", ErrInvalidConfig, unknown[0])
+	}
+	return nil
+}
+
+// combine aplica los fragmentos sobre el documento principal (ya en el formato actual)
+func (r *JSONConfigRepository) combine(data []byte, fragments []fragment) (*layers, error) {
+	var main map[string]interface{}
+	if err := json.Unmarshal(data, &main); err != nil {
+		return nil, ErrInvalidConfig
+	}
+
+	l := &layers{
+		main:    main,
+		merged:  map[string]interface{}{},
+		origins: make(map[string]string),
+	}
+	mergeDoc(l.merged, main, "", r.filePath, l.origins)
+	for _, f := range fragments {
+		mergeDoc(l.merged, f.doc, "", f.path, l.origins)
+	}
+	return l, nil
+}
+
+// mergeDoc aplica src sobre dst registrando en origins el archivo de cada valor
+func mergeDoc(dst, src map[string]interface{}, prefix, source string, origins map[string]string) {
+	for key, value := range src {
+		path := joinPath(prefix, key)
+
+		if nested, ok := value.(map[string]interface{}); ok {
+			target, ok := dst[key].(map[string]interface{})
+			if !ok {
+				clearOrigins(origins, path)
+				target = map[string]interface{}{}
+				dst[key] = target
+			}
+			mergeDoc(target, nested, path, source, origins)
+			continue
+		}
+
+		clearOrigins(origins, path)
+		if value == nil {
+			delete(dst, key)
+		} else {
+			dst[key] = value
+		}
+		origins[path] = source
+	}
+}
+
+// clearOrigins olvida el origen de path y de todo lo que cuelga de él
+func clearOrigins(origins map[string]string, path string) {
+	for p := range origins {
+		if p == path || strings.HasPrefix(p, path+".") {
+			delete(origins, p)
+		}
+	}
+}
+
+// leafPaths retorna, ordenadas, las rutas de los valores que no son objetos
+func leafPaths(doc map[string]interface{}, prefix string) []string {
+	var paths []string
+	for key, value := range doc {
+		path := joinPath(prefix, key)
+		if nested, ok := value.(map[string]interface{}); ok {
+			paths = append(paths, leafPaths(nested, path)...)
+		} else {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// lookupPath busca el valor en una ruta "a.b.c"
+func lookupPath(doc map[string]interface{}, path string) (interface{}, bool) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := doc[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		doc = nested
+	}
+	value, ok := doc[keys[len(keys)-1]]
+	return value, ok
+}
+
+// setPath asigna el valor de una ruta creando los objetos intermedios
+func setPath(doc map[string]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := doc[key].(map[string]interface{})
+		if !ok {
+			nested = map[string]interface{}{}
+			doc[key] = nested
+		}
+		doc = nested
+	}
+	doc[keys[len(keys)-1]] = value
+}
+
+// deletePath elimina el valor de una ruta y los objetos que quedan vacíos
+func deletePath(doc map[string]interface{}, path string) {
+	keys := strings.SplitN(path, ".", 2)
+	if len(keys) == 1 {
+		delete(doc, path)
+		return
+	}
+
+	nested, ok := doc[keys[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+	deletePath(nested, keys[1])
+	if len(nested) == 0 {
+		delete(doc, keys[0])
+	}
+}
\ No newline at end of file
@@ -0,0 +1,166 @@
+// internal/infrastructure/config/dropin_test.go
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+// newDropInRepository crea un archivo principal y los fragmentos indicados
+func newDropInRepository(t *testing.T, fragments map[string]string) *JSONConfigRepository {
+	t.Helper()
+	dir := t.TempDir()
+	dropIns := filepath.Join(dir, "rtc-scheduler.d")
+	if err := os.Mkdir(dropIns, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewJSONConfigRepository(filepath.Join(dir, "rtc-scheduler.json"))
+	config, _ := entities.NewConfig("08:00", "22:00", true)
+	config.MaxSnoozeMinutes = 90
+	config.MQTT = &entities.MQTTConfig{Broker: "tcp://local:1883", Username: "host"}
+	if err := repo.Save(config); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range fragments {
+		if err := os.WriteFile(filepath.Join(dropIns, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo.SetDropInDir(dropIns)
+	return repo
+}
+
+var siteFragments = map[string]string{
+	"10-site.json": `{"wake_time": "06:00", "shutdown_time": "23:00", "mqtt": {"broker": "tcp://site:1883"}}`,
+	"20-host.json": `{"shutdown_time": "21:00", "max_snooze_minutes": null, "webhooks": [{"url": "https://hooks.example.com/host"}]}`,
+	"README":       `not a fragment`,
+}
+
+func TestLoadMergesDropInsInLexicalOrder(t *testing.T) {
+	repo := newDropInRepository(t, siteFragments)
+
+	config, err := repo.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// El fragmento posterior gana; los objetos se combinan clave a clave
+	if config.WakeTime != "06:00" || config.ShutdownTime != "21:00" {
+		t.Errorf("schedule = %s-%s, want 06:00-21:00", config.WakeTime, config.ShutdownTime)
+	}
+	if config.MQTT.Broker != "tcp://site:1883" || config.MQTT.Username != "host" {
+		t.Errorf("mqtt not merged key by key: %+v", config.MQTT)
+	}
+	if config.MaxSnoozeMinutes != 0 {
+		t.Errorf("null should remove max_snooze_minutes, got %d", config.MaxSnoozeMinutes)
+	}
+	if len(config.Webhooks) != 1 || config.Webhooks[0].URL != "https://hooks.example.com/host" {
+		t.Errorf("webhooks = %+v", config.Webhooks)
+	}
+}
+
+func TestLoadEffectiveReportsSources(t *testing.T) {
+	repo := newDropInRepository(t, siteFragments)
+
+	effective, err := repo.LoadEffective()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(effective.Files) != 3 || effective.Files[0] != repo.GetFilePath() {
+		t.Errorf("files = %v", effective.Files)
+	}
+
+	sources := make(map[string]string)
+	for _, value := range effective.Values {
+		sources[value.Path] = filepath.Base(value.Source)
+	}
+	want := map[string]string{
+		"wake_time":     "10-site.json",
+		"shutdown_time": "20-host.json",
+		"mqtt.broker":   "10-site.json",
+		"mqtt.username": "rtc-scheduler.json",
+		"enabled":       "rtc-scheduler.json",
+		"webhooks":      "20-host.json",
+	}
+	for path, source := range want {
+		if sources[path] != source {
+			t.Errorf("%s from %q, want %q", path, sources[path], source)
+		}
+	}
+	if _, ok := sources["max_snooze_minutes"]; ok {
+		t.Error("removed value should not be listed")
+	}
+}
+
+func TestSaveKeepsDropInValuesOutOfMainFile(t *testing.T) {
+	repo := newDropInRepository(t, siteFragments)
+
+	config, err := repo.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Enabled = false
+	if err := repo.Save(config); err != nil {
+		t.Fatalf("changing a value no fragment sets should work: %v", err)
+	}
+
+	data, _ := os.ReadFile(repo.GetFilePath())
+	var main map[string]interface{}
+	json.Unmarshal(data, &main)
+	if main["enabled"] != false || main["wake_time"] != "08:00" || main["max_snooze_minutes"] != float64(90) {
+		t.Errorf("main file should keep its own values: %s", data)
+	}
+	if main["mqtt"].(map[string]interface{})["broker"] != "tcp://local:1883" || main["webhooks"] != nil {
+		t.Errorf("fragment values leaked into the main file: %s", data)
+	}
+
+	// Sin los fragmentos vuelven los valores locales
+	os.RemoveAll(repo.dropInDir)
+	config, err = repo.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.WakeTime != "08:00" || config.Enabled || config.MaxSnoozeMinutes != 90 {
+		t.Errorf("unexpected local config %+v", config)
+	}
+}
+
+func TestSaveRejectsChangingDropInValue(t *testing.T) {
+	repo := newDropInRepository(t, siteFragments)
+
+	config, _ := repo.Load()
+	config.ShutdownTime = "23:30"
+	err := repo.Save(config)
+	if !errors.Is(err, ErrSetByDropIn) || !strings.Contains(err.Error(), "20-host.json") {
+		t.Fatalf("expected ErrSetByDropIn naming 20-host.json, got %v", err)
+	}
+
+	config, _ = repo.Load()
+	config.MaxSnoozeMinutes = 30
+	if err := repo.Save(config); !errors.Is(err, ErrSetByDropIn) {
+		t.Errorf("a value removed by a fragment is owned too, got %v", err)
+	}
+}
+
+func TestInvalidFragments(t *testing.T) {
+	for name, content := range map[string]string{
+		"typo":         `{"wake_tme": "06:00"}`,
+		"program-kept": `{"updated_at": "2025-01-01T00:00:00Z"}`,
+		"not json":     `wake_time=06:00`,
+		"not object":   `["06:00"]`,
+	} {
+		repo := newDropInRepository(t, map[string]string{"50-bad.json": content})
+		_, err := repo.Load()
+		if !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), "50-bad.json") {
+			t.Errorf("%s: expected ErrInvalidConfig naming the file, got %v", name, err)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,191 @@
+// internal/infrastructure/config/history.go
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+// versionDTO es el archivo de una versión guardada en el directorio de historial
+type versionDTO struct {
+	Version int             `json:"version"`
+	SavedAt string          `json:"saved_at"`
+	By      string          `json:"by,omitempty"`
+	Config  json.RawMessage `json:"config"`
+}
+
+// SetHistoryDir habilita el historial de versiones en dir. Las copias
+// pueden contener secretos (webhooks, MQTT, NUT), por eso solo las lee root.
+func (r *JSONConfigRepository) SetHistoryDir(dir string) {
+	r.historyDir = dir
+}
+
+// ListVersions retorna las versiones guardadas, de la más antigua a la más nueva
+func (r *JSONConfigRepository) ListVersions() ([]*entities.ConfigVersion, error) {
+	ids, err := r.versionIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*entities.ConfigVersion, 0, len(ids))
+	for _, id := range ids {
+		version, err := r.LoadVersion(id)
+		if err != nil {
+			continue // una copia dañada no impide listar las demás
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// LoadVersion carga una versión por número
+func (r *JSONConfigRepository) LoadVersion(id int) (*entities.ConfigVersion, error) {
+	if r.historyDir == "" {
+		return nil, fmt.Errorf("%w: %d", entities.ErrUnknownConfigVersion, id)
+	}
+
+	data, err := os.ReadFile(r.versionPath(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %d", entities.ErrUnknownConfigVersion, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var dto versionDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("version %d: %w", id, ErrInvalidConfig)
+	}
+	savedAt, err := time.Parse(time.RFC3339, dto.SavedAt)
+	if err != nil {
+		return nil, fmt.Errorf("version %d: %w", id, ErrInvalidConfig)
+	}
+
+	// El JSON anidado queda re-indentado; se restaura el formato del archivo
+	var content bytes.Buffer
+	if err := json.Indent(&content, dto.Config, "", "  "); err != nil {
+		return nil, fmt.Errorf("version %d: %w", id, ErrInvalidConfig)
+	}
+
+	version := &entities.ConfigVersion{
+		ID:      dto.Version,
+		SavedAt: savedAt,
+		By:      dto.By,
+		Content: content.String(),
+	}
+	// Config queda con los fragmentos actuales aplicados, como al cargarla
+	if config, err := decode(dto.Config); err == nil {
+		version.Config, _ = r.applyDropIns(dto.Config, config)
+	}
+	return version, nil
+}
+
+// recordBaseline guarda el archivo actual como primera versión cuando el
+// historial está vacío, para que el primer cambio también se pueda revertir
+func (r *JSONConfigRepository) recordBaseline() {
+	if r.historyDir == "" {
+		return
+	}
+	if ids, err := r.versionIDs(); err != nil || len(ids) > 0 {
+		return
+	}
+
+	info, err := os.Stat(r.filePath)
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(r.filePath)
+	if err != nil || !json.Valid(data) {
+		return
+	}
+	r.recordVersion(data, info.ModTime(), "", entities.DefaultConfigHistoryVersions)
+}
+
+// recordVersion guarda data como una nueva versión y elimina las que excedan
+// keep. Es best-effort: un historial que no se puede escribir (filesystem de
+// solo lectura) no debe impedir guardar la configuración.
+func (r *JSONConfigRepository) recordVersion(data []byte, savedAt time.Time, by string, keep int) {
+	if r.historyDir == "" {
+		return
+	}
+	if err := os.MkdirAll(r.historyDir, 0700); err != nil {
+		return
+	}
+
+	ids, err := r.versionIDs()
+	if err != nil {
+		return
+	}
+	next := 1
+	if len(ids) > 0 {
+		next = ids[len(ids)-1] + 1
+	}
+
+	content, err := json.MarshalIndent(&versionDTO{
+		Version: next,
+		SavedAt: savedAt.Format(time.RFC3339),
+		By:      by,
+		Config:  data,
+	}, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := writeFileAtomic(r.versionPath(next), content, 0600); err != nil {
+		return
+	}
+
+	ids = append(ids, next)
+	for len(ids) > keep {
+		os.Remove(r.versionPath(ids[0]))
+		ids = ids[1:]
+	}
+}
+
+// versionIDs retorna los números de versión guardados, en orden
+func (r *JSONConfigRepository) versionIDs() ([]int, error) {
+	if r.historyDir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(r.historyDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		if id, err := strconv.Atoi(name); err == nil && name != entry.Name() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// versionPath retorna el archivo de una versión
+func (r *JSONConfigRepository) versionPath(id int) string {
+	return filepath.Join(r.historyDir, fmt.Sprintf("%06d.json", id))
+}
+
+// currentUser retorna quién hace el cambio, también bajo sudo
+func currentUser() string {
+	for _, name := range []string{"SUDO_USER", "USER", "LOGNAME"} {
+		if user := os.Getenv(name); user != "" {
+			return user
+		}
+	}
+	return ""
+}
\ No newline at end of file
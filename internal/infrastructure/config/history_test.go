@@ -0,0 +1,121 @@
+// internal/infrastructure/config/history_test.go
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+func TestConfigHistory(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SUDO_USER", "alice")
+
+	repo := NewJSONConfigRepository(filepath.Join(dir, "rtc-scheduler.json"))
+	repo.SetHistoryDir(filepath.Join(dir, "history"))
+
+	config, _ := entities.NewConfig("07:00", "23:00", true)
+	config.ConfigHistoryVersions = 3
+	for _, wake := range []string{"07:00", "06:30", "06:00", "05:45"} {
+		config.WakeTime = wake
+		if err := repo.Save(config); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	versions, err := repo.ListVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 || versions[0].ID != 2 || versions[2].ID != 4 {
+		t.Fatalf("expected versions 2..4, got %d versions", len(versions))
+	}
+
+	latest := versions[2]
+	if latest.By != "alice" || latest.Config == nil || latest.Config.WakeTime != "05:45" {
+		t.Errorf("unexpected latest version: %+v", latest)
+	}
+
+	// El contenido coincide con el archivo escrito
+	data, _ := os.ReadFile(filepath.Join(dir, "rtc-scheduler.json"))
+	if latest.Content != string(data) {
+		t.Errorf("content differs from the config file:\n%s\n---\n%s", latest.Content, data)
+	}
+
+	if _, err := repo.LoadVersion(1); !errors.Is(err, entities.ErrUnknownConfigVersion) {
+		t.Errorf("pruned version: got %v", err)
+	}
+
+	info, err := os.Stat(repo.versionPath(4))
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("version file should be private: %v %v", info.Mode(), err)
+	}
+}
+
+func TestConfigHistoryBaseline(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rtc-scheduler.json")
+
+	// Un archivo escrito antes de habilitar el historial
+	config, _ := entities.NewConfig("08:00", "22:00", true)
+	if err := NewJSONConfigRepository(path).Save(config); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewJSONConfigRepository(path)
+	repo.SetHistoryDir(filepath.Join(dir, "history"))
+	config.WakeTime = "07:00"
+	if err := repo.Save(config); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, _ := repo.ListVersions()
+	if len(versions) != 2 || versions[0].Config.WakeTime != "08:00" || versions[0].By != "" {
+		t.Fatalf("expected the original file as version 1, got %d versions", len(versions))
+	}
+}
+
+func TestConfigHistoryDisabled(t *testing.T) {
+	dir := t.TempDir()
+	repo := NewJSONConfigRepository(filepath.Join(dir, "rtc-scheduler.json"))
+
+	config, _ := entities.NewConfig("07:00", "23:00", true)
+	if err := repo.Save(config); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := repo.ListVersions()
+	if err != nil || len(versions) != 0 {
+		t.Errorf("history should be empty without a directory: %d, %v", len(versions), err)
+	}
+}
+func TestSaveKeepsConfigMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.json")
+	repo := NewJSONConfigRepository(path)
+
+	config, _ := entities.NewConfig("07:00", "23:00", true)
+	if err := repo.Save(config); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config.WakeTime = "06:30"
+	if err := repo.SaveBackup(config); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(config); err != nil {
+		t.Fatal(err)
+	}
+
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("config mode = %v, want the admin's 0600 kept", info.Mode())
+	}
+	if info, _ := os.Stat(repo.backupPath()); info.Mode().Perm() != 0600 {
+		t.Errorf("backup mode = %v, want 0600", info.Mode())
+	}
+}
\ No newline at end of file
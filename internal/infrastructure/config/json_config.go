@@ -4,7 +4,9 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"rtc-scheduler/internal/domain/entities"
@@ -14,24 +16,124 @@ import (
 var (
 	ErrConfigNotFound = errors.New("configuration file not found")
 	ErrInvalidConfig  = errors.New("invalid configuration format")
+	ErrNoConfigBackup = errors.New("no previous configuration to roll back to")
 )
 
 // configDTO es la estructura para serialización JSON
 type configDTO struct {
+	SchemaVersion int `json:"schema_version"`
+
 	WakeTime     string `json:"wake_time"`
 	ShutdownTime string `json:"shutdown_time"`
 	Enabled      bool   `json:"enabled"`
 	CreatedAt    string `json:"created_at"`
 	UpdatedAt    string `json:"updated_at"`
+
+	HistoryRetentionDays int `json:"history_retention_days,omitempty"`
+	HistoryMaxEvents     int `json:"history_max_events,omitempty"`
+
+	ConfigHistoryVersions int `json:"config_history_versions,omitempty"`
+
+	Webhooks             []webhookDTO `json:"webhooks,omitempty"`
+	ShutdownGraceSeconds int          `json:"shutdown_grace_seconds,omitempty"`
+	MaxSnoozeMinutes     int          `json:"max_snooze_minutes,omitempty"`
+
+	MQTT *mqttDTO `json:"mqtt,omitempty"`
+
+	WakePeers []wakePeerDTO `json:"wake_peers,omitempty"`
+
+	UPS *upsDTO `json:"ups,omitempty"`
+
+	Profiles        []profileDTO       `json:"profiles,omitempty"`
+	ActiveProfile   string             `json:"active_profile,omitempty"`
+	ProfileSwitches []profileSwitchDTO `json:"profile_switches,omitempty"`
+
+	Location *locationDTO `json:"location,omitempty"`
+
+	WakeLeadSeconds int    `json:"wake_lead_seconds,omitempty"`
+	LearnWakeLead   bool   `json:"learn_wake_lead,omitempty"`
+	ReadyUnit       string `json:"ready_unit,omitempty"`
+}
+
+// locationDTO son las coordenadas para los horarios solares
+type locationDTO struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// profileDTO es la representación JSON de un perfil de horario
+type profileDTO struct {
+	Name         string `json:"name"`
+	WakeTime     string `json:"wake_time"`
+	ShutdownTime string `json:"shutdown_time"`
+}
+
+// profileSwitchDTO es un cambio de perfil programado para una fecha (YYYY-MM-DD)
+type profileSwitchDTO struct {
+	Profile string `json:"profile"`
+	Date    string `json:"date"`
+}
+
+// upsDTO es la representación JSON de la UPS monitoreada por NUT
+type upsDTO struct {
+	Name                string `json:"name"`
+	Address             string `json:"address,omitempty"`
+	Username            string `json:"username,omitempty"`
+	Password            string `json:"password,omitempty"`
+	PollIntervalSeconds int    `json:"poll_interval_seconds,omitempty"`
+	ShutdownCharge      int    `json:"shutdown_charge,omitempty"`
+	ResumeCharge        int    `json:"resume_charge,omitempty"`
+	RecoveryWakeMinutes int    `json:"recovery_wake_minutes,omitempty"`
+}
+
+// wakePeerDTO es la representación JSON de un peer de Wake-on-LAN
+type wakePeerDTO struct {
+	Name         string   `json:"name"`
+	MAC          string   `json:"mac"`
+	Broadcast    string   `json:"broadcast,omitempty"`
+	DelaySeconds int      `json:"delay_seconds,omitempty"`
+	DependsOn    []string `json:"depends_on,omitempty"`
+}
+
+// mqttDTO es la representación JSON de la integración MQTT
+type mqttDTO struct {
+	Broker                string `json:"broker"`
+	ClientID              string `json:"client_id,omitempty"`
+	Username              string `json:"username,omitempty"`
+	Password              string `json:"password,omitempty"`
+	TopicPrefix           string `json:"topic_prefix,omitempty"`
+	Discovery             bool   `json:"discovery,omitempty"`
+	DiscoveryPrefix       string `json:"discovery_prefix,omitempty"`
+	StatusIntervalSeconds int    `json:"status_interval_seconds,omitempty"`
+}
+
+// webhookDTO es la representación JSON de un webhook
+type webhookDTO struct {
+	URL      string   `json:"url"`
+	Events   []string `json:"events,omitempty"`
+	Template string   `json:"template,omitempty"`
+	Secret   string   `json:"secret,omitempty"`
 }
 
 // JSONConfigRepository implementa ConfigRepository usando archivos JSON
 type JSONConfigRepository struct {
-	filePath string
+	filePath   string
+	historyDir string // vacío = sin historial de versiones
+	dropInDir  string // vacío = sin fragmentos
+	seedPath   string // vacío = sin semilla de primer arranque
+	seedRemove bool
 }
 
-// Verificar que implementa la interfaz
-var _ repositories.ConfigRepository = (*JSONConfigRepository)(nil)
+// Verificar que implementa las interfaces
+var (
+	_ repositories.ConfigRepository          = (*JSONConfigRepository)(nil)
+	_ repositories.ConfigBackupRepository    = (*JSONConfigRepository)(nil)
+	_ repositories.ConfigHistoryRepository   = (*JSONConfigRepository)(nil)
+	_ repositories.ConfigSourceRepository    = (*JSONConfigRepository)(nil)
+	_ repositories.ConfigCheckRepository     = (*JSONConfigRepository)(nil)
+	_ repositories.StateBundleRepository     = (*JSONConfigRepository)(nil)
+	_ repositories.ConfigMigrationRepository = (*JSONConfigRepository)(nil)
+)
 
 // NewJSONConfigRepository crea una nueva instancia
 func NewJSONConfigRepository(filePath string) *JSONConfigRepository {
@@ -40,8 +142,59 @@ func NewJSONConfigRepository(filePath string) *JSONConfigRepository {
 	}
 }
 
-// Save guarda la configuración en un archivo JSON
+// Save guarda la configuración en un archivo JSON y, con el historial
+// habilitado, conserva una copia fechada de la nueva versión. Los valores
+// que definen los fragmentos no se escriben en el archivo principal.
 func (r *JSONConfigRepository) Save(config *entities.Config) error {
+	data, err := encode(config)
+	if err != nil {
+		return err
+	}
+	if data, err = r.withoutDropIns(data); err != nil {
+		return err
+	}
+	return r.write(data, config.ConfigHistoryLimit())
+}
+
+// write reemplaza el archivo principal y registra la versión en el historial.
+// Si el archivo es de un formato anterior, antes guarda el original.
+func (r *JSONConfigRepository) write(data []byte, keep int) error {
+	if err := r.backupOutdated(); err != nil {
+		return err
+	}
+	r.recordBaseline()
+	if err := rewriteFileAtomic(r.filePath, data, 0644); err != nil {
+		return err
+	}
+	r.recordVersion(data, time.Now(), currentUser(), keep)
+	return nil
+}
+
+// SaveBackup guarda una copia de la configuración en <archivo>.prev, legible
+// solo por root: puede contener secretos de webhooks, MQTT y NUT
+func (r *JSONConfigRepository) SaveBackup(config *entities.Config) error {
+	data, err := encode(config)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(r.backupPath(), data, 0600)
+}
+
+// LoadBackup carga la copia guardada por SaveBackup
+func (r *JSONConfigRepository) LoadBackup() (*entities.Config, error) {
+	if _, err := os.Stat(r.backupPath()); os.IsNotExist(err) {
+		return nil, ErrNoConfigBackup
+	}
+	return r.read(r.backupPath())
+}
+
+// backupPath retorna la ruta de la copia de la configuración anterior
+func (r *JSONConfigRepository) backupPath() string {
+	return r.filePath + ".prev"
+}
+
+// encode serializa la configuración en JSON con formato legible
+func encode(config *entities.Config) ([]byte, error) {
 	// Convertir a DTO
 	dto := &configDTO{
 		WakeTime:     config.WakeTime,
@@ -49,31 +202,170 @@ func (r *JSONConfigRepository) Save(config *entities.Config) error {
 		Enabled:      config.Enabled,
 		CreatedAt:    config.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:    config.UpdatedAt.Format(time.RFC3339),
+
+		HistoryRetentionDays: config.HistoryRetentionDays,
+		HistoryMaxEvents:     config.HistoryMaxEvents,
+
+		ConfigHistoryVersions: config.ConfigHistoryVersions,
+
+		ShutdownGraceSeconds: config.ShutdownGraceSeconds,
+		MaxSnoozeMinutes:     config.MaxSnoozeMinutes,
+
+		WakeLeadSeconds: config.WakeLeadSeconds,
+		LearnWakeLead:   config.LearnWakeLead,
+		ReadyUnit:       config.ReadyUnit,
 	}
 
-	// Serializar a JSON con formato legible
-	data, err := json.MarshalIndent(dto, "", "  ")
-	if err != nil {
-		return err
+	for _, hook := range config.Webhooks {
+		dto.Webhooks = append(dto.Webhooks, webhookDTO{
+			URL:      hook.URL,
+			Events:   hook.Events,
+			Template: hook.Template,
+			Secret:   hook.Secret,
+		})
+	}
+
+	if config.MQTT != nil {
+		mqtt := mqttDTO(*config.MQTT)
+		dto.MQTT = &mqtt
+	}
+
+	for _, peer := range config.WakePeers {
+		dto.WakePeers = append(dto.WakePeers, wakePeerDTO(peer))
+	}
+
+	if config.UPS != nil {
+		ups := upsDTO(*config.UPS)
+		dto.UPS = &ups
 	}
 
-	// Escribir archivo
-	return os.WriteFile(r.filePath, data, 0644)
+	dto.ActiveProfile = config.ActiveProfile
+	for _, profile := range config.Profiles {
+		dto.Profiles = append(dto.Profiles, profileDTO(profile))
+	}
+	for _, change := range config.ProfileSwitches {
+		dto.ProfileSwitches = append(dto.ProfileSwitches, profileSwitchDTO{
+			Profile: change.Profile,
+			Date:    change.At.Format("2006-01-02"),
+		})
+	}
+
+	if config.Location != nil {
+		location := locationDTO(*config.Location)
+		dto.Location = &location
+	}
+
+	// Serializar a JSON con formato legible
+	dto.SchemaVersion = CurrentSchemaVersion
+	return json.MarshalIndent(dto, "", "  ")
 }
 
-// Load carga la configuración desde el archivo JSON
+// Load carga la configuración desde el archivo JSON con los fragmentos aplicados
 func (r *JSONConfigRepository) Load() (*entities.Config, error) {
 	// Verificar que el archivo existe
 	if !r.Exists() {
 		return nil, ErrConfigNotFound
 	}
 
-	// Leer archivo
 	data, err := os.ReadFile(r.filePath)
 	if err != nil {
 		return nil, err
 	}
 
+	// Un formato anterior se migra solo en memoria: Load no toma el lock de
+	// comandos y la corren también -status y los webhooks. El archivo se
+	// actualiza con el próximo Save o con Migrate.
+	config, _, err := decodeVersioned(data)
+	if err != nil {
+		return nil, err
+	}
+	return r.applyDropIns(data, config)
+}
+
+// Migrate reescribe el archivo en el formato actual, guardando antes el
+// original. Retorna la versión que tenía y la actual; si son iguales no hace
+// nada.
+func (r *JSONConfigRepository) Migrate() (int, int, error) {
+	if !r.Exists() {
+		return 0, 0, ErrConfigNotFound
+	}
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	config, from, err := decodeVersioned(data)
+	if err != nil {
+		return from, CurrentSchemaVersion, err
+	}
+	if from == CurrentSchemaVersion {
+		return from, from, nil
+	}
+	if data, err = encode(config); err != nil {
+		return from, CurrentSchemaVersion, err
+	}
+	return from, CurrentSchemaVersion, r.write(data, config.ConfigHistoryLimit())
+}
+
+// backupOutdated guarda el archivo en <archivo>.v<N>.bak si es de un formato
+// anterior. Dentro de la unidad systemd /etc es de solo lectura: la copia va
+// entonces al directorio del historial.
+func (r *JSONConfigRepository) backupOutdated() error {
+	original, err := os.ReadFile(r.filePath)
+	if err != nil {
+		return nil
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(original, &doc); err != nil {
+		return nil
+	}
+	from, err := schemaVersion(doc)
+	if err != nil || from >= CurrentSchemaVersion {
+		return nil
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", r.filePath, from)
+	err = replaceFile(backup, original, 0600, -1, -1)
+	if cannotReplace(err) && r.historyDir != "" {
+		if err = os.MkdirAll(r.historyDir, 0700); err == nil {
+			err = writeFileAtomic(filepath.Join(r.historyDir, filepath.Base(backup)), original, 0600)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to back up the schema version %d config before upgrading it: %w", from, err)
+	}
+	return nil
+}
+
+// read carga la configuración guardada en path
+func (r *JSONConfigRepository) read(path string) (*entities.Config, error) {
+	// Leer archivo
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return decode(data)
+}
+
+// decode interpreta el JSON de una configuración, migrándolo si es de un formato anterior
+func decode(data []byte) (*entities.Config, error) {
+	config, _, err := decodeVersioned(data)
+	return config, err
+}
+
+// decodeVersioned es decode que además retorna la versión de formato original
+func decodeVersioned(data []byte) (*entities.Config, int, error) {
+	data, from, err := migrate(data, time.Now())
+	if err != nil {
+		return nil, from, err
+	}
+
+	config, err := decodeDTO(data)
+	return config, from, err
+}
+
+// decodeDTO convierte un documento en el formato actual a la entidad
+func decodeDTO(data []byte) (*entities.Config, error) {
 	// Deserializar JSON
 	var dto configDTO
 	if err := json.Unmarshal(data, &dto); err != nil {
@@ -97,6 +389,58 @@ func (r *JSONConfigRepository) Load() (*entities.Config, error) {
 		Enabled:      dto.Enabled,
 		CreatedAt:    createdAt,
 		UpdatedAt:    updatedAt,
+
+		HistoryRetentionDays: dto.HistoryRetentionDays,
+		HistoryMaxEvents:     dto.HistoryMaxEvents,
+
+		ConfigHistoryVersions: dto.ConfigHistoryVersions,
+
+		ShutdownGraceSeconds: dto.ShutdownGraceSeconds,
+		MaxSnoozeMinutes:     dto.MaxSnoozeMinutes,
+
+		WakeLeadSeconds: dto.WakeLeadSeconds,
+		LearnWakeLead:   dto.LearnWakeLead,
+		ReadyUnit:       dto.ReadyUnit,
+	}
+
+	for _, hook := range dto.Webhooks {
+		config.Webhooks = append(config.Webhooks, entities.WebhookConfig{
+			URL:      hook.URL,
+			Events:   hook.Events,
+			Template: hook.Template,
+			Secret:   hook.Secret,
+		})
+	}
+
+	if dto.MQTT != nil {
+		mqtt := entities.MQTTConfig(*dto.MQTT)
+		config.MQTT = &mqtt
+	}
+
+	for _, peer := range dto.WakePeers {
+		config.WakePeers = append(config.WakePeers, entities.WakePeer(peer))
+	}
+
+	if dto.UPS != nil {
+		ups := entities.UPSConfig(*dto.UPS)
+		config.UPS = &ups
+	}
+
+	config.ActiveProfile = dto.ActiveProfile
+	for _, profile := range dto.Profiles {
+		config.Profiles = append(config.Profiles, entities.Profile(profile))
+	}
+	for _, change := range dto.ProfileSwitches {
+		at, err := time.ParseInLocation("2006-01-02", change.Date, time.Local)
+		if err != nil {
+			return nil, ErrInvalidConfig
+		}
+		config.ProfileSwitches = append(config.ProfileSwitches, entities.ProfileSwitch{Profile: change.Profile, At: at})
+	}
+
+	if dto.Location != nil {
+		location := entities.Coordinates(*dto.Location)
+		config.Location = &location
 	}
 
 	return config, nil
@@ -0,0 +1,107 @@
+// internal/infrastructure/config/migrations.go
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CurrentSchemaVersion es la versión del formato que escribe este binario.
+// Al cambiar el formato se incrementa y se agrega una migración al final de
+// migrations, con sus archivos golden en testdata/migrations.
+const CurrentSchemaVersion = 1
+
+var ErrConfigTooNew = errors.New("configuration was written by a newer rtc-scheduler")
+
+// migration lleva un documento de la versión from a from+1. Trabaja sobre el
+// JSON genérico para no perder claves que el DTO actual no conozca.
+type migration struct {
+	from        int
+	description string
+	apply       func(doc map[string]interface{}, now time.Time) error
+}
+
+// migrations es la cadena completa, en orden, desde archivos sin versión
+var migrations = []migration{
+	{0, "stamp schema_version and fill missing timestamps", migrateV0ToV1},
+}
+
+// migrate actualiza data a CurrentSchemaVersion. Retorna el documento
+// migrado y la versión original; si ya está al día, retorna data sin cambios.
+func migrate(data []byte, now time.Time) ([]byte, int, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, 0, ErrInvalidConfig
+	}
+
+	from, err := schemaVersion(doc)
+	if err != nil {
+		return nil, 0, err
+	}
+	if from == CurrentSchemaVersion {
+		return data, from, nil
+	}
+	if from > CurrentSchemaVersion {
+		return nil, from, fmt.Errorf("%w: schema version %d, this binary supports up to %d; upgrade rtc-scheduler",
+			ErrConfigTooNew, from, CurrentSchemaVersion)
+	}
+
+	for version := from; version < CurrentSchemaVersion; version++ {
+		if err := applyMigration(doc, version, now); err != nil {
+			return nil, from, err
+		}
+	}
+
+	migrated, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, from, err
+	}
+	return migrated, from, nil
+}
+
+// applyMigration aplica el paso que parte de version
+func applyMigration(doc map[string]interface{}, version int, now time.Time) error {
+	for _, m := range migrations {
+		if m.from != version {
+			continue
+		}
+		if err := m.apply(doc, now); err != nil {
+			return fmt.Errorf("config migration %d→%d (%s): %w", m.from, m.from+1, m.description, err)
+		}
+		doc["schema_version"] = m.from + 1
+		return nil
+	}
+	return fmt.Errorf("no config migration from schema version %d", version)
+}
+
+// schemaVersion lee schema_version; su ausencia indica un archivo anterior al versionado
+func schemaVersion(doc map[string]interface{}) (int, error) {
+	value, ok := doc["schema_version"]
+	if !ok {
+		return 0, nil
+	}
+
+	number, ok := value.(float64)
+	if !ok || number < 0 || number != float64(int(number)) {
+		return 0, fmt.Errorf("%w: schema_version must be a non-negative integer", ErrInvalidConfig)
+	}
+	return int(number), nil
+}
+
+// migrateV0ToV1 completa created_at y updated_at, que los archivos escritos a
+// mano suelen omitir y sin los cuales la configuración no cargaba
+func migrateV0ToV1(doc map[string]interface{}, now time.Time) error {
+	stamp := now.Format(time.RFC3339)
+
+	created, _ := doc["created_at"].(string)
+	if created == "" {
+		created = stamp
+		doc["created_at"] = created
+	}
+	if updated, _ := doc["updated_at"].(string); updated == "" {
+		doc["updated_at"] = created
+	}
+	return nil
+}
\ No newline at end of file
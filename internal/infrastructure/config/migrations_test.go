@@ -0,0 +1,181 @@
+// internal/infrastructure/config/migrations_test.go
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the migration golden files")
+
+// migrationNow es la hora fija con la que se generan los golden
+var migrationNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// TestMigrationGolden aplica cada migración a sus entradas v<N>-*.json y compara
+// con v<N>-*.golden.json. Cada migración debe tener al menos una entrada.
+func TestMigrationGolden(t *testing.T) {
+	for _, m := range migrations {
+		inputs, _ := filepath.Glob(filepath.Join("testdata", "migrations", fmt.Sprintf("v%d-*.json", m.from)))
+		cases := 0
+		for _, input := range inputs {
+			if strings.HasSuffix(input, ".golden.json") {
+				continue
+			}
+			cases++
+
+			t.Run(filepath.Base(input), func(t *testing.T) {
+				data, err := os.ReadFile(input)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var doc map[string]interface{}
+				if err := json.Unmarshal(data, &doc); err != nil {
+					t.Fatal(err)
+				}
+				if err := applyMigration(doc, m.from, migrationNow); err != nil {
+					t.Fatal(err)
+				}
+				got, _ := json.MarshalIndent(doc, "", "  ")
+				got = append(got, '\n')
+
+				golden := strings.TrimSuffix(input, ".json") + ".golden.json"
+				if *updateGolden {
+					if err := os.WriteFile(golden, got, 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("missing golden file (run with -update): %v", err)
+				}
+				if string(got) != string(want) {
+					t.Errorf("migration %d→%d mismatch:\n%s\nwant:\n%s", m.from, m.from+1, got, want)
+				}
+			})
+		}
+
+		if cases == 0 {
+			t.Errorf("migration %d→%d has no golden test input", m.from, m.from+1)
+		}
+	}
+}
+
+func TestMigrationChainCoversAllVersions(t *testing.T) {
+	for version := 0; version < CurrentSchemaVersion; version++ {
+		found := false
+		for _, m := range migrations {
+			found = found || m.from == version
+		}
+		if !found {
+			t.Errorf("no migration from schema version %d", version)
+		}
+	}
+}
+
+func TestLoadMigratesLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rtc-scheduler.json")
+	legacy, err := os.ReadFile(filepath.Join("testdata", "migrations", "v0-handwritten.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, legacy, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewJSONConfigRepository(path)
+	config, err := repo.Load()
+	if err != nil {
+		t.Fatalf("legacy config should load: %v", err)
+	}
+	if config.WakeTime != "07:00" || config.CreatedAt.IsZero() {
+		t.Errorf("unexpected config %+v", config)
+	}
+
+	// Load migra solo en memoria: corre sin el lock de comandos
+	if data, _ := os.ReadFile(path); string(data) != string(legacy) {
+		t.Error("Load must not rewrite the file")
+	}
+	if _, err := os.Stat(path + ".v0.bak"); !os.IsNotExist(err) {
+		t.Errorf("Load must not write a backup: %v", err)
+	}
+
+	from, to, err := repo.Migrate()
+	if err != nil || from != 0 || to != CurrentSchemaVersion {
+		t.Fatalf("Migrate() = %d, %d, %v", from, to, err)
+	}
+
+	// Se conserva el original y el archivo queda en el formato actual
+	backup, err := os.ReadFile(path + ".v0.bak")
+	if err != nil || string(backup) != string(legacy) {
+		t.Errorf("expected the original file as backup: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	var doc map[string]interface{}
+	json.Unmarshal(data, &doc)
+	if doc["schema_version"] != float64(CurrentSchemaVersion) {
+		t.Errorf("file not upgraded: schema_version %v", doc["schema_version"])
+	}
+
+	if from, to, err := repo.Migrate(); err != nil || from != to {
+		t.Errorf("second Migrate() = %d, %d, %v, want nothing to do", from, to, err)
+	}
+}
+
+func TestSaveBacksUpLegacyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.json")
+	legacy, err := os.ReadFile(filepath.Join("testdata", "migrations", "v0-handwritten.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, legacy, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewJSONConfigRepository(path)
+	config, err := repo.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.WakeTime = "06:30"
+	if err := repo.Save(config); err != nil {
+		t.Fatal(err)
+	}
+
+	// El primer Save (con el lock tomado) persiste la migración, con copia
+	if backup, err := os.ReadFile(path + ".v0.bak"); err != nil || string(backup) != string(legacy) {
+		t.Errorf("expected the original file as backup: %v", err)
+	}
+}
+
+func TestLoadRefusesNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.json")
+	newer := fmt.Sprintf(`{"schema_version": %d, "wake_time": "07:00", "shutdown_time": "23:00"}`, CurrentSchemaVersion+1)
+	if err := os.WriteFile(path, []byte(newer), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewJSONConfigRepository(path).Load()
+	if !errors.Is(err, ErrConfigTooNew) {
+		t.Fatalf("expected ErrConfigTooNew, got %v", err)
+	}
+
+	// El archivo no se toca
+	if data, _ := os.ReadFile(path); string(data) != newer {
+		t.Error("a newer config must not be rewritten")
+	}
+}
+
+func TestMigrateRejectsBadVersion(t *testing.T) {
+	if _, _, err := migrate([]byte(`{"schema_version": "one"}`), migrationNow); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
\ No newline at end of file
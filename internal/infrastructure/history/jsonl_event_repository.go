@@ -0,0 +1,276 @@
+// internal/infrastructure/history/jsonl_event_repository.go
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+// eventDTO es la estructura de cada línea del archivo JSONL
+type eventDTO struct {
+	Timestamp string            `json:"timestamp"`
+	Type      string            `json:"type"`
+	Level     string            `json:"level"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+// JSONLEventRepository implementa EventRepository como un log append-only en
+// formato JSONL. Append y Compact toman un flock sobre el log: sin él, un
+// evento escrito mientras Compact reescribe el archivo se perdería.
+type JSONLEventRepository struct {
+	filePath string
+}
+
+// Verificar que implementa la interfaz
+var _ repositories.EventRepository = (*JSONLEventRepository)(nil)
+
+// NewJSONLEventRepository crea una nueva instancia
+func NewJSONLEventRepository(filePath string) *JSONLEventRepository {
+	return &JSONLEventRepository{
+		filePath: filePath,
+	}
+}
+
+// Append agrega un evento al final del log
+func (r *JSONLEventRepository) Append(event *entities.PowerEvent) error {
+	line, err := json.Marshal(toDTO(event))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	file, err := r.openLocked(os.O_APPEND | os.O_CREATE | os.O_WRONLY)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	// Una sola escritura por evento para que las líneas no se mezclen entre procesos
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append event: %w", err)
+	}
+
+	return nil
+}
+
+// List retorna los eventos dentro del rango [from, to] en orden cronológico
+func (r *JSONLEventRepository) List(from, to time.Time) ([]*entities.PowerEvent, error) {
+	all, err := r.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*entities.PowerEvent
+	for _, event := range all {
+		if event.IsWithin(from, to) {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// Compact elimina eventos más antiguos que maxAge y conserva como máximo maxEvents.
+// Retorna la cantidad de eventos eliminados.
+func (r *JSONLEventRepository) Compact(maxAge time.Duration, maxEvents int) (int, error) {
+	lock, err := r.openLocked(os.O_RDONLY)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer lock.Close()
+
+	all, err := r.readAll()
+	if err != nil {
+		return 0, err
+	}
+
+	kept := all
+	if maxAge > 0 {
+		cutoff := time.Now().Add(-maxAge)
+		kept = kept[:0:0]
+		for _, event := range all {
+			if !event.Timestamp.Before(cutoff) {
+				kept = append(kept, event)
+			}
+		}
+	}
+
+	if maxEvents > 0 && len(kept) > maxEvents {
+		kept = kept[len(kept)-maxEvents:]
+	}
+
+	removed := len(all) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	if err := r.rewrite(kept); err != nil {
+		return 0, err
+	}
+
+	return removed, nil
+}
+
+// GetFilePath retorna la ruta del archivo (útil para debugging)
+func (r *JSONLEventRepository) GetFilePath() string {
+	return r.filePath
+}
+
+// openLocked abre el log con flag y toma el flock exclusivo, que se libera al
+// cerrarlo. Si Compact reemplazó el archivo mientras se esperaba el lock, el
+// descriptor apunta al anterior: se vuelve a abrir.
+func (r *JSONLEventRepository) openLocked(flag int) (*os.File, error) {
+	for {
+		file, err := os.OpenFile(r.filePath, flag, 0644)
+		if err != nil {
+			return nil, err
+		}
+		if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		opened, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		current, err := os.Stat(r.filePath)
+		if err == nil && os.SameFile(opened, current) {
+			return file, nil
+		}
+		file.Close()
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+}
+
+// readAll lee todos los eventos del archivo, ignorando líneas corruptas
+func (r *JSONLEventRepository) readAll() ([]*entities.PowerEvent, error) {
+	file, err := os.Open(r.filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	var events []*entities.PowerEvent
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var dto eventDTO
+		if err := json.Unmarshal(scanner.Bytes(), &dto); err != nil {
+			// Una línea truncada (p.ej. por un corte de energía) no debe invalidar el historial
+			continue
+		}
+		if event := fromDTO(&dto); event != nil {
+			events = append(events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+
+	return events, nil
+}
+
+// rewrite reemplaza el archivo de forma atómica con los eventos indicados.
+// Se llama con el lock tomado.
+func (r *JSONLEventRepository) rewrite(events []*entities.PowerEvent) error {
+	dir := filepath.Dir(r.filePath)
+	file, err := os.CreateTemp(dir, "."+filepath.Base(r.filePath)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create compacted history: %w", err)
+	}
+	// Si algo falla antes del rename, no dejar el temporal
+	defer os.Remove(file.Name())
+
+	writer := bufio.NewWriter(file)
+	for _, event := range events {
+		line, err := json.Marshal(toDTO(event))
+		if err != nil {
+			file.Close()
+			return err
+		}
+		writer.Write(line)
+		writer.WriteByte('\n')
+	}
+
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write compacted history: %w", err)
+	}
+	if err := file.Chmod(0644); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write compacted history: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to sync compacted history: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write compacted history: %w", err)
+	}
+
+	return os.Rename(file.Name(), r.filePath)
+}
+
+// toDTO convierte un evento a su representación JSON
+func toDTO(event *entities.PowerEvent) *eventDTO {
+	return &eventDTO{
+		Timestamp: event.Timestamp.Format(time.RFC3339Nano),
+		Type:      string(event.Type),
+		Level:     string(event.Level),
+		Message:   event.Message,
+		Fields:    event.Fields,
+	}
+}
+
+// fromDTO convierte una línea JSON a evento; retorna nil si el timestamp es inválido
+func fromDTO(dto *eventDTO) *entities.PowerEvent {
+	timestamp, err := time.Parse(time.RFC3339Nano, dto.Timestamp)
+	if err != nil {
+		return nil
+	}
+
+	level := entities.EventLevel(dto.Level)
+	if level == "" {
+		level = entities.EventLevelInfo
+	}
+
+	fields := dto.Fields
+	if fields == nil {
+		fields = make(map[string]string)
+	}
+
+	return &entities.PowerEvent{
+		Type:      entities.EventType(dto.Type),
+		Level:     level,
+		Timestamp: timestamp.Local(),
+		Message:   dto.Message,
+		Fields:    fields,
+	}
+}
\ No newline at end of file
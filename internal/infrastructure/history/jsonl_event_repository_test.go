@@ -0,0 +1,198 @@
+// internal/infrastructure/history/jsonl_event_repository_test.go
+package history
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+func newTestRepository(t *testing.T) *JSONLEventRepository {
+	t.Helper()
+	return NewJSONLEventRepository(filepath.Join(t.TempDir(), "history", "events.jsonl"))
+}
+
+// appendAt registra un evento con la hora indicada
+func appendAt(t *testing.T, repo *JSONLEventRepository, at time.Time, message string) {
+	t.Helper()
+	event := entities.NewPowerEvent(entities.EventAlarmArmed, message, nil)
+	event.Timestamp = at
+	if err := repo.Append(event); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// messages retorna los mensajes de los eventos, en orden
+func messages(events []*entities.PowerEvent) string {
+	var out []string
+	for _, event := range events {
+		out = append(out, event.Message)
+	}
+	return strings.Join(out, " ")
+}
+
+func TestListReturnsRangeInOrder(t *testing.T) {
+	repo := newTestRepository(t)
+	base := time.Date(2026, 10, 1, 8, 0, 0, 0, time.Local)
+	// Fuera de orden: un evento con la hora de arranque se agrega después
+	appendAt(t, repo, base.Add(2*time.Hour), "c")
+	appendAt(t, repo, base, "a")
+	appendAt(t, repo, base.Add(time.Hour), "b")
+	appendAt(t, repo, base.Add(3*time.Hour), "d")
+
+	all, err := repo.List(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := messages(all); got != "a b c d" {
+		t.Errorf("List() = %q, want a b c d", got)
+	}
+
+	within, err := repo.List(base.Add(time.Hour), base.Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := messages(within); got != "b c" {
+		t.Errorf("List(b, c) = %q, want b c", got)
+	}
+}
+
+func TestListMissingFile(t *testing.T) {
+	repo := newTestRepository(t)
+
+	events, err := repo.List(time.Time{}, time.Time{})
+	if err != nil || len(events) != 0 {
+		t.Errorf("List() = %v, %v, want no events", events, err)
+	}
+	if removed, err := repo.Compact(time.Hour, 10); err != nil || removed != 0 {
+		t.Errorf("Compact() = %d, %v, want nothing removed", removed, err)
+	}
+	if _, err := os.Stat(repo.GetFilePath()); !os.IsNotExist(err) {
+		t.Errorf("Compact created the history file: %v", err)
+	}
+}
+
+func TestListSkipsCorruptLines(t *testing.T) {
+	repo := newTestRepository(t)
+	now := time.Now()
+	appendAt(t, repo, now.Add(-time.Minute), "before")
+
+	// Línea truncada por un corte y una con fecha inválida
+	file, err := os.OpenFile(repo.GetFilePath(), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"timestamp":"2026-10-01T08:00:00Z","type":"arm` + "\n")
+	file.WriteString(`{"timestamp":"yesterday","type":"armed","message":"bad date"}` + "\n")
+	file.Close()
+
+	appendAt(t, repo, now, "after")
+
+	events, err := repo.List(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := messages(events); got != "before after" {
+		t.Errorf("List() = %q, want the valid events only", got)
+	}
+
+	// Compact descarta las líneas corruptas al reescribir
+	if removed, err := repo.Compact(0, 1); err != nil || removed != 1 {
+		t.Fatalf("Compact() = %d, %v, want 1 removed", removed, err)
+	}
+	data, _ := os.ReadFile(repo.GetFilePath())
+	if lines := strings.Count(string(data), "\n"); lines != 1 || !strings.Contains(string(data), "after") {
+		t.Errorf("compacted file = %q, want only the last event", data)
+	}
+}
+
+func TestCompactByAgeAndCount(t *testing.T) {
+	repo := newTestRepository(t)
+	now := time.Now()
+	appendAt(t, repo, now.Add(-72*time.Hour), "old1")
+	appendAt(t, repo, now.Add(-48*time.Hour), "old2")
+	appendAt(t, repo, now.Add(-3*time.Hour), "a")
+	appendAt(t, repo, now.Add(-2*time.Hour), "b")
+	appendAt(t, repo, now.Add(-time.Hour), "c")
+
+	removed, err := repo.Compact(24*time.Hour, 0)
+	if err != nil || removed != 2 {
+		t.Fatalf("Compact(age) = %d, %v, want 2 removed", removed, err)
+	}
+	events, _ := repo.List(time.Time{}, time.Time{})
+	if got := messages(events); got != "a b c" {
+		t.Errorf("after age compaction = %q, want a b c", got)
+	}
+
+	removed, err = repo.Compact(0, 2)
+	if err != nil || removed != 1 {
+		t.Fatalf("Compact(count) = %d, %v, want 1 removed", removed, err)
+	}
+	events, _ = repo.List(time.Time{}, time.Time{})
+	if got := messages(events); got != "b c" {
+		t.Errorf("after count compaction = %q, want the newest b c", got)
+	}
+
+	if removed, err := repo.Compact(24*time.Hour, 2); err != nil || removed != 0 {
+		t.Errorf("Compact() = %d, %v, want nothing left to remove", removed, err)
+	}
+
+	// Sin temporales ni permisos restringidos tras reescribir
+	entries, _ := os.ReadDir(filepath.Dir(repo.GetFilePath()))
+	if len(entries) != 1 {
+		t.Errorf("history directory has %d entries, want only the log", len(entries))
+	}
+	if info, err := os.Stat(repo.GetFilePath()); err != nil || info.Mode().Perm() != 0644 {
+		t.Errorf("compacted log mode = %v, %v, want 0644", info.Mode().Perm(), err)
+	}
+}
+
+func TestCompactKeepsConcurrentAppends(t *testing.T) {
+	repo := newTestRepository(t)
+	now := time.Now()
+	for i := 0; i < 50; i++ {
+		appendAt(t, repo, now.Add(-48*time.Hour), fmt.Sprintf("old%d", i))
+	}
+
+	const writers, perWriter = 4, 50
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				event := entities.NewPowerEvent(entities.EventAlarmArmed, fmt.Sprintf("w%d-%d", w, i), nil)
+				if err := repo.Append(event); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(w)
+	}
+	for i := 0; i < 20; i++ {
+		if _, err := repo.Compact(24*time.Hour, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+
+	events, err := repo.List(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recent := 0
+	for _, event := range events {
+		if !strings.HasPrefix(event.Message, "old") {
+			recent++
+		}
+	}
+	if recent != writers*perWriter {
+		t.Errorf("%d of %d concurrent events kept", recent, writers*perWriter)
+	}
+}
\ No newline at end of file
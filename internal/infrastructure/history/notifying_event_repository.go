@@ -0,0 +1,53 @@
+// internal/infrastructure/history/notifying_event_repository.go
+package history
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+// NotifyingEventRepository decora un EventRepository y publica cada evento
+// registrado en los notificadores (webhooks, ...)
+type NotifyingEventRepository struct {
+	inner     repositories.EventRepository
+	notifiers []repositories.NotifierRepository
+}
+
+// Verificar que implementa la interfaz
+var _ repositories.EventRepository = (*NotifyingEventRepository)(nil)
+
+// NewNotifyingEventRepository crea una nueva instancia
+func NewNotifyingEventRepository(inner repositories.EventRepository, notifiers ...repositories.NotifierRepository) *NotifyingEventRepository {
+	return &NotifyingEventRepository{
+		inner:     inner,
+		notifiers: notifiers,
+	}
+}
+
+// Append registra el evento y luego lo notifica. Un fallo al guardar no impide
+// la notificación: los avisos deben llegar aunque el disco sea de solo lectura.
+func (r *NotifyingEventRepository) Append(event *entities.PowerEvent) error {
+	errs := []error{r.inner.Append(event)}
+
+	for _, notifier := range r.notifiers {
+		if err := notifier.Notify(event); err != nil {
+			errs = append(errs, fmt.Errorf("notification failed: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// List delega en el repositorio decorado
+func (r *NotifyingEventRepository) List(from, to time.Time) ([]*entities.PowerEvent, error) {
+	return r.inner.List(from, to)
+}
+
+// Compact delega en el repositorio decorado
+func (r *NotifyingEventRepository) Compact(maxAge time.Duration, maxEvents int) (int, error) {
+	return r.inner.Compact(maxAge, maxEvents)
+}
\ No newline at end of file
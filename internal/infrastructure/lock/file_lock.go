@@ -0,0 +1,119 @@
+// internal/infrastructure/lock/file_lock.go
+package lock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+// pollInterval es la espera entre intentos de tomar el lock
+const pollInterval = 100 * time.Millisecond
+
+// holderDTO es la representación JSON del poseedor, escrita dentro del archivo de lock
+type holderDTO struct {
+	PID     int    `json:"pid"`
+	Command string `json:"command"`
+	User    string `json:"user,omitempty"`
+	Since   string `json:"since"`
+}
+
+// FileLock implementa LockRepository con flock(2) sobre un archivo. El lock es
+// advisory y lo libera el kernel si el proceso muere, así que nunca queda
+// tomado por un proceso que ya no existe.
+type FileLock struct {
+	path string
+}
+
+// Verificar que implementa la interfaz
+var _ repositories.LockRepository = (*FileLock)(nil)
+
+// NewFileLock crea una nueva instancia
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path}
+}
+
+// Acquire toma el lock exclusivo esperando hasta timeout. Al tomarlo escribe
+// el poseedor en el archivo; si no lo consigue, el error lo nombra.
+func (l *FileLock) Acquire(holder *entities.LockHolder, timeout time.Duration) (func(), error) {
+	// /run/rtc-scheduler lo crea la unidad systemd; la CLI puede correr antes
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if err != syscall.EWOULDBLOCK && err != syscall.EINTR {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", l.path, err)
+		}
+		if time.Now().After(deadline) {
+			current := readHolder(file)
+			file.Close()
+			return nil, fmt.Errorf("%w: held by %s, gave up after %s", entities.ErrLockBusy, current, timeout)
+		}
+		time.Sleep(pollInterval)
+	}
+
+	writeHolder(file, holder)
+
+	release := func() {
+		file.Truncate(0)
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		file.Close()
+	}
+	return release, nil
+}
+
+// writeHolder reemplaza el contenido del archivo con el poseedor actual
+func writeHolder(file *os.File, holder *entities.LockHolder) {
+	data, err := json.Marshal(&holderDTO{
+		PID:     os.Getpid(),
+		Command: holder.Command,
+		User:    holder.User,
+		Since:   time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		return
+	}
+
+	file.Truncate(0)
+	file.WriteAt(data, 0)
+	file.Sync()
+}
+
+// readHolder lee el poseedor escrito por el proceso que tiene el lock
+func readHolder(file *os.File) *entities.LockHolder {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil
+	}
+
+	var dto holderDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil
+	}
+	holder := &entities.LockHolder{PID: dto.PID, Command: dto.Command, User: dto.User}
+	if since, err := time.Parse(time.RFC3339, dto.Since); err == nil {
+		holder.Since = since
+	}
+	return holder
+}
\ No newline at end of file
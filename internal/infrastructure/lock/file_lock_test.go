@@ -0,0 +1,63 @@
+// internal/infrastructure/lock/file_lock_test.go
+package lock
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+func TestFileLockExclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.lock")
+	first := NewFileLock(path)
+	second := NewFileLock(path)
+
+	release, err := first.Acquire(&entities.LockHolder{Command: "install", User: "alice"}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Otro descriptor del mismo archivo no puede tomarlo y nombra al poseedor
+	start := time.Now()
+	_, err = second.Acquire(&entities.LockHolder{Command: "run-service"}, 300*time.Millisecond)
+	if !errors.Is(err, entities.ErrLockBusy) {
+		t.Fatalf("expected ErrLockBusy, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "install by alice") {
+		t.Errorf("error should name the holder: %v", err)
+	}
+	if waited := time.Since(start); waited < 300*time.Millisecond {
+		t.Errorf("gave up before the timeout: %s", waited)
+	}
+
+	release()
+
+	release, err = second.Acquire(&entities.LockHolder{Command: "run-service"}, time.Second)
+	if err != nil {
+		t.Fatalf("lock should be free after release: %v", err)
+	}
+	release()
+}
+
+func TestFileLockWaitsForRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.lock")
+
+	releaseFirst, err := NewFileLock(path).Acquire(&entities.LockHolder{Command: "enable"}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		releaseFirst()
+	}()
+
+	release, err := NewFileLock(path).Acquire(&entities.LockHolder{Command: "disable"}, 2*time.Second)
+	if err != nil {
+		t.Fatalf("expected to get the lock once released: %v", err)
+	}
+	release()
+}
\ No newline at end of file
@@ -0,0 +1,203 @@
+// internal/infrastructure/power/linux_power_state.go
+package power
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+const (
+	procStatPath       = "/proc/stat"
+	bootIDPath         = "/proc/sys/kernel/random/boot_id"
+	procInterruptsPath = "/proc/interrupts"
+	suspendStatsPath   = "/sys/power/suspend_stats/success"
+	wakeupIRQPath      = "/sys/power/pm_wakeup_irq"
+	wakeupClassPath    = "/sys/class/wakeup"
+)
+
+// LinuxPowerState implementa PowerStateRepository leyendo /proc y /sys/power
+type LinuxPowerState struct {
+	procStatPath       string
+	bootIDPath         string
+	procInterruptsPath string
+	suspendStatsPath   string
+	wakeupIRQPath      string
+	wakeupClassPath    string
+}
+
+// Verificar que implementa la interfaz
+var _ repositories.PowerStateRepository = (*LinuxPowerState)(nil)
+
+// NewLinuxPowerState crea una nueva instancia
+func NewLinuxPowerState() *LinuxPowerState {
+	return &LinuxPowerState{
+		procStatPath:       procStatPath,
+		bootIDPath:         bootIDPath,
+		procInterruptsPath: procInterruptsPath,
+		suspendStatsPath:   suspendStatsPath,
+		wakeupIRQPath:      wakeupIRQPath,
+		wakeupClassPath:    wakeupClassPath,
+	}
+}
+
+// BootID retorna el identificador aleatorio que el kernel genera en cada
+// arranque. A diferencia de btime, no cambia si NTP ajusta el reloj.
+func (p *LinuxPowerState) BootID() (string, error) {
+	data, err := os.ReadFile(p.bootIDPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read boot ID: %w", err)
+	}
+
+	id := strings.TrimSpace(string(data))
+	if id == "" {
+		return "", fmt.Errorf("empty boot ID in %s", p.bootIDPath)
+	}
+	return id, nil
+}
+
+// BootTime retorna la hora de arranque del kernel (campo btime de /proc/stat)
+func (p *LinuxPowerState) BootTime() (time.Time, error) {
+	file, err := os.Open(p.procStatPath)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read boot time: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "btime" {
+			seconds, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid btime value: %w", err)
+			}
+			return time.Unix(seconds, 0), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("btime not found in %s", p.procStatPath)
+}
+
+// SuspendCount retorna cuántas suspensiones exitosas hubo desde el arranque
+func (p *LinuxPowerState) SuspendCount() (int, error) {
+	data, err := os.ReadFile(p.suspendStatsPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read suspend stats: %w", err)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid suspend count: %w", err)
+	}
+
+	return count, nil
+}
+
+// LastWakeSource intenta determinar qué despertó al equipo en la última reanudación.
+// Primero consulta la IRQ de wakeup (/sys/power/pm_wakeup_irq); si no está disponible,
+// usa la fuente de /sys/class/wakeup que provocó un wakeup más recientemente.
+// Retorna nil sin error si el kernel no expone la información (p.ej. tras un arranque en frío).
+func (p *LinuxPowerState) LastWakeSource() (*entities.WakeSource, error) {
+	if source := p.sourceFromWakeupIRQ(); source != nil {
+		return source, nil
+	}
+
+	return p.sourceFromWakeupClass()
+}
+
+// sourceFromWakeupIRQ resuelve el nombre de la IRQ que despertó al sistema
+func (p *LinuxPowerState) sourceFromWakeupIRQ() *entities.WakeSource {
+	data, err := os.ReadFile(p.wakeupIRQPath)
+	if err != nil {
+		return nil
+	}
+	irq := strings.TrimSpace(string(data))
+	if irq == "" {
+		return nil
+	}
+
+	file, err := os.Open(p.procInterruptsPath)
+	if err != nil {
+		return &entities.WakeSource{Name: "irq " + irq}
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, irq+":") {
+			continue
+		}
+		fields := strings.Fields(line)
+		name := fields[len(fields)-1]
+		return &entities.WakeSource{Name: name, RTC: isRTCWakeSource(line)}
+	}
+
+	return &entities.WakeSource{Name: "irq " + irq}
+}
+
+// sourceFromWakeupClass busca la fuente de wakeup con el wakeup más reciente
+func (p *LinuxPowerState) sourceFromWakeupClass() (*entities.WakeSource, error) {
+	entries, err := os.ReadDir(p.wakeupClassPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wakeup sources: %w", err)
+	}
+
+	var latest *entities.WakeSource
+	var latestChange int64 = -1
+
+	for _, entry := range entries {
+		dir := filepath.Join(p.wakeupClassPath, entry.Name())
+
+		// Solo interesan las fuentes que efectivamente despertaron al sistema
+		if readInt(filepath.Join(dir, "wakeup_count")) <= 0 {
+			continue
+		}
+
+		lastChange := readInt(filepath.Join(dir, "last_change_ms"))
+		if lastChange <= latestChange {
+			continue
+		}
+
+		name, err := os.ReadFile(filepath.Join(dir, "name"))
+		if err != nil {
+			continue
+		}
+
+		latestChange = lastChange
+		sourceName := strings.TrimSpace(string(name))
+		latest = &entities.WakeSource{Name: sourceName, RTC: isRTCWakeSource(sourceName)}
+	}
+
+	return latest, nil
+}
+
+// isRTCWakeSource verifica si el nombre corresponde a una fuente de wakeup del RTC
+func isRTCWakeSource(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "rtc") || strings.Contains(name, "alarmtimer")
+}
+
+// readInt lee un entero de un archivo de sysfs; retorna -1 si no es posible
+func readInt(path string) int64 {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return -1
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return value
+}
\ No newline at end of file
@@ -0,0 +1,101 @@
+// internal/infrastructure/power/systemctl_power.go
+package power
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+// powerStatePath lista los estados de reposo que admite el kernel ("freeze mem disk")
+const powerStatePath = "/sys/power/state"
+
+// SystemctlPower implementa PowerRepository usando systemctl suspend/poweroff/hibernate
+type SystemctlPower struct {
+	testMode  bool
+	statePath string
+}
+
+// Verificar que implementa la interfaz
+var _ repositories.PowerRepository = (*SystemctlPower)(nil)
+
+// NewSystemctlPower crea una nueva instancia
+func NewSystemctlPower() *SystemctlPower {
+	return &SystemctlPower{
+		testMode:  false,
+		statePath: powerStatePath,
+	}
+}
+
+// NewSystemctlPowerWithTestMode crea una instancia en modo prueba (no cambia el estado del equipo)
+func NewSystemctlPowerWithTestMode(testMode bool) *SystemctlPower {
+	return &SystemctlPower{
+		testMode:  testMode,
+		statePath: powerStatePath,
+	}
+}
+
+// Execute ejecuta la acción de energía indicada
+func (p *SystemctlPower) Execute(action entities.PowerAction) error {
+	if _, err := entities.ParsePowerAction(string(action)); err != nil {
+		return err
+	}
+
+	if p.testMode {
+		msg := fmt.Sprintf("TEST MODE: %s time reached", action)
+		return exec.Command("wall", msg).Run()
+	}
+
+	output, err := exec.Command("systemctl", string(action)).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemctl %s failed: %w: %s", action, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// IsAvailable verifica si systemctl está disponible
+func (p *SystemctlPower) IsAvailable() bool {
+	_, err := exec.LookPath("systemctl")
+	return err == nil
+}
+
+// Supports verifica que este equipo pueda ejecutar la acción: systemctl debe
+// existir y el kernel debe admitir el estado de reposo correspondiente
+func (p *SystemctlPower) Supports(action entities.PowerAction) error {
+	if _, err := entities.ParsePowerAction(string(action)); err != nil {
+		return err
+	}
+	if !p.IsAvailable() {
+		return fmt.Errorf("systemctl not found")
+	}
+
+	// suspend funciona con suspend-to-RAM (mem) o suspend-to-idle (freeze)
+	var states []string
+	switch action {
+	case entities.PowerActionSuspend:
+		states = []string{"mem", "freeze"}
+	case entities.PowerActionHibernate:
+		states = []string{"disk"}
+	default:
+		return nil
+	}
+
+	data, err := os.ReadFile(p.statePath)
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", p.statePath, err)
+	}
+	available := strings.Fields(string(data))
+	for _, state := range states {
+		for _, have := range available {
+			if have == state {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%s is not supported by this kernel (%s: %q)", action, p.statePath, strings.TrimSpace(string(data)))
+}
\ No newline at end of file
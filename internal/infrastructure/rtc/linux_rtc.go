@@ -4,6 +4,7 @@ package rtc
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -12,8 +13,8 @@ import (
 )
 
 const (
-	wakeAlarmPath = "/sys/class/rtc/rtc0/wakealarm"
-	timePath      = "/sys/class/rtc/rtc0/since_epoch"
+	sysfsDir      = "/sys/class/rtc"
+	defaultDevice = "rtc0"
 )
 
 type LinuxRTC struct {
@@ -25,12 +26,23 @@ type LinuxRTC struct {
 var _ repositories.RTCRepository = (*LinuxRTC)(nil)
 
 func NewLinuxRTC() *LinuxRTC {
+	return NewLinuxRTCForDevice(defaultDevice)
+}
+
+// NewLinuxRTCForDevice usa el RTC indicado por nombre ("rtc1") o ruta ("/dev/rtc1")
+func NewLinuxRTCForDevice(device string) *LinuxRTC {
+	dir := SysfsPath(device)
 	return &LinuxRTC{
-		wakeAlarmPath: wakeAlarmPath,
-		timePath:      timePath,
+		wakeAlarmPath: filepath.Join(dir, "wakealarm"),
+		timePath:      filepath.Join(dir, "since_epoch"),
 	}
 }
 
+// SysfsPath retorna el directorio sysfs de un RTC ("/dev/rtc1" → /sys/class/rtc/rtc1)
+func SysfsPath(device string) string {
+	return filepath.Join(sysfsDir, filepath.Base(device))
+}
+
 func (r *LinuxRTC) SetWakeAlarm(t time.Time) error {
 	// Limpiar alarma anterior
 	if err := r.ClearWakeAlarm(); err != nil {
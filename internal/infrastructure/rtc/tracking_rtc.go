@@ -0,0 +1,69 @@
+// internal/infrastructure/rtc/tracking_rtc.go
+package rtc
+
+import (
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+// TrackingRTC envuelve un RTCRepository y persiste cada alarma armada, de modo
+// que tras el siguiente arranque o reanudación se pueda verificar si el equipo
+// despertó a la hora esperada (el kernel resetea wakealarm al dispararse).
+type TrackingRTC struct {
+	rtc   repositories.RTCRepository
+	state repositories.WakeStateRepository
+}
+
+// Verificar que implementa la interfaz
+var _ repositories.RTCRepository = (*TrackingRTC)(nil)
+
+// NewTrackingRTC crea una nueva instancia
+func NewTrackingRTC(rtc repositories.RTCRepository, state repositories.WakeStateRepository) *TrackingRTC {
+	return &TrackingRTC{
+		rtc:   rtc,
+		state: state,
+	}
+}
+
+// SetWakeAlarm arma la alarma y registra la hora armada.
+// El registro es best-effort: en un filesystem de solo lectura la alarma
+// debe seguir funcionando aunque luego no se pueda verificar.
+func (r *TrackingRTC) SetWakeAlarm(t time.Time) error {
+	if err := r.rtc.SetWakeAlarm(t); err != nil {
+		return err
+	}
+
+	r.state.SaveArmedWake(&entities.ArmedWake{
+		WakeTime: t,
+		ArmedAt:  time.Now(),
+	})
+
+	return nil
+}
+
+// GetWakeAlarm delega en el RTC subyacente
+func (r *TrackingRTC) GetWakeAlarm() (time.Time, error) {
+	return r.rtc.GetWakeAlarm()
+}
+
+// ClearWakeAlarm limpia la alarma y olvida la hora armada
+func (r *TrackingRTC) ClearWakeAlarm() error {
+	if err := r.rtc.ClearWakeAlarm(); err != nil {
+		return err
+	}
+
+	r.state.ClearArmedWake()
+	return nil
+}
+
+// GetCurrentTime delega en el RTC subyacente
+func (r *TrackingRTC) GetCurrentTime() (time.Time, error) {
+	return r.rtc.GetCurrentTime()
+}
+
+// IsAvailable delega en el RTC subyacente
+func (r *TrackingRTC) IsAvailable() bool {
+	return r.rtc.IsAvailable()
+}
\ No newline at end of file
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"rtc-scheduler/internal/domain/entities"
 	"rtc-scheduler/internal/domain/repositories"
 )
 
@@ -22,7 +23,8 @@ var (
 
 // AtScheduler implementa SchedulerRepository usando el comando 'at'
 type AtScheduler struct {
-	testMode bool
+	testMode   bool
+	executable string
 }
 
 // Verificar que implementa la interfaz
@@ -42,6 +44,11 @@ func NewAtSchedulerWithTestMode(testMode bool) *AtScheduler {
 	}
 }
 
+// SetExecutable indica el binario de rtc-scheduler que ejecutará el trabajo de apagado
+func (s *AtScheduler) SetExecutable(path string) {
+	s.executable = path
+}
+
 // ScheduleShutdown programa una suspensión del sistema
 func (s *AtScheduler) ScheduleShutdown(t time.Time) error {
 	if !s.IsAvailable() {
@@ -69,7 +76,7 @@ func (s *AtScheduler) ScheduleShutdown(t time.Time) error {
 	if s.testMode {
 		command = "echo 'TEST MODE: Suspend time reached' | wall"
 	} else {
-		command = "systemctl suspend"
+		command = shutdownCommand(s.executable, "suspend", "systemctl suspend")
 	}
 
 	// Ejecutar 'at'
@@ -141,6 +148,16 @@ func (s *AtScheduler) IsAvailable() bool {
 	return err == nil
 }
 
+// Backend retorna el nombre del mecanismo de programación
+func (s *AtScheduler) Backend() string {
+	return "at"
+}
+
+// ShutdownAction retorna la acción que ejecutan los trabajos de at
+func (s *AtScheduler) ShutdownAction() entities.PowerAction {
+	return entities.PowerActionSuspend
+}
+
 // isFilesystemWritable verifica si el filesystem permite escritura en el directorio de 'at'
 func (s *AtScheduler) isFilesystemWritable() bool {
 	// Intentar crear un archivo temporal en el directorio de 'at'
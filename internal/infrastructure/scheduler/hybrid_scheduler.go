@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"rtc-scheduler/internal/domain/entities"
 	"rtc-scheduler/internal/domain/repositories"
 )
 
@@ -13,6 +14,10 @@ type HybridScheduler struct {
 	atScheduler    *AtScheduler
 	timerScheduler *SystemdTimerScheduler
 	testMode       bool
+
+	// Backends permitidos para programar (SetBackend); por defecto ambos
+	allowAt    bool
+	allowTimer bool
 }
 
 // Verificar que implementa la interfaz
@@ -24,6 +29,8 @@ func NewHybridScheduler() *HybridScheduler {
 		atScheduler:    NewAtScheduler(),
 		timerScheduler: NewSystemdTimerScheduler(),
 		testMode:       false,
+		allowAt:        true,
+		allowTimer:     true,
 	}
 }
 
@@ -33,18 +40,53 @@ func NewHybridSchedulerWithTestMode(testMode bool) *HybridScheduler {
 		atScheduler:    NewAtSchedulerWithTestMode(testMode),
 		timerScheduler: NewSystemdTimerSchedulerWithTestMode(testMode),
 		testMode:       testMode,
+		allowAt:        true,
+		allowTimer:     true,
+	}
+}
+
+// SetBackend restringe los trabajos nuevos a un backend: "auto" (at y, si no se
+// puede, systemd-run), "at" o "systemd". Cancelar y listar siguen revisando
+// ambos para no perder trabajos programados antes del cambio.
+func (s *HybridScheduler) SetBackend(backend string) error {
+	switch backend {
+	case "", "auto":
+		s.allowAt, s.allowTimer = true, true
+	case "at":
+		s.allowAt, s.allowTimer = true, false
+	case "systemd":
+		s.allowAt, s.allowTimer = false, true
+	default:
+		return fmt.Errorf("unknown scheduler backend %q (use auto, at or systemd)", backend)
 	}
+	return nil
+}
+
+// atUsable indica si los trabajos nuevos irían a at
+func (s *HybridScheduler) atUsable() bool {
+	return s.allowAt && s.atScheduler.IsAvailable() && s.atScheduler.isFilesystemWritable()
+}
+
+// timerUsable indica si los trabajos nuevos pueden ir a systemd-run
+func (s *HybridScheduler) timerUsable() bool {
+	return s.allowTimer && s.timerScheduler.IsAvailable()
+}
+
+// SetExecutable indica el binario de rtc-scheduler que ejecutará los trabajos de apagado
+func (s *HybridScheduler) SetExecutable(path string) {
+	s.atScheduler.SetExecutable(path)
+	s.timerScheduler.SetExecutable(path)
 }
 
 // ScheduleShutdown elige el mejor scheduler disponible
 func (s *HybridScheduler) ScheduleShutdown(t time.Time) error {
 	// Prioridad 1: AtScheduler (si está disponible y filesystem es writable)
-	if s.atScheduler.IsAvailable() && s.atScheduler.isFilesystemWritable() {
+	if s.atUsable() {
 		return s.atScheduler.ScheduleShutdown(t)
 	}
 
 	// Prioridad 2: SystemdTimerScheduler (si está disponible)
-	if s.timerScheduler.IsAvailable() {
+	if s.timerUsable() {
 		return s.timerScheduler.ScheduleShutdown(t)
 	}
 
@@ -97,9 +139,31 @@ func (s *HybridScheduler) ListScheduledJobs() ([]*repositories.ShutdownJob, erro
 // IsAvailable verifica si al menos un scheduler está disponible
 func (s *HybridScheduler) IsAvailable() bool {
 	// Está disponible si:
-	// 1. AtScheduler está disponible Y filesystem es writable, O
-	// 2. SystemdTimerScheduler está disponible
-	return (s.atScheduler.IsAvailable() && s.atScheduler.isFilesystemWritable()) || s.timerScheduler.IsAvailable()
+	// 1. AtScheduler está permitido, disponible Y filesystem es writable, O
+	// 2. SystemdTimerScheduler está permitido y disponible
+	return s.atUsable() || s.timerUsable()
+}
+
+// Backend retorna el scheduler que se usaría ahora para programar ("none" si ninguno)
+func (s *HybridScheduler) Backend() string {
+	if s.atUsable() {
+		return s.atScheduler.Backend()
+	}
+	if s.timerUsable() {
+		return s.timerScheduler.Backend()
+	}
+	return "none"
+}
+
+// ShutdownAction retorna la acción del backend que se usaría ahora ("" si ninguno)
+func (s *HybridScheduler) ShutdownAction() entities.PowerAction {
+	if s.atUsable() {
+		return s.atScheduler.ShutdownAction()
+	}
+	if s.timerUsable() {
+		return s.timerScheduler.ShutdownAction()
+	}
+	return ""
 }
 
 // GetSchedulerStatus retorna información detallada sobre el estado de los schedulers
@@ -124,9 +188,9 @@ func (s *HybridScheduler) GetSchedulerStatus() map[string]interface{} {
 
 	// Scheduler activo
 	var activeScheduler string
-	if atAvailable && atWritable {
+	if s.atUsable() {
 		activeScheduler = "at_scheduler"
-	} else if timerAvailable {
+	} else if s.timerUsable() {
 		activeScheduler = "systemd_timer_scheduler"
 	} else {
 		activeScheduler = "none"
@@ -158,12 +222,12 @@ func (s *HybridScheduler) GetJobDetails(jobID string) (string, error) {
 // ScheduleAt programa un comando usando el mejor scheduler disponible
 func (s *HybridScheduler) ScheduleAt(t time.Time, command string) error {
 	// Prioridad 1: AtScheduler
-	if s.atScheduler.IsAvailable() && s.atScheduler.isFilesystemWritable() {
+	if s.atUsable() {
 		return s.atScheduler.ScheduleAt(t, command)
 	}
 
 	// Prioridad 2: SystemdTimerScheduler
-	if s.timerScheduler.IsAvailable() {
+	if s.timerUsable() {
 		return s.timerScheduler.ScheduleAt(t, command)
 	}
 
@@ -173,12 +237,12 @@ func (s *HybridScheduler) ScheduleAt(t time.Time, command string) error {
 // ParseJobID parsea el ID del trabajo de la salida del scheduler activo
 func (s *HybridScheduler) ParseJobID(output string) (string, error) {
 	// Intentar con AtScheduler primero
-	if s.atScheduler.IsAvailable() && s.atScheduler.isFilesystemWritable() {
+	if s.atUsable() {
 		return s.atScheduler.ParseJobID(output)
 	}
 
 	// Intentar con SystemdTimerScheduler
-	if s.timerScheduler.IsAvailable() {
+	if s.timerUsable() {
 		return s.timerScheduler.ParseJobID(output)
 	}
 
@@ -0,0 +1,22 @@
+// internal/infrastructure/scheduler/shutdown_command.go
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// shutdownCommand arma el comando del trabajo de apagado. Si se conoce el
+// ejecutable, el trabajo lo invoca con -execute-shutdown para que se notifiquen
+// los eventos de apagado; si falla, se ejecuta directamente el comando de respaldo.
+func shutdownCommand(executable, action, fallback string) string {
+	if executable == "" {
+		return fallback
+	}
+	return fmt.Sprintf("%s -execute-shutdown=%s || %s", shellQuote(executable), action, fallback)
+}
+
+// shellQuote encierra el valor entre comillas simples para sh
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
\ No newline at end of file
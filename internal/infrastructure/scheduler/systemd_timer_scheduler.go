@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"rtc-scheduler/internal/domain/entities"
 	"rtc-scheduler/internal/domain/repositories"
 )
 
@@ -20,7 +21,8 @@ var (
 
 // SystemdTimerScheduler implementa SchedulerRepository usando systemd-run
 type SystemdTimerScheduler struct {
-	testMode bool
+	testMode   bool
+	executable string
 }
 
 // Verificar que implementa la interfaz
@@ -40,6 +42,11 @@ func NewSystemdTimerSchedulerWithTestMode(testMode bool) *SystemdTimerScheduler
 	}
 }
 
+// SetExecutable indica el binario de rtc-scheduler que ejecutará el trabajo de apagado
+func (s *SystemdTimerScheduler) SetExecutable(path string) {
+	s.executable = path
+}
+
 // ScheduleShutdown programa un apagado del sistema usando systemd-run
 func (s *SystemdTimerScheduler) ScheduleShutdown(t time.Time) error {
 	if !s.IsAvailable() {
@@ -63,7 +70,7 @@ func (s *SystemdTimerScheduler) ScheduleShutdown(t time.Time) error {
 		command = "/usr/bin/wall 'TEST MODE: Shutdown time reached'"
 	} else {
 		// Usar systemctl poweroff con ruta absoluta correcta
-		command = "/usr/bin/systemctl poweroff"
+		command = shutdownCommand(s.executable, "poweroff", "/usr/bin/systemctl poweroff")
 	}
 
 	// Crear timer con systemd-run
@@ -148,6 +155,16 @@ func (s *SystemdTimerScheduler) IsAvailable() bool {
 	return err == nil
 }
 
+// Backend retorna el nombre del mecanismo de programación
+func (s *SystemdTimerScheduler) Backend() string {
+	return "systemd-run"
+}
+
+// ShutdownAction retorna la acción que ejecutan los timers de systemd-run
+func (s *SystemdTimerScheduler) ShutdownAction() entities.PowerAction {
+	return entities.PowerActionPoweroff
+}
+
 // listActiveTimers lista todos los timers activos de systemd
 func (s *SystemdTimerScheduler) listActiveTimers() ([]string, error) {
 	cmd := exec.Command("systemctl", "list-timers", "--all", "--no-pager", "--no-legend")
@@ -0,0 +1,238 @@
+// internal/infrastructure/settings/settings.go
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"rtc-scheduler/pkg/yaml"
+)
+
+// DefaultPath es el archivo YAML de ajustes del sistema (opcional)
+const DefaultPath = "/etc/rtc-scheduler/config.yaml"
+
+// Tipos de scheduler aceptados en scheduler.type
+const (
+	SchedulerAuto    = "auto"
+	SchedulerAt      = "at"
+	SchedulerSystemd = "systemd"
+)
+
+// Qué hacer con la semilla de primer arranque tras importarla (seed.after_import)
+const (
+	SeedRename = "rename"
+	SeedRemove = "remove"
+)
+
+var ErrInvalidSettings = errors.New("invalid settings")
+
+// Settings son los ajustes de arranque: dónde está el RTC, en qué zona horaria
+// se interpretan los horarios, qué scheduler se usa, a dónde va el log y cómo se
+// llama la unidad systemd, y de dónde se importa la configuración en el primer
+// arranque. El horario en sí vive en /etc/rtc-scheduler.json.
+type Settings struct {
+	RTCDevice          string
+	Timezone           string
+	Scheduler          string
+	LogFile            string
+	ServiceName        string
+	ServiceDescription string
+	ServiceUser        string
+	SeedPath           string
+	SeedAfterImport    string
+}
+
+// Key describe un ajuste: su ruta en el YAML, su variable de entorno y su flag
+// (vacíos si no existen)
+type Key struct {
+	Path  string
+	Env   string
+	Flag  string
+	field func(*Settings) *string
+}
+
+// Keys son todos los ajustes reconocidos, en el orden de configs/default.yaml.
+// scheduler.log_file no tiene variable ni flag propios: los reemplazan
+// RTC_SCHEDULER_LOG_OUTPUT y -log-output.
+var Keys = []Key{
+	{"rtc.device", "RTC_SCHEDULER_RTC_DEVICE", "rtc-device", func(s *Settings) *string { return &s.RTCDevice }},
+	{"rtc.timezone", "RTC_SCHEDULER_TIMEZONE", "timezone", func(s *Settings) *string { return &s.Timezone }},
+	{"scheduler.type", "RTC_SCHEDULER_SCHEDULER", "scheduler", func(s *Settings) *string { return &s.Scheduler }},
+	{"scheduler.log_file", "", "", func(s *Settings) *string { return &s.LogFile }},
+	{"service.name", "RTC_SCHEDULER_SERVICE_NAME", "", func(s *Settings) *string { return &s.ServiceName }},
+	{"service.description", "RTC_SCHEDULER_SERVICE_DESCRIPTION", "", func(s *Settings) *string { return &s.ServiceDescription }},
+	{"service.user", "RTC_SCHEDULER_SERVICE_USER", "", func(s *Settings) *string { return &s.ServiceUser }},
+	{"seed.path", "RTC_SCHEDULER_SEED_PATH", "", func(s *Settings) *string { return &s.SeedPath }},
+	{"seed.after_import", "RTC_SCHEDULER_SEED_AFTER_IMPORT", "", func(s *Settings) *string { return &s.SeedAfterImport }},
+}
+
+// Defaults retorna los valores por defecto (los mismos de configs/default.yaml)
+func Defaults() Settings {
+	return Settings{
+		RTCDevice:          "/dev/rtc0",
+		Timezone:           "Local",
+		Scheduler:          SchedulerAuto,
+		LogFile:            "",
+		ServiceName:        "rtc-scheduler",
+		ServiceDescription: "RTC Power Schedule Manager",
+		ServiceUser:        "root",
+		SeedPath:           "",
+		SeedAfterImport:    SeedRename,
+	}
+}
+
+// Set asigna un ajuste por su ruta ("rtc.device")
+func (s *Settings) Set(path, value string) error {
+	for _, key := range Keys {
+		if key.Path == path {
+			*key.field(s) = strings.TrimSpace(value)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unknown setting %q", ErrInvalidSettings, path)
+}
+
+// FromFile aplica sobre s los ajustes del archivo YAML. Si el archivo no existe
+// se retorna s sin cambios.
+func FromFile(s Settings, path string) (Settings, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	doc, err := yaml.Parse(data)
+	if err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+
+	values, err := flatten("", doc)
+	if err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// Orden estable para que el error sea siempre el mismo
+	paths := make([]string, 0, len(values))
+	for p := range values {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		if err := s.Set(p, values[p]); err != nil {
+			return s, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return s, nil
+}
+
+// FromEnv aplica sobre s las variables de entorno definidas
+func FromEnv(s Settings) Settings {
+	for _, key := range Keys {
+		if key.Env == "" {
+			continue
+		}
+		if value := os.Getenv(key.Env); value != "" {
+			*key.field(&s) = strings.TrimSpace(value)
+		}
+	}
+	return s
+}
+
+// Validate verifica que los ajustes se puedan usar
+func (s Settings) Validate() error {
+	if s.RTCDevice == "" || s.RTCName() == "." || s.RTCName() == "/" {
+		return fmt.Errorf("%w: rtc.device must name an RTC device, e.g. /dev/rtc0", ErrInvalidSettings)
+	}
+
+	if _, err := s.Location(); err != nil {
+		return err
+	}
+
+	switch s.Scheduler {
+	case SchedulerAuto, SchedulerAt, SchedulerSystemd:
+	default:
+		return fmt.Errorf("%w: scheduler.type %q (use auto, at or systemd)", ErrInvalidSettings, s.Scheduler)
+	}
+
+	if s.ServiceName == "" || strings.ContainsAny(s.ServiceName, "/ \t") {
+		return fmt.Errorf("%w: service.name %q", ErrInvalidSettings, s.ServiceName)
+	}
+	if s.ServiceUser == "" || strings.ContainsAny(s.ServiceUser, "/ \t") {
+		return fmt.Errorf("%w: service.user %q", ErrInvalidSettings, s.ServiceUser)
+	}
+	if strings.ContainsAny(s.ServiceDescription, "\n\r") {
+		return fmt.Errorf("%w: service.description must be a single line", ErrInvalidSettings)
+	}
+
+	if s.SeedPath != "" && !filepath.IsAbs(s.SeedPath) {
+		return fmt.Errorf("%w: seed.path %q must be absolute", ErrInvalidSettings, s.SeedPath)
+	}
+	switch s.SeedAfterImport {
+	case SeedRename, SeedRemove:
+	default:
+		return fmt.Errorf("%w: seed.after_import %q (use rename or remove)", ErrInvalidSettings, s.SeedAfterImport)
+	}
+
+	return nil
+}
+
+// Location retorna la zona horaria de rtc.timezone ("Local" o vacío = la del sistema)
+func (s Settings) Location() (*time.Location, error) {
+	if s.Timezone == "" || s.Timezone == "Local" {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: rtc.timezone %q: %v", ErrInvalidSettings, s.Timezone, err)
+	}
+	return loc, nil
+}
+
+// RTCName retorna el nombre del dispositivo RTC ("/dev/rtc1" → "rtc1")
+func (s Settings) RTCName() string {
+	return filepath.Base(s.RTCDevice)
+}
+
+// Unit retorna el nombre de la unidad systemd ("rtc-scheduler" → "rtc-scheduler.service")
+func (s Settings) Unit() string {
+	if strings.HasSuffix(s.ServiceName, ".service") {
+		return s.ServiceName
+	}
+	return s.ServiceName + ".service"
+}
+
+// flatten convierte el documento en rutas "seccion.clave" con valores escalares
+func flatten(prefix string, doc map[string]interface{}) (map[string]string, error) {
+	values := make(map[string]string)
+	for key, value := range doc {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case string:
+			values[path] = v
+		case map[string]interface{}:
+			nested, err := flatten(path, v)
+			if err != nil {
+				return nil, err
+			}
+			for p, nv := range nested {
+				values[p] = nv
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s must be a single value", ErrInvalidSettings, path)
+		}
+	}
+	return values, nil
+}
\ No newline at end of file
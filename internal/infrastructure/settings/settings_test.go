@@ -0,0 +1,83 @@
+// internal/infrastructure/settings/settings_test.go
+package settings
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultYAMLMatchesDefaults(t *testing.T) {
+	got, err := FromFile(Settings{}, filepath.Join("..", "..", "..", "configs", "default.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != Defaults() {
+		t.Errorf("configs/default.yaml = %+v, Defaults() = %+v", got, Defaults())
+	}
+}
+
+func TestLayering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := "rtc:\n  device: /dev/rtc1\n  timezone: Europe/Madrid\nscheduler:\n  type: at\n"
+	if err := os.WriteFile(path, []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RTC_SCHEDULER_TIMEZONE", "America/Santiago")
+
+	s, err := FromFile(Defaults(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = FromEnv(s)
+
+	// El archivo pisa los defaults y el entorno pisa el archivo
+	if s.RTCDevice != "/dev/rtc1" || s.RTCName() != "rtc1" || s.Scheduler != SchedulerAt {
+		t.Errorf("file not applied: %+v", s)
+	}
+	if s.Timezone != "America/Santiago" {
+		t.Errorf("env should override the file, got %q", s.Timezone)
+	}
+	if s.ServiceName != "rtc-scheduler" || s.Unit() != "rtc-scheduler.service" {
+		t.Errorf("defaults lost: %+v", s)
+	}
+	if err := s.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFromFileMissing(t *testing.T) {
+	s, err := FromFile(Defaults(), filepath.Join(t.TempDir(), "absent.yaml"))
+	if err != nil || s != Defaults() {
+		t.Errorf("missing file should keep the defaults: %+v, %v", s, err)
+	}
+}
+
+func TestFromFileRejectsUnknownKeys(t *testing.T) {
+	for _, doc := range []string{"rtc:\n  devise: /dev/rtc1\n", "scheduler:\n  type: [at]\n"} {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		os.WriteFile(path, []byte(doc), 0644)
+
+		if _, err := FromFile(Defaults(), path); !errors.Is(err, ErrInvalidSettings) {
+			t.Errorf("%q: expected ErrInvalidSettings, got %v", doc, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	bad := []func(*Settings){
+		func(s *Settings) { s.Scheduler = "cron" },
+		func(s *Settings) { s.Timezone = "Mars/Olympus" },
+		func(s *Settings) { s.RTCDevice = "" },
+		func(s *Settings) { s.ServiceName = "rtc scheduler" },
+		func(s *Settings) { s.ServiceDescription = "two\nlines" },
+	}
+	for i, mutate := range bad {
+		s := Defaults()
+		mutate(&s)
+		if err := s.Validate(); !errors.Is(err, ErrInvalidSettings) {
+			t.Errorf("case %d: expected ErrInvalidSettings, got %v", i, err)
+		}
+	}
+}
\ No newline at end of file
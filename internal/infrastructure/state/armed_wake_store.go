@@ -0,0 +1,66 @@
+// internal/infrastructure/state/armed_wake_store.go
+package state
+
+import (
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+// armedWakeDTO es la estructura para serialización JSON
+type armedWakeDTO struct {
+	WakeTime string `json:"wake_time"`
+	ArmedAt  string `json:"armed_at"`
+}
+
+// ArmedWakeStore implementa WakeStateRepository en un archivo JSON
+type ArmedWakeStore struct {
+	filePath string
+}
+
+// Verificar que implementa la interfaz
+var _ repositories.WakeStateRepository = (*ArmedWakeStore)(nil)
+
+// NewArmedWakeStore crea una nueva instancia
+func NewArmedWakeStore(filePath string) *ArmedWakeStore {
+	return &ArmedWakeStore{
+		filePath: filePath,
+	}
+}
+
+// SaveArmedWake persiste la alarma armada
+func (s *ArmedWakeStore) SaveArmedWake(wake *entities.ArmedWake) error {
+	return writeJSON(s.filePath, &armedWakeDTO{
+		WakeTime: wake.WakeTime.Format(time.RFC3339),
+		ArmedAt:  wake.ArmedAt.Format(time.RFC3339),
+	})
+}
+
+// LoadArmedWake retorna la alarma persistida, o nil si no hay ninguna
+func (s *ArmedWakeStore) LoadArmedWake() (*entities.ArmedWake, error) {
+	var dto armedWakeDTO
+	found, err := readJSON(s.filePath, &dto)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	wakeTime, err := time.Parse(time.RFC3339, dto.WakeTime)
+	if err != nil {
+		return nil, err
+	}
+	armedAt, err := time.Parse(time.RFC3339, dto.ArmedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ArmedWake{
+		WakeTime: wakeTime.Local(),
+		ArmedAt:  armedAt.Local(),
+	}, nil
+}
+
+// ClearArmedWake elimina la alarma persistida
+func (s *ArmedWakeStore) ClearArmedWake() error {
+	return removeFile(s.filePath)
+}
\ No newline at end of file
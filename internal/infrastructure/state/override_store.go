@@ -0,0 +1,122 @@
+// internal/infrastructure/state/override_store.go
+package state
+
+import (
+	"fmt"
+	"time"
+
+	appdto "rtc-scheduler/internal/application/dto"
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+// overridesDTO es la estructura para serialización JSON
+type overridesDTO struct {
+	Skip     *skipNextDTO `json:"skip_next,omitempty"`
+	Vacation *vacationDTO `json:"vacation,omitempty"`
+	// OneOffs es la cola de eventos únicos (-wake/-shutdown con fecha)
+	OneOffs []appdto.ScheduleDTO `json:"one_off,omitempty"`
+}
+
+type skipNextDTO struct {
+	WakeAt       time.Time `json:"wake_at"`
+	SkipShutdown bool      `json:"skip_shutdown"`
+	ShutdownAt   time.Time `json:"shutdown_at,omitempty"`
+	By           string    `json:"by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type vacationDTO struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	By        string    `json:"by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OverrideStore implementa OverrideRepository en un archivo JSON
+type OverrideStore struct {
+	filePath string
+}
+
+// Verificar que implementa la interfaz
+var _ repositories.OverrideRepository = (*OverrideStore)(nil)
+
+// NewOverrideStore crea una nueva instancia
+func NewOverrideStore(filePath string) *OverrideStore {
+	return &OverrideStore{
+		filePath: filePath,
+	}
+}
+
+// SaveOverrides persiste las excepciones; sin ninguna, elimina el archivo
+func (s *OverrideStore) SaveOverrides(overrides *entities.ScheduleOverrides) error {
+	if overrides.IsEmpty() {
+		return removeFile(s.filePath)
+	}
+
+	dto := &overridesDTO{}
+	if skip := overrides.Skip; skip != nil {
+		dto.Skip = &skipNextDTO{
+			WakeAt:       skip.WakeAt,
+			SkipShutdown: skip.SkipShutdown,
+			ShutdownAt:   skip.ShutdownAt,
+			By:           skip.By,
+			CreatedAt:    skip.CreatedAt,
+		}
+	}
+	if vacation := overrides.Vacation; vacation != nil {
+		dto.Vacation = &vacationDTO{
+			From:      vacation.From.Format("2006-01-02"),
+			To:        vacation.To.Format("2006-01-02"),
+			By:        vacation.By,
+			CreatedAt: vacation.CreatedAt,
+		}
+	}
+	dto.OneOffs = appdto.FromOneOffs(overrides.OneOffs)
+
+	return writeJSON(s.filePath, dto)
+}
+
+// LoadOverrides retorna las excepciones persistidas (vacías si no hay archivo)
+func (s *OverrideStore) LoadOverrides() (*entities.ScheduleOverrides, error) {
+	var dto overridesDTO
+	if _, err := readJSON(s.filePath, &dto); err != nil {
+		return nil, err
+	}
+
+	overrides := &entities.ScheduleOverrides{}
+	if skip := dto.Skip; skip != nil {
+		overrides.Skip = &entities.SkipNext{
+			WakeAt:       skip.WakeAt.Local(),
+			SkipShutdown: skip.SkipShutdown,
+			ShutdownAt:   skip.ShutdownAt.Local(),
+			By:           skip.By,
+			CreatedAt:    skip.CreatedAt.Local(),
+		}
+	}
+	if vacation := dto.Vacation; vacation != nil {
+		// Las fechas se interpretan en la zona local, igual que al crearlas
+		from, err := time.ParseInLocation("2006-01-02", vacation.From, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		to, err := time.ParseInLocation("2006-01-02", vacation.To, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		overrides.Vacation = &entities.Vacation{
+			From:      from,
+			To:        to,
+			By:        vacation.By,
+			CreatedAt: vacation.CreatedAt.Local(),
+		}
+	}
+
+	oneOffs, err := appdto.ToOneOffs(dto.OneOffs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state file %s: %w", s.filePath, err)
+	}
+	overrides.OneOffs = oneOffs
+
+	return overrides, nil
+}
\ No newline at end of file
@@ -0,0 +1,78 @@
+// internal/infrastructure/state/snooze_store.go
+package state
+
+import (
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+// snoozeDTO es la estructura para serialización JSON
+type snoozeDTO struct {
+	Day              string `json:"day"`
+	ExtendedSeconds  int64  `json:"extended_seconds"`
+	OriginalShutdown string `json:"original_shutdown"`
+	ShutdownAt       string `json:"shutdown_at"`
+	By               string `json:"by"`
+	At               string `json:"at"`
+}
+
+// SnoozeStore implementa SnoozeRepository en un archivo JSON
+type SnoozeStore struct {
+	filePath string
+}
+
+// Verificar que implementa la interfaz
+var _ repositories.SnoozeRepository = (*SnoozeStore)(nil)
+
+// NewSnoozeStore crea una nueva instancia
+func NewSnoozeStore(filePath string) *SnoozeStore {
+	return &SnoozeStore{
+		filePath: filePath,
+	}
+}
+
+// SaveSnooze persiste el estado del snooze
+func (s *SnoozeStore) SaveSnooze(snooze *entities.SnoozeState) error {
+	return writeJSON(s.filePath, &snoozeDTO{
+		Day:              snooze.Day,
+		ExtendedSeconds:  int64(snooze.Extended / time.Second),
+		OriginalShutdown: snooze.OriginalShutdown.Format(time.RFC3339),
+		ShutdownAt:       snooze.ShutdownAt.Format(time.RFC3339),
+		By:               snooze.By,
+		At:               snooze.At.Format(time.RFC3339),
+	})
+}
+
+// LoadSnooze retorna el estado persistido, o nil si nunca se pospuso el apagado
+func (s *SnoozeStore) LoadSnooze() (*entities.SnoozeState, error) {
+	var dto snoozeDTO
+	found, err := readJSON(s.filePath, &dto)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	snooze := &entities.SnoozeState{
+		Day:      dto.Day,
+		Extended: time.Duration(dto.ExtendedSeconds) * time.Second,
+		By:       dto.By,
+	}
+	fields := []struct {
+		value  string
+		target *time.Time
+	}{
+		{dto.OriginalShutdown, &snooze.OriginalShutdown},
+		{dto.ShutdownAt, &snooze.ShutdownAt},
+		{dto.At, &snooze.At},
+	}
+	for _, field := range fields {
+		t, err := time.Parse(time.RFC3339, field.value)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = t.Local()
+	}
+
+	return snooze, nil
+}
\ No newline at end of file
@@ -6,15 +6,27 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"rtc-scheduler/internal/domain/repositories"
 )
 
 const (
-	serviceName     = "rtc-scheduler.service"
-	systemdPath     = "/etc/systemd/system"
-	serviceFileName = serviceName
+	systemdPath = "/etc/systemd/system"
+
+	// StateDir y RuntimeDir son los directorios que systemd crea para el
+	// servicio (StateDirectory= y RuntimeDirectory=); fuera de ellos, con
+	// ProtectSystem=strict, solo se puede escribir en ReadWritePaths
+	StateDir   = "/var/lib/rtc-scheduler"
+	RuntimeDir = "/run/rtc-scheduler"
+
+	// atSpoolPath es donde at guarda los trabajos de apagado
+	atSpoolPath = "/var/spool/cron/atjobs"
+
+	// resumeTargets son los targets que systemd alcanza al volver de cada
+	// modo de suspensión (sleep.target se alcanza antes de suspender)
+	resumeTargets = "suspend.target hibernate.target hybrid-sleep.target suspend-then-hibernate.target"
 )
 
 var (
@@ -22,9 +34,62 @@ var (
 	ErrSystemdNotAvailable = errors.New("systemd is not available")
 )
 
+// Unit describe la unidad systemd que escribe Install
+type Unit struct {
+	Name        string // nombre con sufijo, p. ej. "rtc-scheduler.service"
+	Description string
+	User        string
+	RTCPath     string // directorio sysfs del RTC, escribible por el servicio
+	ConfigPath  string // archivo de configuración, reescrito por el servicio
+	SeedPath    string // semilla de primer arranque (vacío = deshabilitada)
+	LogPath     string // archivo de log (vacío si el log no va a un archivo)
+}
+
+// DefaultUnit retorna la unidad por defecto
+func DefaultUnit() Unit {
+	return Unit{
+		Name:        "rtc-scheduler.service",
+		Description: "RTC Power Schedule Manager",
+		User:        "root",
+		RTCPath:     "/sys/class/rtc/rtc0",
+		ConfigPath:  "/etc/rtc-scheduler.json",
+	}
+}
+
+// WritablePaths retorna los ReadWritePaths del servicio: todo lo que el
+// binario escribe fuera de StateDir y RuntimeDir. Con "-" systemd ignora la
+// ruta si no existe. La configuración se reescribe en el lugar (el rename
+// sobre un archivo montado aparte falla), así que basta el archivo y su
+// copia .prev; la semilla y el log rotado necesitan su directorio.
+func (u Unit) WritablePaths() []string {
+	paths := []string{u.RTCPath, "-" + u.ConfigPath, "-" + u.ConfigPath + ".prev", "-" + atSpoolPath}
+	if u.SeedPath != "" {
+		paths = append(paths, "-"+filepath.Dir(u.SeedPath))
+	}
+	if u.LogPath != "" {
+		paths = append(paths, "-"+filepath.Dir(u.LogPath))
+	}
+	return paths
+}
+
+// ResumeName retorna el nombre de la unidad que corre el servicio al reanudar:
+// "rtc-scheduler-resume.service"
+func (u Unit) ResumeName() string {
+	return strings.TrimSuffix(u.Name, ".service") + "-resume.service"
+}
+
+// ReadyName retorna el nombre de la unidad que mide cuánto tarda el equipo en
+// estar listo tras despertar: "rtc-scheduler-ready.service"
+func (u Unit) ReadyName() string {
+	return strings.TrimSuffix(u.Name, ".service") + "-ready.service"
+}
+
 // SystemdService implementa ServiceRepository usando systemd
 type SystemdService struct {
 	servicePath string
+	resumePath  string
+	readyPath   string
+	unit        Unit
 }
 
 // Verificar que implementa la interfaz
@@ -32,8 +97,16 @@ var _ repositories.ServiceRepository = (*SystemdService)(nil)
 
 // NewSystemdService crea una nueva instancia
 func NewSystemdService() *SystemdService {
+	return NewSystemdServiceWithUnit(DefaultUnit())
+}
+
+// NewSystemdServiceWithUnit crea una instancia para la unidad indicada
+func NewSystemdServiceWithUnit(unit Unit) *SystemdService {
 	return &SystemdService{
-		servicePath: fmt.Sprintf("%s/%s", systemdPath, serviceFileName),
+		servicePath: fmt.Sprintf("%s/%s", systemdPath, unit.Name),
+		resumePath:  fmt.Sprintf("%s/%s", systemdPath, unit.ResumeName()),
+		readyPath:   fmt.Sprintf("%s/%s", systemdPath, unit.ReadyName()),
+		unit:        unit,
 	}
 }
 
@@ -51,6 +124,12 @@ func (s *SystemdService) Install(executablePath string) error {
 	if err := os.WriteFile(s.servicePath, []byte(serviceContent), 0644); err != nil {
 		return fmt.Errorf("failed to write service file: %w", err)
 	}
+	if err := os.WriteFile(s.resumePath, []byte(s.generateResumeContent(executablePath)), 0644); err != nil {
+		return fmt.Errorf("failed to write service file: %w", err)
+	}
+	if err := os.WriteFile(s.readyPath, []byte(s.generateReadyContent(executablePath)), 0644); err != nil {
+		return fmt.Errorf("failed to write service file: %w", err)
+	}
 
 	// Recargar systemd
 	if err := s.daemonReload(); err != nil {
@@ -72,10 +151,15 @@ func (s *SystemdService) Uninstall() error {
 	// Deshabilitar el servicio
 	s.Disable()
 
-	// Eliminar archivo
+	// Eliminar archivos
 	if err := os.Remove(s.servicePath); err != nil {
 		return fmt.Errorf("failed to remove service file: %w", err)
 	}
+	for _, path := range []string{s.resumePath, s.readyPath} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove service file: %w", err)
+		}
+	}
 
 	// Recargar systemd
 	return s.daemonReload()
@@ -86,7 +170,7 @@ func (s *SystemdService) Enable() error {
 	if !s.IsInstalled() {
 		return ErrServiceNotInstalled
 	}
-	return s.runSystemctl("enable", serviceName)
+	return s.runSystemctl(append([]string{"enable"}, s.units()...)...)
 }
 
 // Disable deshabilita el servicio
@@ -94,7 +178,21 @@ func (s *SystemdService) Disable() error {
 	if !s.IsInstalled() {
 		return nil
 	}
-	return s.runSystemctl("disable", serviceName)
+	return s.runSystemctl(append([]string{"disable"}, s.units()...)...)
+}
+
+// units retorna las unidades instaladas: el servicio y, si existen (las
+// instalaciones anteriores no las tienen), la de reanudación y la de
+// medición de "listo"
+func (s *SystemdService) units() []string {
+	units := []string{s.unit.Name}
+	if _, err := os.Stat(s.resumePath); err == nil {
+		units = append(units, s.unit.ResumeName())
+	}
+	if _, err := os.Stat(s.readyPath); err == nil {
+		units = append(units, s.unit.ReadyName())
+	}
+	return units
 }
 
 // Start inicia el servicio
@@ -102,7 +200,7 @@ func (s *SystemdService) Start() error {
 	if !s.IsInstalled() {
 		return ErrServiceNotInstalled
 	}
-	return s.runSystemctl("start", serviceName)
+	return s.runSystemctl("start", s.unit.Name)
 }
 
 // Stop detiene el servicio
@@ -110,7 +208,7 @@ func (s *SystemdService) Stop() error {
 	if !s.IsInstalled() {
 		return nil
 	}
-	return s.runSystemctl("stop", serviceName)
+	return s.runSystemctl("stop", s.unit.Name)
 }
 
 // Restart reinicia el servicio
@@ -118,13 +216,13 @@ func (s *SystemdService) Restart() error {
 	if !s.IsInstalled() {
 		return ErrServiceNotInstalled
 	}
-	return s.runSystemctl("restart", serviceName)
+	return s.runSystemctl("restart", s.unit.Name)
 }
 
 // Status obtiene el estado del servicio
 func (s *SystemdService) Status() (*repositories.ServiceStatus, error) {
 	status := &repositories.ServiceStatus{
-		Name: serviceName,
+		Name: s.unit.Name,
 	}
 
 	if !s.IsInstalled() {
@@ -133,12 +231,12 @@ func (s *SystemdService) Status() (*repositories.ServiceStatus, error) {
 	}
 
 	// Verificar si está corriendo
-	cmd := exec.Command("systemctl", "is-active", serviceName)
+	cmd := exec.Command("systemctl", "is-active", s.unit.Name)
 	output, err := cmd.Output()
 	status.IsRunning = err == nil && strings.TrimSpace(string(output)) == "active"
 
 	// Verificar si está habilitado
-	cmd = exec.Command("systemctl", "is-enabled", serviceName)
+	cmd = exec.Command("systemctl", "is-enabled", s.unit.Name)
 	output, err = cmd.Output()
 	status.IsEnabled = err == nil && strings.TrimSpace(string(output)) == "enabled"
 
@@ -154,36 +252,98 @@ func (s *SystemdService) IsInstalled() bool {
 // generateServiceContent genera el contenido del archivo de servicio
 func (s *SystemdService) generateServiceContent(executablePath string) string {
 	return fmt.Sprintf(`[Unit]
-Description=RTC Power Schedule Manager
+Description=%s
 Documentation=https://github.com/yourusername/rtc-scheduler
-After=network.target time-sync.target sleep.target
+After=network.target time-sync.target
 Wants=atd.service
 # systemd-run is available in most systemd installations, no need for Wants
 
 [Service]
 Type=oneshot
-User=root
+User=%s
 ExecStart=%s -run-service
 RemainAfterExit=yes
 StandardOutput=journal
 StandardError=journal
 Restart=no
+%s
+[Install]
+WantedBy=multi-user.target
+`, s.unit.Description, s.unit.User, executablePath, s.sandbox())
+}
+
+// generateResumeContent genera la unidad que vuelve a correr el servicio tras
+// cada reanudación: el servicio principal es oneshot con RemainAfterExit y
+// sigue "activo" desde el arranque, así que systemd no lo repite
+func (s *SystemdService) generateResumeContent(executablePath string) string {
+	return fmt.Sprintf(`[Unit]
+Description=%s (after resume)
+After=%s
+
+[Service]
+Type=oneshot
+User=%s
+ExecStart=%s -run-service
+StandardOutput=journal
+StandardError=journal
+%s
+[Install]
+WantedBy=%s
+`, s.unit.Description, resumeTargets, s.unit.User, executablePath, s.sandbox(), resumeTargets)
+}
 
+// sandbox retorna las directivas de permisos compartidas por las unidades que
+// corren -run-service
+func (s *SystemdService) sandbox() string {
+	return fmt.Sprintf(`
 # Permisos necesarios para RTC y scheduling
 PrivateTmp=yes
 NoNewPrivileges=no
 ProtectSystem=strict
 ProtectHome=yes
-ReadWritePaths=/sys/class/rtc/rtc0 /etc/rtc-scheduler.json /var/spool/cron/atjobs
+ReadWritePaths=%s
+# Historial de eventos y estado persistente en /var/lib/rtc-scheduler
+StateDirectory=%s
+# Lock de comandos en /run/rtc-scheduler, compartido con la CLI: no se borra al detenerse
+RuntimeDirectory=%s
+RuntimeDirectoryPreserve=yes
 CapabilityBoundingSet=CAP_SYS_ADMIN
 
 # Environment
 Environment=SYSTEMD_LOG_LEVEL=info
 Environment=PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin
+`, strings.Join(s.unit.WritablePaths(), " "), filepath.Base(StateDir), filepath.Base(RuntimeDir))
+}
+
+// generateReadyContent genera la unidad que corre tras cada arranque y cada
+// reanudación para medir cuánto tardó el equipo en estar listo. -mark-ready
+// espera por sí mismo a ready_unit: ordenarla después de multi-user.target,
+// que la arrastra, sería un ciclo, y sin DefaultDependencies=no quedaría
+// ordenada después de basic.target igualmente.
+func (s *SystemdService) generateReadyContent(executablePath string) string {
+	return fmt.Sprintf(`[Unit]
+Description=%s (wake-to-ready measurement)
+DefaultDependencies=no
+After=local-fs.target %s %s %s
+Conflicts=shutdown.target
+Before=shutdown.target
+
+[Service]
+Type=oneshot
+User=%s
+ExecStart=%s -mark-ready
+StandardOutput=journal
+StandardError=journal
+
+PrivateTmp=yes
+ProtectSystem=strict
+ProtectHome=yes
+StateDirectory=%s
 
 [Install]
-WantedBy=multi-user.target sleep.target
-`, executablePath)
+WantedBy=multi-user.target %s
+`, s.unit.Description, s.unit.Name, s.unit.ResumeName(), resumeTargets, s.unit.User, executablePath,
+		filepath.Base(StateDir), resumeTargets)
 }
 
 // runSystemctl ejecuta un comando systemctl
@@ -213,7 +373,7 @@ func (s *SystemdService) GetLogs(lines int) (string, error) {
 		return "", ErrServiceNotInstalled
 	}
 
-	cmd := exec.Command("journalctl", "-u", serviceName, "-n", fmt.Sprintf("%d", lines), "--no-pager")
+	cmd := exec.Command("journalctl", "-u", s.unit.Name, "-n", fmt.Sprintf("%d", lines), "--no-pager")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get logs: %w", err)
@@ -0,0 +1,154 @@
+// internal/infrastructure/systemd/systemd_service_test.go
+package systemd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// unitDirectives retorna los valores de cada directiva del archivo de unidad
+func unitDirectives(content string) map[string][]string {
+	directives := make(map[string][]string)
+	for _, line := range strings.Split(content, "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.HasPrefix(line, "#") {
+			continue
+		}
+		directives[key] = append(directives[key], value)
+	}
+	return directives
+}
+
+func TestServiceUnitWritablePaths(t *testing.T) {
+	unit := DefaultUnit()
+	unit.SeedPath = "/boot/firmware/rtc-scheduler.json"
+	unit.LogPath = "/var/log/rtc-scheduler/rtc-scheduler.log"
+	service := NewSystemdServiceWithUnit(unit)
+
+	for name, content := range map[string]string{
+		unit.Name:         service.generateServiceContent("/usr/local/bin/rtc-scheduler"),
+		unit.ResumeName(): service.generateResumeContent("/usr/local/bin/rtc-scheduler"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			checkWritablePaths(t, unit, unitDirectives(content))
+		})
+	}
+}
+
+// checkWritablePaths verifica que la unidad deja escribir todo lo que escribe
+// el binario al correr -run-service
+func checkWritablePaths(t *testing.T, unit Unit, directives map[string][]string) {
+	t.Helper()
+	if got := directives["ProtectSystem"]; len(got) != 1 || got[0] != "strict" {
+		t.Fatalf("ProtectSystem = %v, the test assumes strict", got)
+	}
+
+	writable := make(map[string]bool)
+	for _, value := range directives["ReadWritePaths"] {
+		for _, path := range strings.Fields(value) {
+			writable[strings.TrimPrefix(path, "-")] = true
+		}
+	}
+	for _, dir := range directives["StateDirectory"] {
+		writable[filepath.Join("/var/lib", dir)] = true
+	}
+	for _, dir := range directives["RuntimeDirectory"] {
+		writable[filepath.Join("/run", dir)] = true
+	}
+
+	// Todo lo que escribe el binario desde el servicio
+	for _, path := range []string{
+		StateDir,                    // eventos, estado, historial de configuración, cola de webhooks
+		RuntimeDir,                  // lock de comandos
+		unit.RTCPath,                // alarma RTC
+		unit.ConfigPath,             // cambio de perfil, migración, semilla
+		unit.ConfigPath + ".prev",   // copia de la configuración anterior
+		atSpoolPath,                 // trabajos de apagado
+		filepath.Dir(unit.SeedPath), // semilla renombrada o borrada
+		filepath.Dir(unit.LogPath),  // log y sus rotaciones
+	} {
+		if !writable[path] {
+			t.Errorf("%s is not writable by the unit (writable: %v)", path, writable)
+		}
+	}
+
+	if got := directives["RuntimeDirectoryPreserve"]; len(got) != 1 || got[0] != "yes" {
+		t.Errorf("RuntimeDirectoryPreserve = %v, the CLI shares the lock in %s", got, RuntimeDir)
+	}
+}
+
+func TestResumeUnitRunsServiceAfterResume(t *testing.T) {
+	unit := DefaultUnit()
+	service := NewSystemdServiceWithUnit(unit)
+
+	if got := unit.ResumeName(); got != "rtc-scheduler-resume.service" {
+		t.Errorf("ResumeName() = %q", got)
+	}
+
+	directives := unitDirectives(service.generateResumeContent("/usr/local/bin/rtc-scheduler"))
+	if got := directives["ExecStart"]; len(got) != 1 || got[0] != "/usr/local/bin/rtc-scheduler -run-service" {
+		t.Errorf("ExecStart = %v, want -run-service", got)
+	}
+	if got := directives["RemainAfterExit"]; len(got) != 0 {
+		t.Errorf("RemainAfterExit = %v, the unit must run again on every resume", got)
+	}
+	// Ordenada después del target de cada modo, que systemd alcanza al volver
+	for _, key := range []string{"After", "WantedBy"} {
+		targets := strings.Fields(strings.Join(directives[key], " "))
+		for _, want := range []string{"suspend.target", "hibernate.target", "hybrid-sleep.target", "suspend-then-hibernate.target"} {
+			if !contains(targets, want) {
+				t.Errorf("%s = %v, missing %s", key, targets, want)
+			}
+		}
+		if contains(targets, "sleep.target") {
+			t.Errorf("%s = %v, sleep.target is reached before suspending", key, targets)
+		}
+	}
+
+	// El servicio principal solo corre al arrancar
+	main := unitDirectives(service.generateServiceContent("/usr/local/bin/rtc-scheduler"))
+	if got := main["WantedBy"]; len(got) != 1 || got[0] != "multi-user.target" {
+		t.Errorf("service WantedBy = %v, want multi-user.target", got)
+	}
+}
+
+func contains(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReadyUnitHasNoOrderingCycle(t *testing.T) {
+	unit := DefaultUnit()
+	service := NewSystemdServiceWithUnit(unit)
+	directives := unitDirectives(service.generateReadyContent("/usr/local/bin/rtc-scheduler"))
+
+	if got := directives["DefaultDependencies"]; len(got) != 1 || got[0] != "no" {
+		t.Errorf("DefaultDependencies = %v, want no", got)
+	}
+
+	after := strings.Fields(strings.Join(directives["After"], " "))
+	wantedBy := strings.Fields(strings.Join(directives["WantedBy"], " "))
+	// multi-user.target la arrastra en el arranque: ordenarla después es un ciclo
+	if !contains(wantedBy, "multi-user.target") {
+		t.Errorf("WantedBy = %v, missing multi-user.target for boots", wantedBy)
+	}
+	if contains(after, "multi-user.target") {
+		t.Errorf("After = %v, ordering cycle with multi-user.target", after)
+	}
+	// Al reanudar, después de que systemd vuelve de la suspensión
+	for _, want := range []string{"suspend.target", "hibernate.target", unit.ResumeName()} {
+		if !contains(after, want) {
+			t.Errorf("After = %v, missing %s", after, want)
+		}
+	}
+	for _, key := range []string{"After", "WantedBy"} {
+		if contains(strings.Fields(strings.Join(directives[key], " ")), "sleep.target") {
+			t.Errorf("%s includes sleep.target, which is reached before suspending", key)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,32 @@
+// internal/infrastructure/systemd/unit_state.go
+package systemd
+
+import (
+	"os/exec"
+	"strings"
+
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+// UnitState implementa UnitStateRepository consultando systemctl
+type UnitState struct{}
+
+// Verificar que implementa la interfaz
+var _ repositories.UnitStateRepository = (*UnitState)(nil)
+
+// NewUnitState crea una nueva instancia
+func NewUnitState() *UnitState {
+	return &UnitState{}
+}
+
+// IsActive indica si la unidad está activa. systemctl is-active sale con
+// código distinto de cero para cualquier otro estado, así que el error solo
+// se informa si no hubo respuesta.
+func (u *UnitState) IsActive(unit string) (bool, error) {
+	output, err := exec.Command("systemctl", "is-active", unit).Output()
+	state := strings.TrimSpace(string(output))
+	if err != nil && state == "" {
+		return false, err
+	}
+	return state == "active", nil
+}
\ No newline at end of file
@@ -0,0 +1,155 @@
+// internal/infrastructure/ups/nut_client.go
+package ups
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+var (
+	ErrNUTProtocol     = errors.New("unexpected response from upsd")
+	ErrVarNotSupported = errors.New("variable not supported by the UPS")
+)
+
+// nutTimeout limita la conexión y cada intercambio con upsd
+const nutTimeout = 5 * time.Second
+
+// NUTClient implementa UPSRepository hablando el protocolo de texto de upsd
+// (RFC 9271). Cada consulta abre una conexión nueva: el intervalo de sondeo es
+// largo y así un reinicio de upsd no deja una conexión rota.
+type NUTClient struct{}
+
+// Verificar que implementa la interfaz
+var _ repositories.UPSRepository = (*NUTClient)(nil)
+
+// NewNUTClient crea una nueva instancia
+func NewNUTClient() *NUTClient {
+	return &NUTClient{}
+}
+
+// Status consulta ups.status y battery.charge. La carga es opcional: si la UPS
+// no la informa, Charge vale -1.
+func (c *NUTClient) Status(ups *entities.UPSConfig) (*entities.UPSStatus, error) {
+	conn, err := net.DialTimeout("tcp", ups.ServerAddress(), nutTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to upsd at %s: %w", ups.ServerAddress(), err)
+	}
+	defer conn.Close()
+
+	session := &nutSession{conn: conn, reader: bufio.NewReader(conn)}
+	defer session.logout()
+
+	if ups.Username != "" {
+		if err := session.expectOK("USERNAME " + quoteNUT(ups.Username)); err != nil {
+			return nil, fmt.Errorf("upsd login failed: %w", err)
+		}
+		if err := session.expectOK("PASSWORD " + quoteNUT(ups.Password)); err != nil {
+			return nil, fmt.Errorf("upsd login failed: %w", err)
+		}
+	}
+
+	flags, err := session.getVar(ups.Name, "ups.status")
+	if err != nil {
+		return nil, err
+	}
+
+	status := &entities.UPSStatus{Flags: entities.ParseUPSFlags(flags), Charge: -1}
+
+	charge, err := session.getVar(ups.Name, "battery.charge")
+	switch {
+	case errors.Is(err, ErrVarNotSupported):
+	case err != nil:
+		return nil, err
+	default:
+		value, err := strconv.ParseFloat(charge, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: battery.charge %q", ErrNUTProtocol, charge)
+		}
+		status.Charge = value
+	}
+
+	return status, nil
+}
+
+// nutSession es una conexión abierta con upsd
+type nutSession struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// command envía una línea y retorna la respuesta sin el salto de línea
+func (s *nutSession) command(line string) (string, error) {
+	s.conn.SetDeadline(time.Now().Add(nutTimeout))
+
+	if _, err := fmt.Fprintf(s.conn, "%s\n", line); err != nil {
+		return "", err
+	}
+
+	response, err := s.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	response = strings.TrimRight(response, "\r\n")
+
+	if strings.HasPrefix(response, "ERR ") {
+		code := strings.TrimPrefix(response, "ERR ")
+		if code == "VAR-NOT-SUPPORTED" {
+			return "", ErrVarNotSupported
+		}
+		return "", fmt.Errorf("upsd error: %s", code)
+	}
+
+	return response, nil
+}
+
+// expectOK envía un comando que debe responder "OK"
+func (s *nutSession) expectOK(line string) error {
+	response, err := s.command(line)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(response, "OK") {
+		return fmt.Errorf("%w: %q", ErrNUTProtocol, response)
+	}
+	return nil
+}
+
+// getVar ejecuta GET VAR y retorna el valor de la respuesta
+// `VAR <ups> <var> "<valor>"`
+func (s *nutSession) getVar(upsName, name string) (string, error) {
+	response, err := s.command(fmt.Sprintf("GET VAR %s %s", upsName, name))
+	if err != nil {
+		return "", fmt.Errorf("GET VAR %s: %w", name, err)
+	}
+
+	prefix := fmt.Sprintf("VAR %s %s ", upsName, name)
+	if !strings.HasPrefix(response, prefix) {
+		return "", fmt.Errorf("%w: %q", ErrNUTProtocol, response)
+	}
+
+	value, err := strconv.Unquote(strings.TrimPrefix(response, prefix))
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrNUTProtocol, response)
+	}
+	return value, nil
+}
+
+// logout cierra la sesión de forma ordenada; el error no importa
+func (s *nutSession) logout() {
+	s.command("LOGOUT")
+}
+
+// quoteNUT cita un argumento con comillas dobles, escapando \ y "
+func quoteNUT(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
\ No newline at end of file
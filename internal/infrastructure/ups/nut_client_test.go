@@ -0,0 +1,139 @@
+// internal/infrastructure/ups/nut_client_test.go
+package ups
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+// fakeUPSD es un upsd mínimo que responde GET VAR desde un mapa
+type fakeUPSD struct {
+	listener net.Listener
+	vars     map[string]string
+	password string
+
+	mu       sync.Mutex
+	commands []string
+}
+
+func newFakeUPSD(t *testing.T, vars map[string]string, password string) *fakeUPSD {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeUPSD{listener: listener, vars: vars, password: password}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeUPSD) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeUPSD) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimSpace(line)
+
+		s.mu.Lock()
+		s.commands = append(s.commands, line)
+		s.mu.Unlock()
+
+		fields := strings.Fields(line)
+		switch {
+		case fields[0] == "USERNAME":
+			fmt.Fprintln(conn, "OK")
+		case fields[0] == "PASSWORD":
+			if line == fmt.Sprintf("PASSWORD %q", s.password) {
+				fmt.Fprintln(conn, "OK")
+			} else {
+				fmt.Fprintln(conn, "ERR ACCESS-DENIED")
+			}
+		case fields[0] == "LOGOUT":
+			fmt.Fprintln(conn, "OK Goodbye")
+			return
+		case len(fields) == 4 && fields[0] == "GET" && fields[1] == "VAR":
+			if fields[2] != "ups" {
+				fmt.Fprintln(conn, "ERR UNKNOWN-UPS")
+			} else if value, ok := s.vars[fields[3]]; ok {
+				fmt.Fprintf(conn, "VAR %s %s %q\n", fields[2], fields[3], value)
+			} else {
+				fmt.Fprintln(conn, "ERR VAR-NOT-SUPPORTED")
+			}
+		default:
+			fmt.Fprintln(conn, "ERR UNKNOWN-COMMAND")
+		}
+	}
+}
+
+func (s *fakeUPSD) received(prefix string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, command := range s.commands {
+		if strings.HasPrefix(command, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNUTClientStatus(t *testing.T) {
+	server := newFakeUPSD(t, map[string]string{"ups.status": "OB DISCHRG", "battery.charge": "42"}, "s3cret")
+
+	config := &entities.UPSConfig{Name: "ups", Address: server.listener.Addr().String(), Username: "monitor", Password: "s3cret"}
+	status, err := NewNUTClient().Status(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !status.OnBattery() || status.LowBattery() || status.Charge != 42 {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if !server.received(`USERNAME "monitor"`) || !server.received("LOGOUT") {
+		t.Error("expected login and logout")
+	}
+}
+
+func TestNUTClientChargeNotSupported(t *testing.T) {
+	server := newFakeUPSD(t, map[string]string{"ups.status": "OL"}, "")
+
+	status, err := NewNUTClient().Status(&entities.UPSConfig{Name: "ups", Address: server.listener.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.OnBattery() || status.HasCharge() {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestNUTClientErrors(t *testing.T) {
+	server := newFakeUPSD(t, map[string]string{"ups.status": "OL"}, "right")
+	address := server.listener.Addr().String()
+
+	if _, err := NewNUTClient().Status(&entities.UPSConfig{Name: "other", Address: address}); err == nil || !strings.Contains(err.Error(), "UNKNOWN-UPS") {
+		t.Errorf("expected UNKNOWN-UPS error, got %v", err)
+	}
+
+	if _, err := NewNUTClient().Status(&entities.UPSConfig{Name: "ups", Address: address, Username: "u", Password: "wrong"}); err == nil {
+		t.Error("expected login error")
+	}
+}
\ No newline at end of file
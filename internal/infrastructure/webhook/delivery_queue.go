@@ -0,0 +1,180 @@
+// internal/infrastructure/webhook/delivery_queue.go
+package webhook
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// DefaultMaxQueued es la cantidad máxima de entregas pendientes conservadas
+const DefaultMaxQueued = 1000
+
+// Delivery es una entrega ya renderizada (cuerpo y firma) pendiente de envío
+type Delivery struct {
+	ID        string    `json:"id,omitempty"`
+	URL       string    `json:"url"`
+	Event     string    `json:"event"`
+	Body      string    `json:"body"`
+	Signature string    `json:"signature,omitempty"`
+	Attempts  int       `json:"attempts"`
+	QueuedAt  time.Time `json:"queued_at"`
+	// NextAttempt es cuándo vence el reintento tras un fallo (cero = ya)
+	NextAttempt time.Time `json:"next_attempt,omitempty"`
+}
+
+// key identifica la entrega en la cola; las encoladas por versiones
+// anteriores no tienen ID
+func (d *Delivery) key() string {
+	if d.ID != "" {
+		return d.ID
+	}
+	return d.URL + "|" + d.Event + "|" + d.QueuedAt.Format(time.RFC3339Nano)
+}
+
+// newDeliveryID genera un identificador aleatorio para una entrega
+func newDeliveryID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// DeliveryQueue persiste en un archivo JSONL las entregas pendientes. Cada
+// entrega se encola antes de enviarla y sale de la cola cuando se entrega,
+// así que un corte o un fallo de red no la pierde. Varios procesos (servicio,
+// apagado, MQTT) comparten el archivo: las modificaciones toman un flock.
+type DeliveryQueue struct {
+	filePath   string
+	maxEntries int
+}
+
+// NewDeliveryQueue crea una nueva instancia
+func NewDeliveryQueue(filePath string) *DeliveryQueue {
+	return &DeliveryQueue{
+		filePath:   filePath,
+		maxEntries: DefaultMaxQueued,
+	}
+}
+
+// Load retorna las entregas pendientes en orden de llegada
+func (q *DeliveryQueue) Load() ([]*Delivery, error) {
+	file, err := os.Open(q.filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open webhook queue: %w", err)
+	}
+	defer file.Close()
+
+	var deliveries []*Delivery
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var d Delivery
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			continue // Línea corrupta: se descarta
+		}
+		deliveries = append(deliveries, &d)
+	}
+
+	return deliveries, scanner.Err()
+}
+
+// Push agrega entregas al final de la cola
+func (q *DeliveryQueue) Push(deliveries ...*Delivery) error {
+	return q.Update(func(pending []*Delivery) []*Delivery {
+		return append(pending, deliveries...)
+	})
+}
+
+// Update reemplaza la cola por lo que retorne fn, sin que otro proceso la
+// modifique entre la lectura y la escritura
+func (q *DeliveryQueue) Update(fn func(pending []*Delivery) []*Delivery) error {
+	if err := os.MkdirAll(filepath.Dir(q.filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create webhook queue directory: %w", err)
+	}
+
+	lock, err := os.OpenFile(q.filePath+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to lock webhook queue: %w", err)
+	}
+	defer lock.Close()
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock webhook queue: %w", err)
+	}
+	defer syscall.Flock(int(lock.Fd()), syscall.LOCK_UN)
+
+	pending, err := q.Load()
+	if err != nil {
+		return err
+	}
+	return q.Save(fn(pending))
+}
+
+// Save reemplaza el contenido de la cola. Si se supera el máximo se
+// descartan las entregas más antiguas; una cola vacía elimina el archivo.
+func (q *DeliveryQueue) Save(deliveries []*Delivery) error {
+	if len(deliveries) == 0 {
+		if err := os.Remove(q.filePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove webhook queue: %w", err)
+		}
+		return nil
+	}
+
+	if len(deliveries) > q.maxEntries {
+		deliveries = deliveries[len(deliveries)-q.maxEntries:]
+	}
+
+	if err := os.MkdirAll(filepath.Dir(q.filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create webhook queue directory: %w", err)
+	}
+
+	// El archivo contiene firmas: solo legible por root (CreateTemp usa 0600)
+	file, err := os.CreateTemp(filepath.Dir(q.filePath), "."+filepath.Base(q.filePath)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write webhook queue: %w", err)
+	}
+	tmpPath := file.Name()
+
+	writer := bufio.NewWriter(file)
+	for _, d := range deliveries {
+		line, err := json.Marshal(d)
+		if err != nil {
+			file.Close()
+			os.Remove(tmpPath)
+			return err
+		}
+		writer.Write(append(line, '\n'))
+	}
+
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write webhook queue: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write webhook queue: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, q.filePath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace webhook queue: %w", err)
+	}
+
+	return nil
+}
+
+// GetFilePath retorna la ruta del archivo de la cola
+func (q *DeliveryQueue) GetFilePath() string {
+	return q.filePath
+}
\ No newline at end of file
@@ -0,0 +1,94 @@
+// internal/infrastructure/webhook/payload.go
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+// SignatureHeader contiene "sha256=<hex>" con el HMAC del cuerpo
+const SignatureHeader = "X-RTC-Signature"
+
+// EventHeader contiene el tipo de evento
+const EventHeader = "X-RTC-Event"
+
+// Payload son los datos disponibles en las plantillas y el cuerpo JSON por defecto
+type Payload struct {
+	Event     string            `json:"event"`
+	Level     string            `json:"level"`
+	Timestamp time.Time         `json:"timestamp"`
+	Message   string            `json:"message"`
+	Host      string            `json:"host"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+// templateFuncs son las funciones extra de las plantillas; "json" escapa un valor
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// newPayload construye los datos del evento
+func newPayload(event *entities.PowerEvent, host string) *Payload {
+	return &Payload{
+		Event:     string(event.Type),
+		Level:     string(event.Level),
+		Timestamp: event.Timestamp,
+		Message:   event.Message,
+		Host:      host,
+		Fields:    event.Fields,
+	}
+}
+
+// renderBody genera el cuerpo de la petición con la plantilla del webhook o en JSON
+func renderBody(hook *entities.WebhookConfig, payload *Payload) (string, error) {
+	if hook.Template == "" {
+		data, err := json.Marshal(payload)
+		return string(data), err
+	}
+
+	tmpl, err := template.New("webhook").Funcs(templateFuncs).Parse(hook.Template)
+	if err != nil {
+		return "", fmt.Errorf("invalid webhook template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, payload); err != nil {
+		return "", fmt.Errorf("failed to render webhook template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// sign calcula la firma HMAC-SHA256 del cuerpo
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// resolveSecret retorna el secreto; con el prefijo "file:" lo lee desde un archivo,
+// lo que evita guardarlo en el archivo de configuración (legible por todos)
+func resolveSecret(secret string) (string, error) {
+	path := strings.TrimPrefix(secret, "file:")
+	if path == secret {
+		return secret, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read webhook secret: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
\ No newline at end of file
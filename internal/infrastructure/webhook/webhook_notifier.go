@@ -0,0 +1,268 @@
+// internal/infrastructure/webhook/webhook_notifier.go
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+const (
+	// DefaultTimeout limita cada petición HTTP. El envío ocurre en el camino
+	// del evento (servicio, apagado), así que es corto y no hay reintentos:
+	// lo que falle queda en la cola para el próximo envío.
+	DefaultTimeout = 3 * time.Second
+	// DefaultRetryBackoff es la espera tras el primer fallo de una entrega; se
+	// duplica con cada intento fallido hasta MaxRetryBackoff
+	DefaultRetryBackoff = 30 * time.Second
+	MaxRetryBackoff     = time.Hour
+	// configTTL es cuánto se reutilizan los webhooks leídos de la configuración
+	configTTL = time.Minute
+)
+
+// errPermanent marca respuestas que no tiene sentido reintentar (4xx)
+var errPermanent = errors.New("webhook rejected the delivery")
+
+// WebhookNotifier implementa NotifierRepository enviando eventos por HTTP POST
+// a los webhooks configurados. Cada entrega se guarda en disco antes de
+// enviarla y se intenta una sola vez por envío de la cola; las que fallan se
+// reintentan con backoff exponencial en los envíos siguientes (cada
+// notificación y cada ejecución del servicio, p.ej. al reanudar). La entrega
+// es "al menos una vez": dos procesos que envían la cola a la vez pueden
+// repetir una entrega.
+type WebhookNotifier struct {
+	configRepo repositories.ConfigRepository
+	queue      *DeliveryQueue
+	client     *http.Client
+	hostname   string
+
+	mu       sync.Mutex
+	config   *entities.Config
+	loadedAt time.Time
+}
+
+// Verificar que implementa la interfaz
+var _ repositories.NotifierRepository = (*WebhookNotifier)(nil)
+
+// NewWebhookNotifier crea una nueva instancia. Los webhooks se leen de la
+// configuración y se reutilizan durante configTTL, para reflejar cambios sin
+// reiniciar sin releerla en cada evento.
+func NewWebhookNotifier(configRepo repositories.ConfigRepository, queuePath string) *WebhookNotifier {
+	hostname, _ := os.Hostname()
+
+	return &WebhookNotifier{
+		configRepo: configRepo,
+		queue:      NewDeliveryQueue(queuePath),
+		client:     &http.Client{Timeout: DefaultTimeout},
+		hostname:   hostname,
+	}
+}
+
+// Notify encola el evento para los webhooks interesados y envía la cola,
+// las entregas pendientes primero para conservar el orden
+func (n *WebhookNotifier) Notify(event *entities.PowerEvent) error {
+	config, err := n.loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load webhook configuration: %w", err)
+	}
+	if config == nil {
+		return nil
+	}
+
+	var deliveries []*Delivery
+	var errs []error
+	payload := newPayload(event, n.hostname)
+	for i := range config.Webhooks {
+		hook := &config.Webhooks[i]
+		if !hook.Matches(event) {
+			continue
+		}
+
+		delivery, err := n.prepare(hook, payload)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", hook.URL, err))
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if len(deliveries) > 0 {
+		if err := n.queue.Push(deliveries...); err != nil {
+			// Sin cola (disco de solo lectura) se envían igual, una vez
+			errs = append(errs, err)
+			for _, delivery := range deliveries {
+				if err := n.send(delivery); err != nil {
+					errs = append(errs, fmt.Errorf("webhook %s: %w", delivery.URL, err))
+				}
+			}
+			return errors.Join(errs...)
+		}
+	}
+
+	if err := n.Flush(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Flush intenta una vez las entregas encoladas cuyo reintento ya venció. El
+// orden se conserva por URL: tras un fallo transitorio, o ante una entrega
+// que todavía espera su reintento, las siguientes a esa URL quedan para la
+// próxima vez; las demás URLs siguen. Las rechazadas (4xx) se descartan.
+func (n *WebhookNotifier) Flush() error {
+	pending, err := n.queue.Load()
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+
+	var errs []error
+	now := time.Now()
+	done := make(map[string]bool)
+	failed := make(map[string]bool)
+	blocked := make(map[string]bool)
+	for _, delivery := range pending {
+		if blocked[delivery.URL] {
+			continue
+		}
+		if delivery.NextAttempt.After(now) {
+			blocked[delivery.URL] = true
+			continue
+		}
+
+		err := n.send(delivery)
+		if err == nil {
+			done[delivery.key()] = true
+			continue
+		}
+		if errors.Is(err, errPermanent) {
+			done[delivery.key()] = true
+			errs = append(errs, fmt.Errorf("webhook %s: %w", delivery.URL, err))
+			continue
+		}
+
+		failed[delivery.key()] = true
+		blocked[delivery.URL] = true
+		errs = append(errs, fmt.Errorf("webhook %s: delivery failed, queued for retry: %w", delivery.URL, err))
+	}
+
+	// Otro proceso pudo encolar entretanto: quitar solo lo entregado
+	err = n.queue.Update(func(current []*Delivery) []*Delivery {
+		var remaining []*Delivery
+		for _, delivery := range current {
+			if done[delivery.key()] {
+				continue
+			}
+			if failed[delivery.key()] {
+				delivery.Attempts++
+				delivery.NextAttempt = now.Add(retryBackoff(delivery.Attempts))
+			}
+			remaining = append(remaining, delivery)
+		}
+		return remaining
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// retryBackoff retorna la espera antes del próximo intento de una entrega que
+// ya falló attempts veces
+func retryBackoff(attempts int) time.Duration {
+	wait := DefaultRetryBackoff
+	for i := 1; i < attempts && wait < MaxRetryBackoff; i++ {
+		wait *= 2
+	}
+	if wait > MaxRetryBackoff {
+		wait = MaxRetryBackoff
+	}
+	return wait
+}
+
+// loadConfig retorna la configuración, releída como mucho cada configTTL;
+// nil si no hay configuración
+func (n *WebhookNotifier) loadConfig() (*entities.Config, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.config != nil && time.Since(n.loadedAt) < configTTL {
+		return n.config, nil
+	}
+	if !n.configRepo.Exists() {
+		return nil, nil
+	}
+
+	config, err := n.configRepo.Load()
+	if err != nil {
+		return nil, err
+	}
+	n.config, n.loadedAt = config, time.Now()
+	return config, nil
+}
+
+// prepare renderiza el cuerpo y la firma de una entrega
+func (n *WebhookNotifier) prepare(hook *entities.WebhookConfig, payload *Payload) (*Delivery, error) {
+	body, err := renderBody(hook, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &Delivery{
+		ID:       newDeliveryID(),
+		URL:      hook.URL,
+		Event:    payload.Event,
+		Body:     body,
+		QueuedAt: time.Now(),
+	}
+
+	if hook.Secret != "" {
+		secret, err := resolveSecret(hook.Secret)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Signature = sign(secret, body)
+	}
+
+	return delivery, nil
+}
+
+// send realiza una petición POST con el cuerpo ya renderizado
+func (n *WebhookNotifier) send(delivery *Delivery) error {
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, strings.NewReader(delivery.Body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errPermanent, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "rtc-scheduler")
+	req.Header.Set(EventHeader, delivery.Event)
+	if delivery.Signature != "" {
+		req.Header.Set(SignatureHeader, delivery.Signature)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return fmt.Errorf("%w: %s", errPermanent, resp.Status)
+	default:
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+}
\ No newline at end of file
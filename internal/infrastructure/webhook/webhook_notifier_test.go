@@ -0,0 +1,280 @@
+// internal/infrastructure/webhook/webhook_notifier_test.go
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+// memoryConfigRepository es un ConfigRepository en memoria para pruebas
+type memoryConfigRepository struct {
+	config *entities.Config
+}
+
+func (r *memoryConfigRepository) Load() (*entities.Config, error) { return r.config, nil }
+func (r *memoryConfigRepository) Save(c *entities.Config) error   { r.config = c; return nil }
+func (r *memoryConfigRepository) Delete() error                   { r.config = nil; return nil }
+func (r *memoryConfigRepository) Exists() bool                    { return r.config != nil }
+func (r *memoryConfigRepository) CreateDefault() error            { return nil }
+
+// recorder guarda las peticiones recibidas y responde con los códigos indicados
+type recorder struct {
+	mu       sync.Mutex
+	bodies   []string
+	headers  []http.Header
+	statuses []int
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	rec.bodies = append(rec.bodies, string(body))
+	rec.headers = append(rec.headers, r.Header.Clone())
+
+	status := http.StatusOK
+	if len(rec.statuses) > 0 {
+		status = rec.statuses[0]
+		rec.statuses = rec.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestNotifier(t *testing.T, hooks ...entities.WebhookConfig) *WebhookNotifier {
+	t.Helper()
+	config, _ := entities.NewConfig("08:00", "22:00", true)
+	config.Webhooks = hooks
+
+	n := NewWebhookNotifier(&memoryConfigRepository{config: config}, filepath.Join(t.TempDir(), "webhook-queue.jsonl"))
+	n.hostname = "test-host"
+	return n
+}
+
+func TestNotifySendsSignedDefaultPayload(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	n := newTestNotifier(t, entities.WebhookConfig{URL: server.URL, Secret: "s3cret"})
+	event := entities.NewPowerEvent(entities.EventAlarmArmed, "RTC wake alarm armed", map[string]string{"wake_time": "08:00"})
+
+	if err := n.Notify(event); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rec.bodies) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(rec.bodies))
+	}
+
+	var payload Payload
+	if err := json.Unmarshal([]byte(rec.bodies[0]), &payload); err != nil {
+		t.Fatalf("invalid JSON body %q: %v", rec.bodies[0], err)
+	}
+	if payload.Event != "armed" || payload.Host != "test-host" || payload.Fields["wake_time"] != "08:00" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	if got, want := rec.headers[0].Get(SignatureHeader), sign("s3cret", rec.bodies[0]); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+	if got := rec.headers[0].Get(EventHeader); got != "armed" {
+		t.Errorf("event header = %q", got)
+	}
+}
+
+func TestNotifyFiltersEventsAndRendersTemplate(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	n := newTestNotifier(t, entities.WebhookConfig{
+		URL:      server.URL,
+		Events:   []string{"wake_missed", "error"},
+		Template: `{"text": {{json .Message}}, "host": "{{.Host}}"}`,
+	})
+
+	n.Notify(entities.NewPowerEvent(entities.EventAlarmArmed, "ignored", nil))
+
+	failure := entities.NewPowerEvent(entities.EventError, `Service "run" failed`, nil)
+	failure.Level = entities.EventLevelError
+	if err := n.Notify(failure); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rec.bodies) != 1 {
+		t.Fatalf("expected only the error event to be sent, got %d requests", len(rec.bodies))
+	}
+	if want := `{"text": "Service \"run\" failed", "host": "test-host"}`; rec.bodies[0] != want {
+		t.Errorf("body = %s, want %s", rec.bodies[0], want)
+	}
+}
+
+func TestNotifyRetriesWithBackoff(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusBadGateway}}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	n := newTestNotifier(t, entities.WebhookConfig{URL: server.URL})
+	started := time.Now()
+	err := n.Notify(entities.NewPowerEvent(entities.EventShutdownImminent, "System suspend imminent", nil))
+	if err == nil || !strings.Contains(err.Error(), "queued for retry") {
+		t.Fatalf("expected queued error, got %v", err)
+	}
+	if len(rec.bodies) != 1 {
+		t.Fatalf("expected a single attempt in the event path, got %d", len(rec.bodies))
+	}
+
+	pending, _ := n.queue.Load()
+	if len(pending) != 1 || pending[0].Attempts != 1 || pending[0].NextAttempt.Before(started.Add(DefaultRetryBackoff)) {
+		t.Fatalf("expected the delivery queued with a backoff, got %+v", pending)
+	}
+
+	// Antes del reintento no se envía nada a esa URL, ni siquiera lo nuevo
+	if err := n.Notify(entities.NewPowerEvent(entities.EventWakeDetected, "Wake detected", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.bodies) != 1 {
+		t.Fatalf("expected no request before the retry is due, got %d", len(rec.bodies))
+	}
+
+	// Vence el reintento: primero la entrega fallida, después la nueva
+	n.queue.Update(func(pending []*Delivery) []*Delivery {
+		pending[0].NextAttempt = time.Now().Add(-time.Second)
+		return pending
+	})
+	if err := n.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.bodies) != 3 || !strings.Contains(rec.bodies[1], "shutdown_imminent") || !strings.Contains(rec.bodies[2], "wake_detected") {
+		t.Errorf("expected the failed delivery retried first, then the new one: %q", rec.bodies)
+	}
+	if pending, _ := n.queue.Load(); len(pending) != 0 {
+		t.Errorf("expected empty queue, got %d entries", len(pending))
+	}
+}
+
+func TestRetryBackoffDoublesUpToLimit(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{
+		1:  DefaultRetryBackoff,
+		2:  2 * DefaultRetryBackoff,
+		4:  8 * DefaultRetryBackoff,
+		20: MaxRetryBackoff,
+	} {
+		if got := retryBackoff(attempts); got != want {
+			t.Errorf("retryBackoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+func TestFlushKeepsDeliveringToOtherURLs(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+	dead := httptest.NewServer(rec)
+	deadURL := dead.URL
+	dead.Close()
+
+	n := newTestNotifier(t, entities.WebhookConfig{URL: deadURL}, entities.WebhookConfig{URL: server.URL})
+	for _, message := range []string{"first", "second"} {
+		n.Notify(entities.NewPowerEvent(entities.EventAlarmArmed, message, nil))
+	}
+
+	if len(rec.bodies) != 2 || !strings.Contains(rec.bodies[0], "first") || !strings.Contains(rec.bodies[1], "second") {
+		t.Errorf("expected the live webhook to get both events in order: %q", rec.bodies)
+	}
+	pending, _ := n.queue.Load()
+	if len(pending) != 2 || pending[0].URL != deadURL || pending[1].URL != deadURL {
+		t.Fatalf("expected both deliveries to the dead webhook queued, got %+v", pending)
+	}
+	// El segundo evento no se intentó: espera detrás del primero
+	if pending[0].Attempts != 1 || pending[1].Attempts != 0 {
+		t.Errorf("attempts = %d, %d, want 1, 0", pending[0].Attempts, pending[1].Attempts)
+	}
+}
+
+func TestNotifyQueuesBeforeSending(t *testing.T) {
+	var n *WebhookNotifier
+	var queued []*Delivery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queued, _ = n.queue.Load()
+	}))
+	defer server.Close()
+
+	n = newTestNotifier(t, entities.WebhookConfig{URL: server.URL})
+	if err := n.Notify(entities.NewPowerEvent(entities.EventShutdownExecuted, "Executing system suspend", nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queued) != 1 || queued[0].Event != "shutdown_executed" || queued[0].ID == "" {
+		t.Errorf("delivery should be on disk while it is sent, queue had %+v", queued)
+	}
+	if pending, _ := n.queue.Load(); len(pending) != 0 {
+		t.Errorf("expected empty queue after delivery, got %d entries", len(pending))
+	}
+}
+
+func TestNotifyQueuesWhileOfflineAndFlushesLater(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	url := server.URL
+	server.Close() // Sin servidor: las entregas fallan
+
+	n := newTestNotifier(t, entities.WebhookConfig{URL: url})
+	err := n.Notify(entities.NewPowerEvent(entities.EventShutdownImminent, "System suspend imminent", nil))
+	if err == nil || !strings.Contains(err.Error(), "queued for retry") {
+		t.Fatalf("expected queued error, got %v", err)
+	}
+
+	pending, _ := n.queue.Load()
+	if len(pending) != 1 || pending[0].Attempts != 1 {
+		t.Fatalf("expected 1 queued delivery after 1 attempt, got %+v", pending)
+	}
+
+	// La red vuelve: el servidor responde (en una nueva dirección de prueba)
+	config, _ := n.configRepo.Load()
+	server = httptest.NewServer(rec)
+	defer server.Close()
+	pending[0].URL = server.URL
+	pending[0].NextAttempt = time.Time{}
+	n.queue.Save(pending)
+	config.Webhooks[0].URL = server.URL
+
+	if err := n.Notify(entities.NewPowerEvent(entities.EventWakeDetected, "Wake detected", nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rec.bodies) != 2 || !strings.Contains(rec.bodies[0], "shutdown_imminent") || !strings.Contains(rec.bodies[1], "wake_detected") {
+		t.Errorf("expected queued delivery first, then the new one: %q", rec.bodies)
+	}
+	if pending, _ := n.queue.Load(); len(pending) != 0 {
+		t.Errorf("expected empty queue, got %d entries", len(pending))
+	}
+}
+
+func TestNotifyDoesNotQueueRejectedDeliveries(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusNotFound}}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	n := newTestNotifier(t, entities.WebhookConfig{URL: server.URL})
+	if err := n.Notify(entities.NewPowerEvent(entities.EventWakeMissed, "Wake missed", nil)); err == nil {
+		t.Fatal("expected error for rejected delivery")
+	}
+
+	if len(rec.bodies) != 1 {
+		t.Errorf("4xx responses must not be retried, got %d attempts", len(rec.bodies))
+	}
+	if pending, _ := n.queue.Load(); len(pending) != 0 {
+		t.Errorf("rejected delivery should not be queued")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,20 @@
+// internal/infrastructure/wol/magic_packet.go
+package wol
+
+import (
+	"bytes"
+	"net"
+)
+
+// magicPacketRepeats es la cantidad de veces que se repite la MAC
+const magicPacketRepeats = 16
+
+// BuildMagicPacket arma el paquete mágico: 6 bytes 0xFF seguidos de la MAC
+// repetida 16 veces (102 bytes en total)
+func BuildMagicPacket(mac net.HardwareAddr) []byte {
+	packet := bytes.Repeat([]byte{0xFF}, 6)
+	for i := 0; i < magicPacketRepeats; i++ {
+		packet = append(packet, mac...)
+	}
+	return packet
+}
\ No newline at end of file
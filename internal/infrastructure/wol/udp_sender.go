@@ -0,0 +1,52 @@
+// internal/infrastructure/wol/udp_sender.go
+package wol
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+)
+
+// sendTimeout limita el envío del datagrama
+const sendTimeout = 5 * time.Second
+
+// UDPSender implementa WakeOnLANRepository enviando el paquete mágico por UDP broadcast
+type UDPSender struct{}
+
+// Verificar que implementa la interfaz
+var _ repositories.WakeOnLANRepository = (*UDPSender)(nil)
+
+// NewUDPSender crea una nueva instancia
+func NewUDPSender() *UDPSender {
+	return &UDPSender{}
+}
+
+// SendMagicPacket envía el paquete mágico a la dirección de broadcast del peer.
+// Go habilita SO_BROADCAST en los sockets UDP, así que no se requieren privilegios.
+func (s *UDPSender) SendMagicPacket(peer *entities.WakePeer) error {
+	mac, err := peer.HardwareAddr()
+	if err != nil {
+		return err
+	}
+
+	address, err := peer.BroadcastAddr()
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("udp", address, sendTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to open UDP socket to %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	conn.SetWriteDeadline(time.Now().Add(sendTimeout))
+	if _, err := conn.Write(BuildMagicPacket(mac)); err != nil {
+		return fmt.Errorf("failed to send magic packet to %s: %w", address, err)
+	}
+
+	return nil
+}
\ No newline at end of file
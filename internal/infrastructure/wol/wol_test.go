@@ -0,0 +1,53 @@
+// internal/infrastructure/wol/wol_test.go
+package wol
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"rtc-scheduler/internal/domain/entities"
+)
+
+func TestBuildMagicPacket(t *testing.T) {
+	mac, _ := net.ParseMAC("00:11:22:aa:bb:cc")
+	packet := BuildMagicPacket(mac)
+
+	if len(packet) != 102 {
+		t.Fatalf("packet length = %d, want 102", len(packet))
+	}
+	if !bytes.Equal(packet[:6], []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("sync stream = % x", packet[:6])
+	}
+	for i := 0; i < 16; i++ {
+		if chunk := packet[6+i*6 : 12+i*6]; !bytes.Equal(chunk, mac) {
+			t.Errorf("repeat %d = % x, want % x", i, chunk, []byte(mac))
+		}
+	}
+}
+
+func TestUDPSenderSendsToBroadcastAddress(t *testing.T) {
+	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	peer := &entities.WakePeer{Name: "desk", MAC: "00-11-22-AA-BB-CC", Broadcast: listener.LocalAddr().String()}
+	if err := NewUDPSender().SendMagicPacket(peer); err != nil {
+		t.Fatal(err)
+	}
+
+	listener.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, 200)
+	n, err := listener.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mac, _ := peer.HardwareAddr()
+	if !bytes.Equal(buf[:n], BuildMagicPacket(mac)) {
+		t.Errorf("received % x", buf[:n])
+	}
+}
\ No newline at end of file
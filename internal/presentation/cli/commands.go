@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"rtc-scheduler/internal/application/usecases"
+	"rtc-scheduler/internal/presentation/mqtt"
 	"rtc-scheduler/pkg/logger"
 )
 
@@ -26,6 +27,26 @@ type CLI struct {
 	disableUC    *usecases.DisableServiceUseCase
 	clearUC      *usecases.ClearAlarmUseCase
 	runServiceUC *usecases.RunServiceUseCase
+	historyUC    *usecases.ShowHistoryUseCase
+	shutdownUC   *usecases.ExecuteShutdownUseCase
+	wakePeersUC  *usecases.WakePeersUseCase
+	upsMonitorUC *usecases.MonitorUPSUseCase
+	snoozeUC     *usecases.SnoozeShutdownUseCase
+	skipNextUC   *usecases.SkipNextUseCase
+	vacationUC   *usecases.SetVacationUseCase
+	profilesUC   *usecases.ManageProfilesUseCase
+	reconfigUC   *usecases.ReconfigureScheduleUseCase
+	configHistUC *usecases.ConfigHistoryUseCase
+	dumpUC       *usecases.DumpConfigUseCase
+	validateUC   *usecases.ValidateConfigUseCase
+	migrateUC    *usecases.MigrateConfigUseCase
+	exportUC     *usecases.ExportStateUseCase
+	importUC     *usecases.ImportStateUseCase
+	oneOffsUC    *usecases.ManageOneOffsUseCase
+	sleepUC      *usecases.SleepNowUseCase
+	markReadyUC  *usecases.MarkWakeReadyUseCase
+	lock         *usecases.CommandLock
+	mqttCtl      *mqtt.Controller
 	logger       logger.Logger
 }
 
@@ -39,6 +60,26 @@ func NewCLI(
 	disableUC *usecases.DisableServiceUseCase,
 	clearUC *usecases.ClearAlarmUseCase,
 	runServiceUC *usecases.RunServiceUseCase,
+	historyUC *usecases.ShowHistoryUseCase,
+	shutdownUC *usecases.ExecuteShutdownUseCase,
+	wakePeersUC *usecases.WakePeersUseCase,
+	upsMonitorUC *usecases.MonitorUPSUseCase,
+	snoozeUC *usecases.SnoozeShutdownUseCase,
+	skipNextUC *usecases.SkipNextUseCase,
+	vacationUC *usecases.SetVacationUseCase,
+	profilesUC *usecases.ManageProfilesUseCase,
+	reconfigUC *usecases.ReconfigureScheduleUseCase,
+	configHistUC *usecases.ConfigHistoryUseCase,
+	dumpUC *usecases.DumpConfigUseCase,
+	validateUC *usecases.ValidateConfigUseCase,
+	migrateUC *usecases.MigrateConfigUseCase,
+	exportUC *usecases.ExportStateUseCase,
+	importUC *usecases.ImportStateUseCase,
+	oneOffsUC *usecases.ManageOneOffsUseCase,
+	sleepUC *usecases.SleepNowUseCase,
+	markReadyUC *usecases.MarkWakeReadyUseCase,
+	lock *usecases.CommandLock,
+	mqttCtl *mqtt.Controller,
 	log logger.Logger,
 ) *CLI {
 	return &CLI{
@@ -50,6 +91,26 @@ func NewCLI(
 		disableUC:    disableUC,
 		clearUC:      clearUC,
 		runServiceUC: runServiceUC,
+		historyUC:    historyUC,
+		shutdownUC:   shutdownUC,
+		wakePeersUC:  wakePeersUC,
+		upsMonitorUC: upsMonitorUC,
+		snoozeUC:     snoozeUC,
+		skipNextUC:   skipNextUC,
+		vacationUC:   vacationUC,
+		profilesUC:   profilesUC,
+		reconfigUC:   reconfigUC,
+		configHistUC: configHistUC,
+		dumpUC:       dumpUC,
+		validateUC:   validateUC,
+		migrateUC:    migrateUC,
+		exportUC:     exportUC,
+		importUC:     importUC,
+		oneOffsUC:    oneOffsUC,
+		sleepUC:      sleepUC,
+		markReadyUC:  markReadyUC,
+		lock:         lock,
+		mqttCtl:      mqttCtl,
 		logger:       log,
 	}
 }
@@ -64,13 +125,55 @@ func (c *CLI) Run() error {
 	enable := flag.Bool("enable", false, "Enable service")
 	disable := flag.Bool("disable", false, "Disable service")
 	runService := flag.Bool("run-service", false, "Run from service (internal use)")
+	markReady := flag.Bool("mark-ready", false, "Record how long the system took to be ready after the wake alarm (internal use)")
+	setSchedule := flag.Bool("set-schedule", false, "Change the wake and shutdown times of the installed service")
+	reconfigure := flag.Bool("reconfigure", false, "Alias of -set-schedule")
+	rollback := flag.Bool("rollback", false, "Restore the configuration saved before the last -set-schedule")
+	configHistory := flag.Bool("config-history", false, "List saved versions of the configuration")
+	configDiff := flag.String("config-diff", "", "Compare two configuration versions: -config-diff A [B] (B defaults to the latest)")
+	configRollback := flag.String("config-rollback", "", "Restore configuration version N and re-arm")
+	configDump := flag.Bool("config-dump", false, "Show the effective configuration and which file set each value")
+	migrateConfig := flag.Bool("migrate-config", false, "Rewrite the config file in the current schema version, keeping a backup")
+	validateConfig := flag.Bool("validate-config", false, "Check a configuration without applying it: -validate-config [path] (default: installed)")
+	noHostChecks := flag.Bool("no-host-checks", false, "With -validate-config, skip RTC, backend and power action checks (for CI)")
+	exportPath := flag.String("export", "", "Write config, profiles, overrides and hooks to a file (\"-\" = stdout)")
+	importPath := flag.String("import", "", "Validate and install a file written by -export, or a plain config (\"-\" = stdin)")
+	sleepFor := flag.String("sleep-for", "", "Suspend now and wake after a duration, e.g. 8h or 90m")
+	sleepUntil := flag.String("sleep-until", "", "Suspend now and wake at a time, e.g. 06:00 or \"tomorrow 7am\"")
+	snooze := flag.String("snooze", "", "Postpone the pending shutdown, e.g. 30m, 1h or until 23:30")
+	skipNext := flag.Bool("skip-next", false, "Skip the next wake once (see -skip-shutdown, -cancel)")
+	skipShutdown := flag.Bool("skip-shutdown", false, "With -skip-next, also skip the next shutdown")
+	cancel := flag.Bool("cancel", false, "With -skip-next, remove a pending skip")
+	vacation := flag.String("vacation", "", "Keep the machine off: -vacation FROM TO (YYYY-MM-DD), or -vacation off")
+	profile := flag.String("profile", "", "Manage schedule profiles: list, use NAME [-on YYYY-MM-DD], create NAME [-wake -shutdown], delete NAME")
+	profileOn := flag.String("on", "", "With -profile use, switch on this date (YYYY-MM-DD) instead of now")
+	queue := flag.String("queue", "", "Manage one-off date/time schedules: list, cancel ID")
+	history := flag.Bool("history", false, "Show power event history")
+	wakePeer := flag.String("wol", "", "Send a Wake-on-LAN packet to a configured peer (\"all\" wakes every peer in order)")
+	upsMonitor := flag.Bool("ups-monitor", false, "Monitor the UPS through upsd and suspend early on battery")
+	mqttMode := flag.Bool("mqtt", false, "Run the MQTT client (publishes status, accepts commands)")
+	executeShutdown := flag.String("execute-shutdown", "", "Run the scheduled power action: suspend, poweroff or hibernate (internal use)")
 	test := flag.Bool("test", false, "Test mode (no real shutdown)")
+	yes := flag.Bool("yes", false, "Don't ask to confirm how -wake, -shutdown, -snooze or -sleep-* were interpreted")
 	version := flag.Bool("version", false, "Show version")
 
-	wakeTime := flag.String("wake", "", "Wake time (HH:MM)")
-	shutdownTime := flag.String("shutdown", "", "Shutdown time (HH:MM)")
+	wakeTime := flag.String("wake", "", "Wake time: HH:MM, 7:30am, sunset-30m, +8h, tomorrow 07:30, mon 06:00, YYYY-MM-DD HH:MM or RFC 3339")
+	shutdownTime := flag.String("shutdown", "", "Shutdown time, same forms as -wake (relative ones count from the wake)")
+	from := flag.String("from", "", "History range start (YYYY-MM-DD[ HH:MM], RFC 3339 or duration ago, e.g. 24h)")
+	to := flag.String("to", "", "History range end (YYYY-MM-DD[ HH:MM], RFC 3339 or duration ago)")
+
+	// Flags de logging: los consume main al crear el logger, se declaran aquí para que flag.Parse los acepte
+	flag.String("log-level", "", "Log level: debug, info, warn, error (env RTC_SCHEDULER_LOG_LEVEL)")
+	flag.String("log-format", "", "Log format: text or json (env RTC_SCHEDULER_LOG_FORMAT)")
+	flag.String("log-output", "", "Log output: stdout, stderr, journald or a file path, rotated by size/age (env RTC_SCHEDULER_LOG_OUTPUT)")
+
+	// Ajustes de arranque: también los consume main (ver /etc/rtc-scheduler/config.yaml)
+	flag.String("rtc-device", "", "RTC device for wake alarms, e.g. /dev/rtc1 (env RTC_SCHEDULER_RTC_DEVICE)")
+	flag.String("timezone", "", "Time zone for wake/shutdown times, e.g. Europe/Madrid (env RTC_SCHEDULER_TIMEZONE)")
+	flag.String("scheduler", "", "Shutdown backend: auto, at or systemd (env RTC_SCHEDULER_SCHEDULER)")
 
 	flag.Parse()
+	args := parseInterspersed()
 
 	// Mostrar versión
 	if *version {
@@ -78,35 +181,140 @@ func (c *CLI) Run() error {
 		return nil
 	}
 
-	// Verificar permisos de root (excepto para status y version)
-	if os.Geteuid() != 0 && !*status && !*version {
+	// Verificar permisos de root (excepto para comandos de solo lectura)
+	readOnly := *status || *version || *history || *validateConfig || *configDump || *configHistory || *configDiff != "" ||
+		*profile == usecases.ProfileActionList || *queue == usecases.OneOffActionList
+	if os.Geteuid() != 0 && !readOnly {
 		return fmt.Errorf("❌ This program must be run as root (sudo)")
 	}
 
-	// Routing de comandos
+	// Routing de comandos. Los que modifican la configuración, la alarma o los
+	// apagados corren con el lock de comandos tomado (exclusive).
 	switch {
 	case *install:
-		return c.handleInstall(*wakeTime, *shutdownTime)
+		if err := c.interpretTimes(wakeTime, shutdownTime, true, *yes); err != nil {
+			return err
+		}
+		return c.exclusive("install", func() error { return c.handleInstall(*wakeTime, *shutdownTime) })
 	case *uninstall:
-		return c.handleUninstall()
+		return c.exclusive("uninstall", c.handleUninstall)
 	case *status:
 		return c.handleStatus()
 	case *clear:
-		return c.handleClear()
+		return c.exclusive("clear", c.handleClear)
 	case *enable:
-		return c.handleEnable()
+		return c.exclusive("enable", c.handleEnable)
 	case *disable:
-		return c.handleDisable()
+		return c.exclusive("disable", c.handleDisable)
+	case *setSchedule || *reconfigure:
+		if err := c.interpretTimes(wakeTime, shutdownTime, true, *yes); err != nil {
+			return err
+		}
+		return c.exclusive("set-schedule", func() error { return c.handleSetSchedule(*wakeTime, *shutdownTime) })
+	case *rollback:
+		return c.exclusive("rollback", c.handleRollback)
+	case *configHistory:
+		return c.handleConfigHistory()
+	case *configDiff != "":
+		return c.handleConfigDiff(*configDiff, argAt(args, 0))
+	case *configRollback != "":
+		return c.exclusive("config-rollback", func() error { return c.handleConfigRollback(*configRollback) })
+	case *configDump:
+		return c.handleConfigDump()
+	case *migrateConfig:
+		return c.exclusive("migrate-config", c.handleMigrateConfig)
+	case *validateConfig:
+		return c.handleValidateConfig(argAt(args, 0), !*noHostChecks)
+	case *exportPath != "":
+		return c.handleExport(*exportPath)
+	case *importPath != "":
+		return c.exclusive("import", func() error { return c.handleImport(*importPath) })
+	case *sleepFor != "" || *sleepUntil != "":
+		if err := c.interpretSleep(*sleepFor, *sleepUntil, *yes); err != nil {
+			return err
+		}
+		return c.exclusive("sleep", func() error { return c.handleSleep(*sleepFor, *sleepUntil, *test) })
+	case *snooze != "":
+		if err := c.interpretSnooze(*snooze, *yes); err != nil {
+			return err
+		}
+		return c.exclusive("snooze", func() error { return c.handleSnooze(*snooze) })
+	case *skipNext:
+		return c.exclusive("skip-next", func() error { return c.handleSkipNext(*skipShutdown, *cancel) })
+	case *vacation != "":
+		return c.exclusive("vacation", func() error { return c.handleVacation(*vacation, argAt(args, 0)) })
+	case *profile == usecases.ProfileActionList:
+		return c.handleProfile(*profile, argAt(args, 0), *wakeTime, *shutdownTime, *profileOn)
+	case *profile != "":
+		if *profile == usecases.ProfileActionCreate {
+			if err := c.interpretTimes(wakeTime, shutdownTime, true, *yes); err != nil {
+				return err
+			}
+		}
+		return c.exclusive("profile", func() error {
+			return c.handleProfile(*profile, argAt(args, 0), *wakeTime, *shutdownTime, *profileOn)
+		})
+	case *queue == usecases.OneOffActionList:
+		return c.handleQueue(*queue, argAt(args, 0))
+	case *queue != "":
+		return c.exclusive("queue", func() error { return c.handleQueue(*queue, argAt(args, 0)) })
 	case *runService:
 		return c.handleRunService()
+	case *markReady:
+		return c.handleMarkReady()
+	case *executeShutdown != "":
+		return c.handleExecuteShutdown(*executeShutdown)
+	case *history:
+		return c.handleHistory(*from, *to)
+	case *wakePeer != "":
+		return c.handleWakePeer(*wakePeer)
+	case *upsMonitor:
+		return c.handleUPSMonitor()
+	case *mqttMode:
+		return c.handleMQTT()
 	default:
 		// Modo manual (programación única)
 		if *wakeTime == "" || *shutdownTime == "" {
 			c.showUsage()
 			return fmt.Errorf("wake and shutdown times are required for manual scheduling")
 		}
-		return c.handleManualSchedule(*wakeTime, *shutdownTime, *test)
+		if err := c.interpretTimes(wakeTime, shutdownTime, false, *yes); err != nil {
+			return err
+		}
+		return c.exclusive("schedule", func() error { return c.handleManualSchedule(*wakeTime, *shutdownTime, *test) })
+	}
+}
+
+// exclusive ejecuta un comando con el lock de comandos tomado
+func (c *CLI) exclusive(command string, fn func() error) error {
+	ran := false
+	err := c.lock.Run(command, invokingUser(), func() error {
+		ran = true
+		return fn()
+	})
+	if err != nil && !ran {
+		return fmt.Errorf("❌ Cannot run -%s: %w", command, err)
 	}
+	return err
+}
+
+// parseInterspersed acepta flags después de argumentos posicionales
+// (-profile use NAME -on FECHA) y retorna los posicionales en orden
+func parseInterspersed() []string {
+	var args []string
+	for flag.NArg() > 0 {
+		args = append(args, flag.Arg(0))
+		flag.CommandLine.Parse(flag.Args()[1:])
+	}
+	return args
+}
+
+// argAt retorna el argumento posicional i, o "" si no existe
+func argAt(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
 }
 
 // showUsage muestra la ayuda de uso
@@ -122,15 +330,68 @@ func (c *CLI) showUsage() {
 	fmt.Println("  -enable                                 Enable service")
 	fmt.Println("  -disable                                Disable service")
 	fmt.Println("  -status                                 Show status")
+	fmt.Println("  -set-schedule -wake HH:MM -shutdown HH:MM  Change the schedule and re-arm (no reinstall)")
+	fmt.Println("  -set-schedule -wake sunset-30m -shutdown sunrise  Follow the sun (needs \"location\" in the config)")
+	fmt.Println("  -rollback                               Undo the last -set-schedule")
+	fmt.Println("  -snooze 1h                              Postpone the pending shutdown (daily limit applies)")
+	fmt.Println("  -snooze 23:30                           Postpone the pending shutdown until a time")
+	fmt.Println("  -sleep-for 8h                           Suspend now, wake in 8 hours (-test: arm only)")
+	fmt.Println("  -sleep-until 06:00                      Suspend now, wake at 06:00")
+	fmt.Println("  -skip-next [-skip-shutdown]             Skip the next wake (and shutdown) once")
+	fmt.Println("  -skip-next -cancel                      Undo -skip-next")
+	fmt.Println("  -vacation 2025-07-01 2025-07-14         No wakes in a date range, then resume")
+	fmt.Println("  -vacation off                           End the vacation now")
+	fmt.Println()
+	fmt.Println("PROFILES:")
+	fmt.Println("  -profile list                           List schedule profiles")
+	fmt.Println("  -profile create NAME -wake HH:MM -shutdown HH:MM  Add a profile")
+	fmt.Println("  -profile use NAME                       Activate a profile now (re-arms the RTC)")
+	fmt.Println("  -profile use NAME -on 2025-09-01        Activate a profile on a date")
+	fmt.Println("  -profile delete NAME                    Remove an inactive profile")
+	fmt.Println()
+	fmt.Println("CONFIG VERSIONS:")
+	fmt.Println("  -config-history                         List saved config versions (date, user, schedule)")
+	fmt.Println("  -config-diff 3 [5]                      Show changes between versions (default: latest)")
+	fmt.Println("  -config-rollback 3                      Restore version 3 and re-arm")
+	fmt.Println("  -config-dump                            Effective config with /etc/rtc-scheduler.d drop-ins")
+	fmt.Println("  -migrate-config                         Rewrite an older config file in the current format")
+	fmt.Println("  -validate-config [path]                 Check a config without applying it (-no-host-checks for CI)")
+	fmt.Println("  -export state.json                      Save config, profiles, overrides and hooks (- = stdout)")
+	fmt.Println("  -import state.json                      Validate, install and re-arm an exported state")
+	fmt.Println()
+	fmt.Println("HISTORY:")
+	fmt.Println("  -history                                Show all recorded power events")
+	fmt.Println("  -history -from 24h                      Events from the last 24 hours")
+	fmt.Println("  -history -from 2025-01-01 -to 2025-01-31  Events in a date range")
+	fmt.Println()
+	fmt.Println("INTEGRATIONS:")
+	fmt.Println("  -mqtt                                   Run the MQTT client (configured in \"mqtt\")")
+	fmt.Println("  -wol NAME                               Wake one peer now (Wake-on-LAN)")
+	fmt.Println("  -ups-monitor                            Monitor the UPS (NUT) and suspend early on battery")
+	fmt.Println("  -wol all                                Wake all peers in dependency order")
 	fmt.Println()
 	fmt.Println("MANUAL SCHEDULING:")
 	fmt.Println("  -wake HH:MM -shutdown HH:MM             Schedule once")
 	fmt.Println("  -wake HH:MM -shutdown HH:MM -test       Schedule once (test mode)")
+	fmt.Println("  -wake \"2026-12-24 06:00\" -shutdown \"2026-12-24 23:00\"  Queue a one-off on those dates")
+	fmt.Println("  -wake \"tomorrow 7:30am\" -shutdown +8h  Natural input, confirmed before applying (-yes skips)")
+	fmt.Println("  -queue list                             List queued one-offs")
+	fmt.Println("  -queue cancel ID                        Remove a queued one-off and re-arm")
 	fmt.Println()
 	fmt.Println("MAINTENANCE:")
 	fmt.Println("  -clear                                  Clear wake alarm")
 	fmt.Println("  -version                                Show version")
 	fmt.Println()
+	fmt.Println("LOGGING:")
+	fmt.Println("  -log-level debug|info|warn|error        Minimum level (env RTC_SCHEDULER_LOG_LEVEL)")
+	fmt.Println("  -log-format text|json                   Output format (env RTC_SCHEDULER_LOG_FORMAT)")
+	fmt.Println("  -log-output stdout|stderr|journald|PATH Destination; files rotate by size/age")
+	fmt.Println()
+	fmt.Println("SETTINGS (defaults < /etc/rtc-scheduler/config.yaml < env < flags):")
+	fmt.Println("  -rtc-device /dev/rtc1                   RTC used for wake alarms")
+	fmt.Println("  -timezone Europe/Madrid                 Zone for wake/shutdown times (default: system)")
+	fmt.Println("  -scheduler auto|at|systemd              Shutdown backend")
+	fmt.Println()
 	fmt.Println("EXAMPLES:")
 	fmt.Println("  sudo ./rtc-scheduler -install -wake 08:00 -shutdown 22:00")
 	fmt.Println("  sudo ./rtc-scheduler -status")
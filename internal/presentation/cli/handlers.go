@@ -4,7 +4,11 @@ package cli
 import (
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
 	"rtc-scheduler/internal/application/usecases"
 )
@@ -116,20 +120,571 @@ func (c *CLI) handleDisable() error {
 	return nil
 }
 
+// handleSnooze pospone el apagado pendiente
+func (c *CLI) handleSnooze(duration string) error {
+	c.logger.Info("Snoozing shutdown", "duration", duration)
+
+	input := &usecases.SnoozeShutdownInput{
+		Duration: duration,
+		By:       invokingUser(),
+	}
+
+	output, err := c.snoozeUC.Execute(input)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to snooze shutdown: %w", err)
+	}
+
+	fmt.Println("😴", output.Message)
+	return nil
+}
+
+// handleSleep suspende ya y despierta a la hora indicada
+func (c *CLI) handleSleep(forValue, untilValue string, testMode bool) error {
+	c.logger.Info("Sleeping now", "for", forValue, "until", untilValue, "test_mode", testMode)
+
+	output, err := c.sleepUC.Execute(&usecases.SleepNowInput{
+		For:      forValue,
+		Until:    untilValue,
+		TestMode: testMode,
+		By:       invokingUser(),
+	})
+	if err != nil {
+		return fmt.Errorf("❌ Sleep failed: %w", err)
+	}
+
+	fmt.Println("💤", output.Message)
+	return nil
+}
+
+// handleSetSchedule cambia el horario del servicio instalado sin reinstalarlo
+func (c *CLI) handleSetSchedule(wakeTime, shutdownTime string) error {
+	if wakeTime == "" || shutdownTime == "" {
+		return fmt.Errorf("❌ -set-schedule needs -wake HH:MM and -shutdown HH:MM")
+	}
+
+	c.logger.Info("Changing schedule", "wake_time", wakeTime, "shutdown_time", shutdownTime)
+
+	output, err := c.reconfigUC.Execute(&usecases.ReconfigureScheduleInput{
+		WakeTime:     wakeTime,
+		ShutdownTime: shutdownTime,
+		By:           invokingUser(),
+	})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to change schedule: %w", err)
+	}
+
+	c.printReconfigure(output)
+	fmt.Println("   Undo with: sudo rtc-scheduler -rollback")
+	return nil
+}
+
+// handleRollback restaura la configuración anterior al último cambio de horario
+func (c *CLI) handleRollback() error {
+	c.logger.Info("Rolling back configuration")
+
+	output, err := c.reconfigUC.Execute(&usecases.ReconfigureScheduleInput{
+		Rollback: true,
+		By:       invokingUser(),
+	})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to roll back configuration: %w", err)
+	}
+
+	c.printReconfigure(output)
+	return nil
+}
+
+// printReconfigure muestra el resultado de un cambio de horario
+func (c *CLI) printReconfigure(output *usecases.ReconfigureScheduleOutput) {
+	fmt.Println("✅", output.Message)
+	if output.Degraded {
+		fmt.Println("⚠️  Shutdown could not be scheduled (read-only filesystem)")
+	}
+}
+
+// handleConfigHistory lista las versiones guardadas de la configuración
+func (c *CLI) handleConfigHistory() error {
+	output, err := c.configHistUC.Execute(&usecases.ConfigHistoryInput{Action: usecases.ConfigHistoryList})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to read config history: %w", err)
+	}
+
+	fmt.Println("🗃️  Configuration Versions:")
+	if len(output.Versions) == 0 {
+		fmt.Println("   None recorded yet")
+		return nil
+	}
+	for _, version := range output.Versions {
+		by := version.By
+		if by == "" {
+			by = "unknown"
+		}
+		fmt.Printf("   %3d  %s  %-10s %s\n", version.ID, version.SavedAt.Format("2006-01-02 15:04:05"), by, version.Summary())
+	}
+	return nil
+}
+
+// handleConfigDiff muestra las diferencias entre dos versiones
+func (c *CLI) handleConfigDiff(from, to string) error {
+	output, err := c.configHistUC.Execute(&usecases.ConfigHistoryInput{
+		Action: usecases.ConfigHistoryDiff,
+		From:   from,
+		To:     to,
+	})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to compare config versions: %w", err)
+	}
+
+	fmt.Println("🔍", output.Message)
+	fmt.Print(output.Diff)
+	return nil
+}
+
+// handleConfigRollback restaura una versión guardada de la configuración
+func (c *CLI) handleConfigRollback(version string) error {
+	c.logger.Info("Rolling back configuration", "version", version)
+
+	output, err := c.configHistUC.Execute(&usecases.ConfigHistoryInput{
+		Action:  usecases.ConfigHistoryRollback,
+		Version: version,
+		By:      invokingUser(),
+	})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to roll back configuration: %w", err)
+	}
+
+	fmt.Println("✅", output.Message)
+	return nil
+}
+
+// handleMigrateConfig reescribe la configuración en el formato actual
+func (c *CLI) handleMigrateConfig() error {
+	output, err := c.migrateUC.Execute(&usecases.MigrateConfigInput{})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to migrate configuration: %w", err)
+	}
+
+	fmt.Println("✅", output.Message)
+	return nil
+}
+
+// handleConfigDump muestra la configuración efectiva y el origen de cada valor
+func (c *CLI) handleConfigDump() error {
+	output, err := c.dumpUC.Execute(&usecases.DumpConfigInput{})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to read configuration: %w", err)
+	}
+	effective := output.Effective
+
+	fmt.Println("📄 Configuration files (applied in this order):")
+	for _, file := range effective.Files {
+		fmt.Println("  ", file)
+	}
+	fmt.Println()
+
+	width := 0
+	for _, value := range effective.Values {
+		if n := len(value.Path) + len(value.Value) + 3; n > width {
+			width = n
+		}
+	}
+	fmt.Println("⚙️  Effective values:")
+	for _, value := range effective.Values {
+		fmt.Printf("   %-*s ← %s\n", width, value.Path+" = "+value.Value, value.Source)
+	}
+	fmt.Println()
+
+	fmt.Println("🧩 Merged JSON:")
+	fmt.Println(effective.Content)
+	return nil
+}
+
+// handleValidateConfig revisa una configuración y falla si encuentra problemas.
+// Sin root, las revisiones del equipo que lo necesitan quedan como advertencias
+func (c *CLI) handleValidateConfig(path string, hostChecks bool) error {
+	output, err := c.validateUC.Execute(&usecases.ValidateConfigInput{
+		Path:       path,
+		HostChecks: hostChecks,
+		Privileged: os.Geteuid() == 0,
+	})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to validate configuration: %w", err)
+	}
+
+	name := path
+	if name == "" {
+		name = "installed configuration"
+	}
+	for _, warning := range output.HostWarnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+	if output.Valid() {
+		fmt.Printf("✅ %s is valid\n", name)
+		return nil
+	}
+
+	if len(output.Problems) > 0 {
+		fmt.Printf("❌ %s:\n", name)
+		for _, problem := range output.Problems {
+			fmt.Println("  ", problem)
+		}
+	}
+	if len(output.HostProblems) > 0 {
+		fmt.Println("🖥️  This host:")
+		for _, problem := range output.HostProblems {
+			fmt.Println("  ", problem)
+		}
+	}
+	return fmt.Errorf("❌ %d problem(s) found", len(output.Problems)+len(output.HostProblems))
+}
+
+// handleExport guarda el estado completo en un archivo o en la salida estándar
+func (c *CLI) handleExport(path string) error {
+	output, err := c.exportUC.Execute(&usecases.ExportStateInput{Path: path})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to export state: %w", err)
+	}
+
+	// Con "-" la salida estándar es el propio archivo exportado
+	if path == "-" {
+		return nil
+	}
+	fmt.Println("✅", output.Message)
+	fmt.Println("⚠️  The file includes webhook, MQTT and UPS credentials; it is readable by root only")
+	return nil
+}
+
+// handleImport valida e instala un estado exportado
+func (c *CLI) handleImport(path string) error {
+	c.logger.Info("Importing state", "path", path)
+
+	output, err := c.importUC.Execute(&usecases.ImportStateInput{Path: path, By: invokingUser()})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to import state: %w", err)
+	}
+	if len(output.Problems) > 0 {
+		fmt.Printf("❌ %s:\n", path)
+		for _, problem := range output.Problems {
+			fmt.Println("  ", problem)
+		}
+		return fmt.Errorf("❌ Nothing imported, %d problem(s) found", len(output.Problems))
+	}
+
+	fmt.Println("✅", output.Message)
+	return nil
+}
+
+// handleSkipNext omite el próximo ciclo, o deshace un skip pendiente
+func (c *CLI) handleSkipNext(skipShutdown, cancel bool) error {
+	c.logger.Info("Skipping next cycle", "skip_shutdown", skipShutdown, "cancel", cancel)
+
+	input := &usecases.SkipNextInput{
+		SkipShutdown: skipShutdown,
+		Cancel:       cancel,
+		By:           invokingUser(),
+	}
+
+	output, err := c.skipNextUC.Execute(input)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to skip next cycle: %w", err)
+	}
+
+	fmt.Println("⏭️ ", output.Message)
+	return nil
+}
+
+// handleVacation configura o termina el modo vacaciones
+func (c *CLI) handleVacation(from, to string) error {
+	c.logger.Info("Setting vacation", "from", from, "to", to)
+
+	input := &usecases.SetVacationInput{By: invokingUser()}
+	switch {
+	case strings.EqualFold(from, "off"):
+		input.Cancel = true
+	case to == "":
+		return fmt.Errorf("❌ -vacation needs two dates: -vacation FROM TO (YYYY-MM-DD)")
+	default:
+		input.From = from
+		input.To = to
+	}
+
+	output, err := c.vacationUC.Execute(input)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to set vacation: %w", err)
+	}
+
+	fmt.Println("🏖️ ", output.Message)
+	return nil
+}
+
+// handleProfile lista, crea, activa o elimina perfiles de horario
+func (c *CLI) handleProfile(action, name, wakeTime, shutdownTime, on string) error {
+	c.logger.Info("Managing profiles", "action", action, "profile", name)
+
+	input := &usecases.ManageProfilesInput{
+		Action:       action,
+		Name:         name,
+		WakeTime:     wakeTime,
+		ShutdownTime: shutdownTime,
+		On:           on,
+		By:           invokingUser(),
+	}
+
+	output, err := c.profilesUC.Execute(input)
+	if err != nil {
+		return fmt.Errorf("❌ Profile %s failed: %w", action, err)
+	}
+
+	if action != usecases.ProfileActionList {
+		fmt.Println("🗂️ ", output.Message)
+		return nil
+	}
+
+	fmt.Println("🗂️  Schedule Profiles:")
+	if len(output.Profiles) == 0 {
+		fmt.Println("   None (create one with -profile create NAME -wake HH:MM -shutdown HH:MM)")
+	}
+	for _, profile := range output.Profiles {
+		marker := " "
+		if profile.Name == output.Active {
+			marker = "*"
+		}
+		fmt.Printf(" %s %-16s wake %s  shutdown %s\n", marker, profile.Name, profile.WakeTime, profile.ShutdownTime)
+	}
+	for _, change := range output.Switches {
+		fmt.Printf("   📅 %s\n", change.String())
+	}
+	return nil
+}
+
+// handleQueue lista o cancela las ventanas únicas de la cola
+func (c *CLI) handleQueue(action, id string) error {
+	c.logger.Info("Managing one-off queue", "action", action, "id", id)
+
+	input := &usecases.ManageOneOffsInput{
+		Action: action,
+		ID:     id,
+		By:     invokingUser(),
+	}
+
+	output, err := c.oneOffsUC.Execute(input)
+	if err != nil {
+		return fmt.Errorf("❌ Queue %s failed: %w", action, err)
+	}
+
+	if action != usecases.OneOffActionList {
+		fmt.Println("📅", output.Message)
+		return nil
+	}
+
+	fmt.Println("📅 One-off Queue:")
+	if len(output.OneOffs) == 0 {
+		fmt.Println("   Empty (add one with -wake \"YYYY-MM-DD HH:MM\" -shutdown \"YYYY-MM-DD HH:MM\")")
+	}
+	for _, oneOff := range output.OneOffs {
+		fmt.Printf("   #%-4s %s", oneOff.ID, oneOff.String())
+		if oneOff.By != "" {
+			fmt.Printf("  (by %s)", oneOff.By)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// invokingUser retorna el usuario que ejecutó el comando, también bajo sudo
+func invokingUser() string {
+	for _, name := range []string{"SUDO_USER", "USER", "LOGNAME"} {
+		if user := os.Getenv(name); user != "" {
+			return user
+		}
+	}
+	return fmt.Sprintf("uid %d", os.Getuid())
+}
+
 // handleRunService ejecuta desde el servicio systemd
 func (c *CLI) handleRunService() error {
-	c.logger.Info("Running from service")
+	var output *usecases.RunServiceOutput
+	err := c.exclusive("run-service", func() error {
+		var err error
+		output, err = c.runServiceUC.Execute(&usecases.RunServiceInput{})
+		if err != nil {
+			return fmt.Errorf("❌ Service execution failed: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
-	input := &usecases.RunServiceInput{}
-	output, err := c.runServiceUC.Execute(input)
+	if output.Degraded {
+		fmt.Printf("⚠️  %s (run %s)\n", output.Message, output.RunID)
+	} else {
+		fmt.Printf("✅ %s (run %s)\n", output.Message, output.RunID)
+	}
+
+	// Con el lock ya liberado: las esperas entre peers y los webhooks que no
+	// responden no bloquean otros comandos
+	c.runServiceUC.WakePeers(output)
+	c.runServiceUC.FlushNotifications(output)
+	return nil
+}
+
+// handleMarkReady mide el tiempo de alarma a "listo" desde la unidad -ready
+func (c *CLI) handleMarkReady() error {
+	output, err := c.markReadyUC.Execute(&usecases.MarkWakeReadyInput{})
 	if err != nil {
-		return fmt.Errorf("❌ Service execution failed: %w", err)
+		return fmt.Errorf("❌ Wake-to-ready measurement failed: %w", err)
 	}
 
+	if !output.Measured {
+		fmt.Println("ℹ️ ", output.Message)
+		return nil
+	}
 	fmt.Println("✅", output.Message)
 	return nil
 }
 
+// handleExecuteShutdown ejecuta la acción de apagado desde el trabajo programado
+func (c *CLI) handleExecuteShutdown(action string) error {
+	input := &usecases.ExecuteShutdownInput{
+		Action: action,
+	}
+
+	output, err := c.shutdownUC.Execute(input)
+	if err != nil {
+		return fmt.Errorf("❌ Shutdown failed: %w", err)
+	}
+
+	fmt.Println("✅", output.Message)
+	return nil
+}
+
+// handleUPSMonitor monitorea la UPS hasta recibir SIGINT o SIGTERM
+func (c *CLI) handleUPSMonitor() error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	stop := make(chan struct{})
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	output, err := c.upsMonitorUC.Execute(&usecases.MonitorUPSInput{Stop: stop})
+	if err != nil {
+		return fmt.Errorf("❌ UPS monitor failed: %w", err)
+	}
+
+	fmt.Println("✅", output.Message)
+	return nil
+}
+
+// handleWakePeer envía Wake-on-LAN a un peer, o a todos en orden con "all"
+func (c *CLI) handleWakePeer(name string) error {
+	c.logger.Info("Waking peer", "peer", name)
+
+	input := &usecases.WakePeersInput{}
+	if name != "all" {
+		input.Peer = name
+	}
+
+	output, err := c.wakePeersUC.Execute(input)
+	if output != nil {
+		for _, peer := range output.Woken {
+			fmt.Println("📡 Magic packet sent to", peer)
+		}
+		for _, peer := range output.Skipped {
+			fmt.Println("⏭️  Skipped", peer, "(a dependency could not be woken)")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("❌ Wake-on-LAN failed: %w", err)
+	}
+
+	fmt.Println("✅", output.Message)
+	return nil
+}
+
+// handleMQTT ejecuta el cliente MQTT hasta recibir SIGINT o SIGTERM
+func (c *CLI) handleMQTT() error {
+	c.logger.Info("Starting MQTT client")
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	stop := make(chan struct{})
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	if err := c.mqttCtl.Run(stop); err != nil {
+		return fmt.Errorf("❌ MQTT client failed: %w", err)
+	}
+
+	fmt.Println("✅ MQTT client stopped")
+	return nil
+}
+
+// handleHistory muestra el historial de eventos dentro del rango indicado
+func (c *CLI) handleHistory(from, to string) error {
+	c.logger.Info("Showing history", "from", from, "to", to)
+
+	fromTime, err := parseHistoryTime(from, false)
+	if err != nil {
+		return fmt.Errorf("❌ Invalid -from value: %w", err)
+	}
+	toTime, err := parseHistoryTime(to, true)
+	if err != nil {
+		return fmt.Errorf("❌ Invalid -to value: %w", err)
+	}
+
+	input := &usecases.ShowHistoryInput{
+		From: fromTime,
+		To:   toTime,
+	}
+
+	output, err := c.historyUC.Execute(input)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to read history: %w", err)
+	}
+
+	fmt.Println(output.Message)
+	return nil
+}
+
+// parseHistoryTime interpreta un límite del rango de historial.
+// Acepta RFC 3339, "YYYY-MM-DD", "YYYY-MM-DD HH:MM" o una duración relativa a ahora ("24h").
+// Una fecha sin hora usada como límite superior incluye el día completo.
+func parseHistoryTime(value string, isEnd bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		if isEnd {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized time %q", value)
+}
+
 // handleManualSchedule maneja la programación manual (una sola vez)
 func (c *CLI) handleManualSchedule(wakeTime, shutdownTime string, testMode bool) error {
 	c.logger.Info("Manual scheduling", "wake_time", wakeTime, "shutdown_time", shutdownTime, "test_mode", testMode)
@@ -138,6 +693,7 @@ func (c *CLI) handleManualSchedule(wakeTime, shutdownTime string, testMode bool)
 		WakeTime:     wakeTime,
 		ShutdownTime: shutdownTime,
 		TestMode:     testMode,
+		By:           invokingUser(),
 	}
 
 	output, err := c.scheduleUC.Execute(input)
@@ -146,6 +702,11 @@ func (c *CLI) handleManualSchedule(wakeTime, shutdownTime string, testMode bool)
 	}
 
 	fmt.Println("✅", output.Message)
+	if output.OneOff != nil {
+		// Se combina con el horario diario: el próximo despertar puede ser otro
+		fmt.Println("   Merged with the daily schedule, see -status for the next wake")
+		return nil
+	}
 	if output.Schedule != nil {
 		fmt.Printf("   Next wake: %s\n", output.Schedule.WakeTime.Format("2006-01-02 15:04:05"))
 		fmt.Printf("   Next shutdown: %s\n", output.Schedule.ShutdownTime.Format("2006-01-02 15:04:05"))
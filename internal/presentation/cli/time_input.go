@@ -0,0 +1,187 @@
+// internal/presentation/cli/time_input.go
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"rtc-scheduler/pkg/solar"
+	"rtc-scheduler/pkg/timeparse"
+)
+
+// interpretTimes convierte -wake y -shutdown escritos como "7:30am", "+8h",
+// "tomorrow 07:30" o "mon 06:00" a la forma que esperan los comandos, muestra
+// la interpretación y la confirma. Para un horario diario (daily) solo valen
+// horas del día y horarios solares (ver interpretClockTimes). En el modo
+// manual dos horas del día siguen siendo la próxima ocurrencia; cualquier
+// otra forma se convierte en fecha y hora (ventana única). El apagado
+// relativo o sin día se cuenta desde el despertar: -wake "tomorrow 07:00"
+// -shutdown +8h apaga a las 15:00.
+func (c *CLI) interpretTimes(wakeTime, shutdownTime *string, daily, assumeYes bool) error {
+	if *wakeTime == "" || *shutdownTime == "" {
+		return nil
+	}
+	if daily || solar.IsAnchor(*wakeTime) || solar.IsAnchor(*shutdownTime) {
+		return interpretClockTimes(wakeTime, shutdownTime, daily, assumeYes)
+	}
+	// Ya en forma estricta y del mismo tipo: nada que interpretar
+	if isLiteralTime(*wakeTime) && isLiteralTime(*shutdownTime) && (len(*wakeTime) == len("15:04")) == (len(*shutdownTime) == len("15:04")) {
+		return nil
+	}
+
+	now := time.Now()
+	wake, err := timeparse.Parse(*wakeTime, now)
+	if err != nil {
+		return fmt.Errorf("❌ -wake: %w", err)
+	}
+	shutdown, err := timeparse.Parse(*shutdownTime, wake.Time)
+	if err != nil {
+		return fmt.Errorf("❌ -shutdown: %w", err)
+	}
+
+	var lines []string
+	switch {
+	case wake.Kind == timeparse.Clock && shutdown.Kind == timeparse.Clock:
+		lines = []string{
+			fmt.Sprintf("Wake:     %-20q → %s, next occurrence", *wakeTime, wake.Clock()),
+			fmt.Sprintf("Shutdown: %-20q → %s, next occurrence", *shutdownTime, shutdown.Clock()),
+		}
+		*wakeTime, *shutdownTime = wake.Clock(), shutdown.Clock()
+
+	default:
+		lines = []string{
+			fmt.Sprintf("Wake:     %-20q → %s", *wakeTime, wake.Describe(now)),
+			fmt.Sprintf("Shutdown: %-20q → %s", *shutdownTime, shutdown.Describe(now)),
+		}
+		*wakeTime, *shutdownTime = wake.Time.Format(time.RFC3339), shutdown.Time.Format(time.RFC3339)
+	}
+
+	return confirm(lines, assumeYes)
+}
+
+// interpretClockTimes convierte las horas del día a HH:MM, para un horario
+// diario o, en el modo manual, junto a un horario solar (ambos son la
+// próxima ocurrencia). Los horarios solares ("sunset-30m") quedan en forma
+// canónica: se resuelven cada día con la ubicación configurada.
+func interpretClockTimes(wakeTime, shutdownTime *string, daily, assumeYes bool) error {
+	when := "next occurrence"
+	if daily {
+		when = "every day"
+	}
+
+	now := time.Now()
+	var lines []string
+	for _, entry := range []struct {
+		flag, label string
+		value       *string
+	}{{"-wake", "Wake:    ", wakeTime}, {"-shutdown", "Shutdown:", shutdownTime}} {
+		if anchor, err := solar.ParseAnchor(*entry.value); err == nil {
+			*entry.value = anchor.String()
+			continue
+		}
+		if isLiteralTime(*entry.value) && len(*entry.value) == len("15:04") {
+			continue
+		}
+		result, err := timeparse.Parse(*entry.value, now)
+		if err != nil {
+			return fmt.Errorf("❌ %s: %w", entry.flag, err)
+		}
+		if result.Kind != timeparse.Clock {
+			return fmt.Errorf("❌ %s: needs a time of day like 07:30 or 7:30am, or a solar time like sunset-30m", entry.flag)
+		}
+		lines = append(lines, fmt.Sprintf("%s %-20q → %s %s", entry.label, *entry.value, result.Clock(), when))
+		*entry.value = result.Clock()
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+	return confirm(lines, assumeYes)
+}
+
+// interpretSnooze muestra cómo se entiende un -snooze que no es una duración
+// simple ("2d", "23:30", "tomorrow 01:00") y lo confirma. El valor se pasa sin
+// cambios: el caso de uso lo interpreta igual, respecto del apagado pendiente.
+func (c *CLI) interpretSnooze(value string, assumeYes bool) error {
+	if _, err := time.ParseDuration(strings.TrimSpace(value)); err == nil {
+		return nil
+	}
+
+	if d, err := timeparse.ParseDuration(value); err == nil {
+		return confirm([]string{fmt.Sprintf("Snooze: %q → postpone the pending shutdown by %s", value, d)}, assumeYes)
+	}
+	until, err := timeparse.Parse(value, time.Now())
+	if err != nil {
+		// El caso de uso reporta el error
+		return nil
+	}
+	return confirm([]string{fmt.Sprintf("Snooze: %q → postpone the pending shutdown until %s", value, until.Describe(time.Now()))}, assumeYes)
+}
+
+// interpretSleep muestra hasta cuándo duerme un -sleep-for o -sleep-until que
+// no es una duración o una hora estricta y lo confirma
+func (c *CLI) interpretSleep(forValue, untilValue string, assumeYes bool) error {
+	now := time.Now()
+	if forValue != "" {
+		if _, err := time.ParseDuration(strings.TrimSpace(forValue)); err == nil {
+			return nil
+		}
+		d, err := timeparse.ParseDuration(forValue)
+		if err != nil {
+			return nil
+		}
+		wake := timeparse.Result{Time: now.Add(d)}
+		return confirm([]string{fmt.Sprintf("Sleep: %q → wake %s", forValue, wake.Describe(now))}, assumeYes)
+	}
+
+	if isLiteralTime(untilValue) {
+		return nil
+	}
+	wake, err := timeparse.Parse(untilValue, now)
+	if err != nil {
+		return nil
+	}
+	return confirm([]string{fmt.Sprintf("Sleep: %q → wake %s", untilValue, wake.Describe(now))}, assumeYes)
+}
+
+// confirm muestra la interpretación y, en una terminal y sin -yes, pide confirmación
+func confirm(lines []string, assumeYes bool) error {
+	for _, line := range lines {
+		fmt.Println("🕐", line)
+	}
+	if assumeYes || !isTerminal(os.Stdin) {
+		return nil
+	}
+
+	fmt.Print("Proceed? [y/N] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("❌ Cancelled, nothing changed")
+}
+
+// isLiteralTime indica si value ya está en una forma estricta (HH:MM,
+// YYYY-MM-DD HH:MM o RFC 3339) que no necesita confirmación
+func isLiteralTime(value string) bool {
+	for _, layout := range []string{"15:04", "2006-01-02 15:04", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil && t.Format(layout) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// isTerminal indica si f es una terminal interactiva (/dev/null también es
+// un dispositivo de caracteres, por eso se consulta el modo de la terminal)
+func isTerminal(f *os.File) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
\ No newline at end of file
@@ -0,0 +1,308 @@
+// internal/presentation/mqtt/controller.go
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"rtc-scheduler/internal/application/usecases"
+	"rtc-scheduler/pkg/logger"
+	mqttclient "rtc-scheduler/pkg/mqtt"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 2 * time.Minute
+)
+
+// defaultSnooze es la extensión cuando el comando snooze llega sin payload
+const defaultSnooze = "1h"
+
+// topics agrupa los tópicos derivados del prefijo configurado
+type topics struct {
+	prefix       string
+	availability string
+	status       string
+	nextEvent    string
+	result       string
+}
+
+// newTopics construye los tópicos a partir del prefijo
+func newTopics(prefix string) *topics {
+	return &topics{
+		prefix:       prefix,
+		availability: prefix + "/availability",
+		status:       prefix + "/status",
+		nextEvent:    prefix + "/next_event",
+		result:       prefix + "/result",
+	}
+}
+
+// command retorna el tópico de un comando
+func (t *topics) command(name string) string {
+	return t.prefix + "/command/" + name
+}
+
+// Controller expone el scheduler por MQTT: publica el estado y el próximo
+// evento, y traduce los mensajes de los tópicos de comando a casos de uso
+type Controller struct {
+	settingsUC *usecases.LoadMQTTSettingsUseCase
+	statusUC   *usecases.ShowStatusUseCase
+	enableUC   *usecases.EnableServiceUseCase
+	disableUC  *usecases.DisableServiceUseCase
+	scheduleUC *usecases.ReconfigureScheduleUseCase
+	snoozeUC   *usecases.SnoozeShutdownUseCase
+	lock       *usecases.CommandLock
+	logger     logger.Logger
+	hostname   string
+}
+
+// NewController crea una nueva instancia
+func NewController(
+	settingsUC *usecases.LoadMQTTSettingsUseCase,
+	statusUC *usecases.ShowStatusUseCase,
+	enableUC *usecases.EnableServiceUseCase,
+	disableUC *usecases.DisableServiceUseCase,
+	scheduleUC *usecases.ReconfigureScheduleUseCase,
+	snoozeUC *usecases.SnoozeShutdownUseCase,
+	lock *usecases.CommandLock,
+	log logger.Logger,
+) *Controller {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	return &Controller{
+		settingsUC: settingsUC,
+		statusUC:   statusUC,
+		enableUC:   enableUC,
+		disableUC:  disableUC,
+		scheduleUC: scheduleUC,
+		snoozeUC:   snoozeUC,
+		lock:       lock,
+		logger:     log,
+		hostname:   hostname,
+	}
+}
+
+// Run se conecta al broker y atiende comandos hasta que stop se cierre.
+// Si la conexión se pierde se reconecta con backoff exponencial.
+func (c *Controller) Run(stop <-chan struct{}) error {
+	output, err := c.settingsUC.Execute(&usecases.LoadMQTTSettingsInput{})
+	if err != nil {
+		return err
+	}
+	settings := output.Settings
+	t := newTopics(settings.Prefix(c.hostname))
+
+	clientID := settings.ClientID
+	if clientID == "" {
+		clientID = "rtc-scheduler-" + c.hostname
+	}
+
+	delay := minReconnectDelay
+	for {
+		client := mqttclient.NewClient(mqttclient.Options{
+			Broker:   settings.Broker,
+			ClientID: clientID,
+			Username: settings.Username,
+			Password: settings.Password,
+			Will:     &mqttclient.Message{Topic: t.availability, Payload: []byte("offline"), Retain: true},
+		})
+
+		if err := client.Connect(); err != nil {
+			c.logger.Warn("Failed to connect to MQTT broker", "broker", settings.Broker, "error", err, "retry_in", delay)
+		} else {
+			c.logger.Info("Connected to MQTT broker", "broker", settings.Broker, "prefix", t.prefix)
+			delay = minReconnectDelay
+
+			stopped, err := c.serve(client, t, settings.Discovery, settings.DiscoveryRoot(), settings.StatusInterval(), stop)
+			if stopped {
+				return nil
+			}
+			c.logger.Warn("MQTT connection lost", "error", err, "retry_in", delay)
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// serve atiende una conexión establecida. Retorna true si terminó porque se pidió detenerse.
+func (c *Controller) serve(
+	client *mqttclient.Client,
+	t *topics,
+	discovery bool,
+	discoveryPrefix string,
+	interval time.Duration,
+	stop <-chan struct{},
+) (bool, error) {
+	if err := client.Publish(t.availability, []byte("online"), true); err != nil {
+		return false, err
+	}
+
+	if discovery {
+		if err := c.publishDiscovery(client, t, discoveryPrefix); err != nil {
+			return false, err
+		}
+	}
+
+	err := client.Subscribe(t.command("+"), func(topic string, payload []byte) {
+		c.handleCommand(client, t, path.Base(topic), payload)
+	})
+	if err != nil {
+		client.Disconnect()
+		return false, err
+	}
+
+	c.publishState(client, t)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			client.Publish(t.availability, []byte("offline"), true)
+			client.Disconnect()
+			c.logger.Info("Disconnected from MQTT broker")
+			return true, nil
+		case <-client.Done():
+			return false, client.Err()
+		case <-ticker.C:
+			c.publishState(client, t)
+		}
+	}
+}
+
+// handleCommand ejecuta un comando recibido y publica el resultado y el nuevo estado
+func (c *Controller) handleCommand(client *mqttclient.Client, t *topics, command string, payload []byte) {
+	c.logger.Info("MQTT command received", "command", command, "payload", string(payload))
+
+	// Los comandos esperan a que termine cualquier otro que modifique el sistema
+	var message string
+	err := c.lock.Run("mqtt "+command, "mqtt", func() error {
+		var err error
+		message, err = c.executeCommand(command, payload)
+		return err
+	})
+	result := &resultPayload{
+		Command:   command,
+		Success:   err == nil,
+		Message:   message,
+		Timestamp: time.Now(),
+	}
+	if err != nil {
+		c.logger.Warn("MQTT command failed", "command", command, "error", err)
+		result.Message = err.Error()
+	}
+
+	c.publishJSON(client, t.result, result, false)
+	c.publishState(client, t)
+}
+
+// executeCommand traduce un comando a su caso de uso
+func (c *Controller) executeCommand(command string, payload []byte) (string, error) {
+	switch command {
+	case "enable":
+		output, err := c.enableUC.Execute(&usecases.EnableServiceInput{})
+		if err != nil {
+			return "", err
+		}
+		return output.Message, nil
+
+	case "disable":
+		output, err := c.disableUC.Execute(&usecases.DisableServiceInput{})
+		if err != nil {
+			return "", err
+		}
+		return output.Message, nil
+
+	case "snooze":
+		duration := strings.TrimSpace(string(payload))
+		if duration == "" {
+			duration = defaultSnooze
+		}
+		output, err := c.snoozeUC.Execute(&usecases.SnoozeShutdownInput{
+			Duration: duration,
+			By:       "mqtt",
+		})
+		if err != nil {
+			return "", err
+		}
+		return output.Message, nil
+
+	case "set_schedule":
+		wake, shutdown, err := parseSchedulePayload(payload)
+		if err != nil {
+			return "", err
+		}
+		output, err := c.scheduleUC.Execute(&usecases.ReconfigureScheduleInput{
+			WakeTime:     wake,
+			ShutdownTime: shutdown,
+			By:           "mqtt",
+		})
+		if err != nil {
+			return "", err
+		}
+		return output.Message, nil
+
+	default:
+		return "", fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// publishState publica el estado y el próximo evento (retenidos)
+func (c *Controller) publishState(client *mqttclient.Client, t *topics) {
+	status, err := c.statusUC.Execute(&usecases.ShowStatusInput{})
+	if err != nil {
+		c.logger.Warn("Failed to gather status for MQTT", "error", err)
+		return
+	}
+
+	c.publishJSON(client, t.status, newStatusPayload(status), true)
+	c.publishJSON(client, t.nextEvent, newNextEventPayload(status), true)
+}
+
+// publishDiscovery publica la configuración de descubrimiento de Home Assistant
+func (c *Controller) publishDiscovery(client *mqttclient.Client, t *topics, prefix string) error {
+	for _, entity := range discoveryEntities(t, c.hostname) {
+		topic := fmt.Sprintf("%s/%s/%s/config", prefix, entity.component, entity.object)
+		data, err := json.Marshal(entity.config)
+		if err != nil {
+			return err
+		}
+		if err := client.Publish(topic, data, true); err != nil {
+			return err
+		}
+	}
+
+	c.logger.Info("Published Home Assistant discovery", "prefix", prefix)
+	return nil
+}
+
+// publishJSON serializa y publica un mensaje
+func (c *Controller) publishJSON(client *mqttclient.Client, topic string, v interface{}, retain bool) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		c.logger.Warn("Failed to encode MQTT payload", "topic", topic, "error", err)
+		return
+	}
+
+	if err := client.Publish(topic, data, retain); err != nil {
+		c.logger.Warn("Failed to publish MQTT message", "topic", topic, "error", err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,210 @@
+// internal/presentation/mqtt/controller_test.go
+package mqtt
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"rtc-scheduler/internal/application/usecases"
+	"rtc-scheduler/internal/domain/entities"
+	"rtc-scheduler/internal/domain/repositories"
+	"rtc-scheduler/pkg/logger"
+	mqttclient "rtc-scheduler/pkg/mqtt"
+	"rtc-scheduler/pkg/mqtt/mqtttest"
+)
+
+type memoryConfigRepository struct{ config *entities.Config }
+
+func (r *memoryConfigRepository) Load() (*entities.Config, error) {
+	copied := *r.config
+	return &copied, nil
+}
+func (r *memoryConfigRepository) Save(c *entities.Config) error { r.config = c; return nil }
+func (r *memoryConfigRepository) Delete() error                 { r.config = nil; return nil }
+func (r *memoryConfigRepository) Exists() bool                  { return r.config != nil }
+func (r *memoryConfigRepository) CreateDefault() error          { return nil }
+
+type fakeRTC struct{ alarm time.Time }
+
+func (r *fakeRTC) SetWakeAlarm(t time.Time) error     { r.alarm = t; return nil }
+func (r *fakeRTC) GetWakeAlarm() (time.Time, error)   { return r.alarm, nil }
+func (r *fakeRTC) ClearWakeAlarm() error              { r.alarm = time.Time{}; return nil }
+func (r *fakeRTC) GetCurrentTime() (time.Time, error) { return time.Now(), nil }
+func (r *fakeRTC) IsAvailable() bool                  { return true }
+
+type fakeService struct{ enabled bool }
+
+func (s *fakeService) Install(string) error { return nil }
+func (s *fakeService) Uninstall() error     { return nil }
+func (s *fakeService) Enable() error        { s.enabled = true; return nil }
+func (s *fakeService) Disable() error       { s.enabled = false; return nil }
+func (s *fakeService) Start() error         { return nil }
+func (s *fakeService) Stop() error          { return nil }
+func (s *fakeService) IsInstalled() bool    { return true }
+func (s *fakeService) Status() (*repositories.ServiceStatus, error) {
+	return &repositories.ServiceStatus{Name: "rtc-scheduler", IsEnabled: s.enabled, IsRunning: true}, nil
+}
+
+type fakeScheduler struct{ shutdown time.Time }
+
+func (s *fakeScheduler) ScheduleShutdown(t time.Time) error { s.shutdown = t; return nil }
+func (s *fakeScheduler) CancelShutdown() error              { s.shutdown = time.Time{}; return nil }
+func (s *fakeScheduler) IsAvailable() bool                  { return true }
+func (s *fakeScheduler) Backend() string                    { return "fake" }
+func (s *fakeScheduler) ShutdownAction() entities.PowerAction {
+	return entities.PowerActionSuspend
+}
+func (s *fakeScheduler) ListScheduledJobs() ([]*repositories.ShutdownJob, error) {
+	if s.shutdown.IsZero() {
+		return nil, nil
+	}
+	return []*repositories.ShutdownJob{{ID: "1", ScheduledAt: s.shutdown, Command: "suspend"}}, nil
+}
+
+type memorySnoozeRepository struct{ state *entities.SnoozeState }
+
+func (r *memorySnoozeRepository) LoadSnooze() (*entities.SnoozeState, error) { return r.state, nil }
+func (r *memorySnoozeRepository) SaveSnooze(s *entities.SnoozeState) error   { r.state = s; return nil }
+
+func TestControllerPublishesStatusAndHandlesCommands(t *testing.T) {
+	broker := mqtttest.NewBroker()
+	defer broker.Close()
+
+	config, _ := entities.NewConfig("07:00", "23:00", true)
+	config.MQTT = &entities.MQTTConfig{Broker: broker.URL(), TopicPrefix: "test/rtc", Discovery: true}
+	configRepo := &memoryConfigRepository{config: config}
+	rtc := &fakeRTC{}
+	service := &fakeService{enabled: true}
+	scheduler := &fakeScheduler{}
+	snoozes := &memorySnoozeRepository{}
+	log := logger.NewWithLevel(logger.ErrorLevel)
+
+	controller := NewController(
+		usecases.NewLoadMQTTSettingsUseCase(configRepo, log),
+		usecases.NewShowStatusUseCase(rtc, configRepo, service, scheduler, nil, snoozes, nil, log),
+		usecases.NewEnableServiceUseCase(configRepo, service, log),
+		usecases.NewDisableServiceUseCase(configRepo, service, scheduler, rtc, nil, log),
+		usecases.NewReconfigureScheduleUseCase(configRepo, nil, rtc, scheduler, nil, nil, log),
+		usecases.NewSnoozeShutdownUseCase(configRepo, rtc, scheduler, snoozes, nil, log),
+		usecases.NewCommandLock(nil, log),
+		log,
+	)
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() { done <- controller.Run(stop) }()
+
+	// Estado inicial y disponibilidad
+	status := waitJSON(t, broker, "test/rtc/status", func(p map[string]interface{}) bool { return p["enabled"] == true })
+	if status["wake_time"] != "07:00" || status["next_wake"] == nil {
+		t.Errorf("unexpected status %v", status)
+	}
+	if msg := broker.Retained("test/rtc/availability"); msg == nil || string(msg.Payload) != "online" {
+		t.Errorf("expected retained availability online, got %v", msg)
+	}
+	if msg := broker.Retained("test/rtc/next_event"); msg == nil || !strings.Contains(string(msg.Payload), `"event":"`) {
+		t.Errorf("expected retained next_event, got %v", msg)
+	}
+
+	// Descubrimiento de Home Assistant
+	discovered := false
+	for _, msg := range broker.Published() {
+		if strings.HasPrefix(msg.Topic, "homeassistant/button/") && strings.HasSuffix(msg.Topic, "/disable/config") {
+			discovered = msg.Retain && strings.Contains(string(msg.Payload), `"command_topic":"test/rtc/command/disable"`)
+		}
+	}
+	if !discovered {
+		t.Error("expected retained Home Assistant discovery for the disable button")
+	}
+
+	// Comandos
+	broker.Publish("test/rtc/command/disable", nil, false)
+	result := waitJSON(t, broker, "test/rtc/result", func(p map[string]interface{}) bool { return p["command"] == "disable" })
+	if result["success"] != true || service.enabled || configRepo.config.Enabled {
+		t.Errorf("disable command not applied: %v", result)
+	}
+	waitJSON(t, broker, "test/rtc/status", func(p map[string]interface{}) bool { return p["enabled"] == false })
+
+	// Con el servicio deshabilitado el horario se guarda pero no se arma
+	broker.Publish("test/rtc/command/set_schedule", []byte("06:45 22:00"), false)
+	result = waitJSON(t, broker, "test/rtc/result", func(p map[string]interface{}) bool { return p["command"] == "set_schedule" })
+	if result["success"] != true || !rtc.alarm.IsZero() {
+		t.Errorf("set_schedule while disabled: %v (alarm %s)", result, rtc.alarm)
+	}
+
+	broker.Publish("test/rtc/command/enable", nil, false)
+	waitJSON(t, broker, "test/rtc/status", func(p map[string]interface{}) bool { return p["enabled"] == true })
+
+	broker.Publish("test/rtc/command/set_schedule", []byte("06:30 22:15"), false)
+	result = waitJSON(t, broker, "test/rtc/result", func(p map[string]interface{}) bool {
+		return p["command"] == "set_schedule" && strings.Contains(p["message"].(string), "to 06:30-22:15")
+	})
+	if result["success"] != true || rtc.alarm.Format("15:04") != "06:30" || scheduler.shutdown.Format("15:04") != "22:15" {
+		t.Errorf("set_schedule not applied: %v (alarm %s, shutdown %s)", result, rtc.alarm, scheduler.shutdown)
+	}
+	if saved, _ := configRepo.Load(); saved.WakeTime != "06:30" || saved.ShutdownTime != "22:15" {
+		t.Errorf("set_schedule not saved: %s %s", saved.WakeTime, saved.ShutdownTime)
+	}
+
+	broker.Publish("test/rtc/command/set_schedule", []byte("tomorrow"), false)
+	waitJSON(t, broker, "test/rtc/result", func(p map[string]interface{}) bool {
+		return p["command"] == "set_schedule" && p["success"] == false
+	})
+
+	// Snooze del apagado recién programado
+	broker.Publish("test/rtc/command/snooze", []byte("1h"), false)
+	result = waitJSON(t, broker, "test/rtc/result", func(p map[string]interface{}) bool { return p["command"] == "snooze" })
+	if result["success"] != true || scheduler.shutdown.Format("15:04") != "23:15" || snoozes.state.By != "mqtt" {
+		t.Errorf("snooze not applied: %v (shutdown %s)", result, scheduler.shutdown)
+	}
+
+	// Parada ordenada
+	close(stop)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("controller did not stop")
+	}
+	if _, ok := broker.WaitFor("test/rtc/availability", 2*time.Second, func(msg *mqttclient.Message) bool {
+		return msg.Retain && string(msg.Payload) == "offline"
+	}); !ok {
+		t.Error("expected retained availability offline on stop")
+	}
+}
+
+func TestParseSchedulePayload(t *testing.T) {
+	for _, payload := range []string{"07:00 23:00", "07:00,23:00", `{"wake":"07:00","shutdown":"23:00"}`} {
+		wake, shutdown, err := parseSchedulePayload([]byte(payload))
+		if err != nil || wake != "07:00" || shutdown != "23:00" {
+			t.Errorf("parseSchedulePayload(%q) = %q, %q, %v", payload, wake, shutdown, err)
+		}
+	}
+
+	if _, _, err := parseSchedulePayload([]byte("07:00")); err == nil {
+		t.Error("expected error for a single time")
+	}
+}
+
+// waitJSON espera un mensaje JSON en el tópico que cumpla match
+func waitJSON(t *testing.T, broker *mqtttest.Broker, topic string, match func(map[string]interface{}) bool) map[string]interface{} {
+	t.Helper()
+
+	var found map[string]interface{}
+	_, ok := broker.WaitFor(topic, 3*time.Second, func(msg *mqttclient.Message) bool {
+		var payload map[string]interface{}
+		if json.Unmarshal(msg.Payload, &payload) != nil || !match(payload) {
+			return false
+		}
+		found = payload
+		return true
+	})
+	if !ok {
+		t.Fatalf("no matching message on %s", topic)
+	}
+	return found
+}
\ No newline at end of file
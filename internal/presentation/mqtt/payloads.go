@@ -0,0 +1,182 @@
+// internal/presentation/mqtt/payloads.go
+package mqtt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"rtc-scheduler/internal/application/usecases"
+)
+
+var errInvalidSchedulePayload = errors.New(`set_schedule expects "HH:MM HH:MM" or {"wake":"HH:MM","shutdown":"HH:MM"}`)
+
+// statusPayload se publica retenido en <prefix>/status
+type statusPayload struct {
+	Enabled          bool       `json:"enabled"`
+	ServiceInstalled bool       `json:"service_installed"`
+	ServiceRunning   bool       `json:"service_running"`
+	WakeTime         string     `json:"wake_time"`
+	ShutdownTime     string     `json:"shutdown_time"`
+	RTCWakeAlarm     string     `json:"rtc_wake_alarm"`
+	NextWake         *time.Time `json:"next_wake"`
+	NextAlarm        *time.Time `json:"next_alarm"`
+	NextShutdown     *time.Time `json:"next_shutdown"`
+	LastWake         string     `json:"last_wake,omitempty"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// nextEventPayload se publica retenido en <prefix>/next_event
+type nextEventPayload struct {
+	Event string     `json:"event"`
+	Time  *time.Time `json:"time"`
+}
+
+// resultPayload se publica en <prefix>/result tras cada comando
+type resultPayload struct {
+	Command   string    `json:"command"`
+	Success   bool      `json:"success"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// schedulePayload es la forma JSON del comando set_schedule
+type schedulePayload struct {
+	Wake     string `json:"wake"`
+	Shutdown string `json:"shutdown"`
+}
+
+// newStatusPayload convierte la salida del caso de uso de estado
+func newStatusPayload(status *usecases.ShowStatusOutput) *statusPayload {
+	payload := &statusPayload{
+		Enabled:          status.Enabled,
+		ServiceInstalled: status.ServiceInstalled,
+		ServiceRunning:   status.ServiceRunning,
+		WakeTime:         status.WakeTime,
+		ShutdownTime:     status.ShutdownTime,
+		RTCWakeAlarm:     status.RTCWakeAlarm,
+		NextWake:         optionalTime(status.NextWake),
+		NextAlarm:        optionalTime(status.NextAlarm),
+		NextShutdown:     optionalTime(status.NextShutdown),
+		UpdatedAt:        time.Now(),
+	}
+	if status.LastWake != nil {
+		payload.LastWake = status.LastWake.Message
+	}
+	return payload
+}
+
+// newNextEventPayload retorna el próximo evento (encendido o apagado)
+func newNextEventPayload(status *usecases.ShowStatusOutput) *nextEventPayload {
+	switch {
+	case status.NextWake.IsZero() && status.NextShutdown.IsZero():
+		return &nextEventPayload{Event: "none"}
+	case status.NextShutdown.IsZero() || (!status.NextWake.IsZero() && status.NextWake.Before(status.NextShutdown)):
+		return &nextEventPayload{Event: "wake", Time: optionalTime(status.NextWake)}
+	default:
+		return &nextEventPayload{Event: "shutdown", Time: optionalTime(status.NextShutdown)}
+	}
+}
+
+// parseSchedulePayload acepta "07:00 23:00", "07:00,23:00" o JSON
+func parseSchedulePayload(payload []byte) (wake, shutdown string, err error) {
+	text := strings.TrimSpace(string(payload))
+
+	if strings.HasPrefix(text, "{") {
+		var p schedulePayload
+		if err := json.Unmarshal([]byte(text), &p); err != nil || p.Wake == "" || p.Shutdown == "" {
+			return "", "", errInvalidSchedulePayload
+		}
+		return p.Wake, p.Shutdown, nil
+	}
+
+	parts := strings.FieldsFunc(text, func(r rune) bool { return r == ' ' || r == ',' })
+	if len(parts) != 2 {
+		return "", "", errInvalidSchedulePayload
+	}
+	return parts[0], parts[1], nil
+}
+
+// nonIDChars son los caracteres no válidos en identificadores de Home Assistant
+var nonIDChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// discoveryEntity es una entidad publicada en el descubrimiento de Home Assistant
+type discoveryEntity struct {
+	component string
+	object    string
+	config    map[string]interface{}
+}
+
+// discoveryEntities construye las entidades de Home Assistant para este equipo
+func discoveryEntities(t *topics, hostname string) []*discoveryEntity {
+	node := nonIDChars.ReplaceAllString(strings.ToLower(hostname), "_")
+	device := map[string]interface{}{
+		"identifiers":  []string{"rtc_scheduler_" + node},
+		"name":         "RTC Scheduler " + hostname,
+		"manufacturer": "rtc-scheduler",
+		"model":        "RTC Scheduler",
+	}
+
+	entities := []*discoveryEntity{
+		{"binary_sensor", "enabled", map[string]interface{}{
+			"name":           "Schedule enabled",
+			"state_topic":    t.status,
+			"value_template": "{{ 'ON' if value_json.enabled else 'OFF' }}",
+		}},
+		{"sensor", "next_wake", map[string]interface{}{
+			"name":           "Next wake",
+			"device_class":   "timestamp",
+			"state_topic":    t.status,
+			"value_template": "{{ value_json.next_wake }}",
+		}},
+		{"sensor", "next_shutdown", map[string]interface{}{
+			"name":           "Next shutdown",
+			"device_class":   "timestamp",
+			"state_topic":    t.status,
+			"value_template": "{{ value_json.next_shutdown }}",
+		}},
+		{"button", "enable", map[string]interface{}{
+			"name":          "Enable schedule",
+			"command_topic": t.command("enable"),
+			"payload_press": "enable",
+		}},
+		{"button", "disable", map[string]interface{}{
+			"name":          "Disable schedule",
+			"command_topic": t.command("disable"),
+			"payload_press": "disable",
+		}},
+		{"button", "snooze", map[string]interface{}{
+			"name":          "Snooze shutdown 1h",
+			"command_topic": t.command("snooze"),
+			"payload_press": "1h",
+		}},
+		{"text", "schedule", map[string]interface{}{
+			"name":           "Schedule (wake shutdown)",
+			"command_topic":  t.command("set_schedule"),
+			"state_topic":    t.status,
+			"value_template": "{{ value_json.wake_time }} {{ value_json.shutdown_time }}",
+			"pattern":        `^\d{1,2}:\d{2}[ ,]\d{1,2}:\d{2}$`,
+		}},
+	}
+
+	for _, e := range entities {
+		e.config["unique_id"] = fmt.Sprintf("rtc_scheduler_%s_%s", node, e.object)
+		e.config["object_id"] = fmt.Sprintf("rtc_scheduler_%s_%s", node, e.object)
+		e.config["availability_topic"] = t.availability
+		e.config["device"] = device
+		e.object = node + "/" + e.object
+	}
+
+	return entities
+}
+
+// optionalTime convierte un tiempo cero en nil (null en JSON)
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
\ No newline at end of file
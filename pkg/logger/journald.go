@@ -0,0 +1,236 @@
+// pkg/logger/journald.go
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+const (
+	// DefaultJournalSocket es el socket nativo de systemd-journald
+	DefaultJournalSocket = "/run/systemd/journal/socket"
+	// EnvJournalStream la define systemd cuando stdout/stderr están conectados al journal
+	EnvJournalStream = "JOURNAL_STREAM"
+
+	journalFieldPrefix = "RTC_"
+	journalIdentifier  = "rtc-scheduler"
+	maxJournalFieldLen = 64
+)
+
+// journalPriorities mapea niveles a prioridades syslog (PRIORITY=)
+var journalPriorities = map[Level]int{
+	DebugLevel: 7, // debug
+	InfoLevel:  6, // info
+	WarnLevel:  4, // warning
+	ErrorLevel: 3, // err
+	FatalLevel: 2, // crit
+}
+
+// JournaldLogger envía cada mensaje al journal usando el protocolo nativo,
+// de modo que los argumentos clave/valor se conservan como campos
+// (p.ej. "wake_time" se registra como RTC_WAKE_TIME=...).
+type JournaldLogger struct {
+	mu     sync.Mutex
+	level  Level
+	conn   *net.UnixConn
+	socket *net.UnixAddr
+}
+
+// Verificar que implementa la interfaz
+var _ Logger = (*JournaldLogger)(nil)
+
+// NewJournald crea un logger conectado al socket por defecto del journal
+func NewJournald(level Level) (*JournaldLogger, error) {
+	return NewJournaldWithSocket(DefaultJournalSocket, level)
+}
+
+// NewJournaldWithSocket crea un logger que envía datagramas al socket indicado
+func NewJournaldWithSocket(socketPath string, level Level) (*JournaldLogger, error) {
+	addr := &net.UnixAddr{Name: socketPath, Net: "unixgram"}
+
+	// Socket sin conectar: permite reenviar a la misma dirección si journald se reinicia
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create journal socket: %w", err)
+	}
+
+	if _, err := os.Stat(socketPath); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("journal socket not available: %w", err)
+	}
+
+	return &JournaldLogger{
+		level:  level,
+		conn:   conn,
+		socket: addr,
+	}, nil
+}
+
+// Debug registra un mensaje de nivel DEBUG
+func (l *JournaldLogger) Debug(msg string, args ...interface{}) {
+	if l.level <= DebugLevel {
+		l.send(DebugLevel, msg, args...)
+	}
+}
+
+// Info registra un mensaje de nivel INFO
+func (l *JournaldLogger) Info(msg string, args ...interface{}) {
+	if l.level <= InfoLevel {
+		l.send(InfoLevel, msg, args...)
+	}
+}
+
+// Warn registra un mensaje de nivel WARN
+func (l *JournaldLogger) Warn(msg string, args ...interface{}) {
+	if l.level <= WarnLevel {
+		l.send(WarnLevel, msg, args...)
+	}
+}
+
+// Error registra un mensaje de nivel ERROR
+func (l *JournaldLogger) Error(msg string, args ...interface{}) {
+	if l.level <= ErrorLevel {
+		l.send(ErrorLevel, msg, args...)
+	}
+}
+
+// Fatal registra un mensaje de nivel FATAL y termina el programa
+func (l *JournaldLogger) Fatal(msg string, args ...interface{}) {
+	l.send(FatalLevel, msg, args...)
+	os.Exit(1)
+}
+
+// SetLevel cambia el nivel de logging
+func (l *JournaldLogger) SetLevel(level Level) {
+	l.level = level
+}
+
+// Close cierra el socket
+func (l *JournaldLogger) Close() error {
+	return l.conn.Close()
+}
+
+// send construye el datagrama y lo envía. Si el journal no está disponible el
+// mensaje se escribe en stderr para no perderlo.
+func (l *JournaldLogger) send(level Level, msg string, args ...interface{}) {
+	payload := encodeJournalEntry(level, msg, args...)
+
+	l.mu.Lock()
+	err := l.write(payload)
+	l.mu.Unlock()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s %s\n", levelNames[level], msg, formatKeyValues(args...))
+	}
+}
+
+// write envía el payload; los mensajes que no caben en un datagrama se pasan
+// como descriptor de archivo, tal como indica el protocolo nativo del journal.
+func (l *JournaldLogger) write(payload []byte) error {
+	_, err := l.conn.WriteToUnix(payload, l.socket)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return err
+	}
+
+	file, err := os.CreateTemp("/dev/shm", "rtc-scheduler-journal-")
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	os.Remove(file.Name())
+
+	if _, err := file.Write(payload); err != nil {
+		return err
+	}
+
+	rights := syscall.UnixRights(int(file.Fd()))
+	_, _, err = l.conn.WriteMsgUnix(nil, rights, l.socket)
+	return err
+}
+
+// encodeJournalEntry serializa el mensaje en el formato nativo del journal
+func encodeJournalEntry(level Level, msg string, args ...interface{}) []byte {
+	var buf bytes.Buffer
+
+	writeJournalField(&buf, "MESSAGE", msg)
+	writeJournalField(&buf, "PRIORITY", fmt.Sprint(journalPriorities[level]))
+	writeJournalField(&buf, "SYSLOG_IDENTIFIER", journalIdentifier)
+
+	for i := 0; i+1 < len(args); i += 2 {
+		name := journalFieldName(fmt.Sprint(args[i]))
+		writeJournalField(&buf, name, fmt.Sprint(jsonFieldValue(args[i+1])))
+	}
+
+	return buf.Bytes()
+}
+
+// writeJournalField escribe NAME=value, o el formato binario si el valor tiene saltos de línea
+func writeJournalField(buf *bytes.Buffer, name, value string) {
+	if !strings.Contains(value, "\n") {
+		buf.WriteString(name)
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+
+	buf.WriteString(name)
+	buf.WriteByte('\n')
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalFieldName convierte una clave en un nombre de campo válido:
+// mayúsculas, dígitos y "_", con prefijo RTC_ y como máximo 64 caracteres.
+func journalFieldName(key string) string {
+	var b strings.Builder
+	b.WriteString(journalFieldPrefix)
+
+	for _, r := range strings.ToUpper(key) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+
+	name := b.String()
+	if len(name) > maxJournalFieldLen {
+		name = name[:maxJournalFieldLen]
+	}
+	return name
+}
+
+// JournalStreamActive verifica si stdout o stderr están conectados al journal.
+// Como JOURNAL_STREAM se hereda entre procesos, se compara el "device:inode"
+// indicado con el de nuestros descriptores, según recomienda systemd.
+func JournalStreamActive() bool {
+	stream := os.Getenv(EnvJournalStream)
+	if stream == "" {
+		return false
+	}
+
+	for _, file := range []*os.File{os.Stderr, os.Stdout} {
+		info, err := file.Stat()
+		if err != nil {
+			continue
+		}
+		if st, ok := info.Sys().(*syscall.Stat_t); ok && fmt.Sprintf("%d:%d", st.Dev, st.Ino) == stream {
+			return true
+		}
+	}
+
+	return false
+}
\ No newline at end of file
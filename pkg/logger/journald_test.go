@@ -0,0 +1,79 @@
+// pkg/logger/journald_test.go
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJournaldLoggerSendsStructuredFields(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "journal.sock")
+	server, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	l, err := NewJournaldWithSocket(socketPath, InfoLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.Debug("hidden")
+	l.Warn("Wake missed", "wake_time", "08:00", "armed-for", time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC), "detail", "line1\nline2")
+
+	server.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, 4096)
+	n, err := server.Read(buf)
+	if err != nil {
+		t.Fatalf("reading datagram: %v", err)
+	}
+	payload := buf[:n]
+
+	for _, want := range []string{
+		"MESSAGE=Wake missed\n",
+		"PRIORITY=4\n",
+		"SYSLOG_IDENTIFIER=rtc-scheduler\n",
+		"RTC_WAKE_TIME=08:00\n",
+		"RTC_ARMED_FOR=2025-03-10T08:00:00Z\n",
+	} {
+		if !bytes.Contains(payload, []byte(want)) {
+			t.Errorf("payload missing %q:\n%q", want, payload)
+		}
+	}
+
+	var multiline bytes.Buffer
+	multiline.WriteString("RTC_DETAIL\n")
+	binary.Write(&multiline, binary.LittleEndian, uint64(len("line1\nline2")))
+	multiline.WriteString("line1\nline2\n")
+	if !bytes.Contains(payload, multiline.Bytes()) {
+		t.Errorf("multi-line field not binary encoded:\n%q", payload)
+	}
+
+	if bytes.Contains(payload, []byte("hidden")) {
+		t.Error("debug message should be filtered at info level")
+	}
+}
+
+func TestNewJournaldWithMissingSocket(t *testing.T) {
+	if _, err := NewJournaldWithSocket(filepath.Join(t.TempDir(), "missing.sock"), InfoLevel); err == nil {
+		t.Error("expected error when the journal socket does not exist")
+	}
+}
+
+func TestJournalFieldName(t *testing.T) {
+	for key, want := range map[string]string{
+		"wake_time": "RTC_WAKE_TIME",
+		"run-id":    "RTC_RUN_ID",
+		"rtc.dev":   "RTC_RTC_DEV",
+	} {
+		if got := journalFieldName(key); got != want {
+			t.Errorf("journalFieldName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
\ No newline at end of file
@@ -2,6 +2,8 @@
 package logger
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -39,6 +41,8 @@ type Logger interface {
 // SimpleLogger es una implementación simple de Logger
 type SimpleLogger struct {
 	level  Level
+	format Format
+	color  bool
 	logger *log.Logger
 }
 
@@ -46,6 +50,8 @@ type SimpleLogger struct {
 func New() Logger {
 	return &SimpleLogger{
 		level:  InfoLevel,
+		format: TextFormat,
+		color:  isTerminal(os.Stdout),
 		logger: log.New(os.Stdout, "", 0),
 	}
 }
@@ -54,6 +60,8 @@ func New() Logger {
 func NewWithLevel(level Level) Logger {
 	return &SimpleLogger{
 		level:  level,
+		format: TextFormat,
+		color:  isTerminal(os.Stdout),
 		logger: log.New(os.Stdout, "", 0),
 	}
 }
@@ -94,6 +102,11 @@ func (l *SimpleLogger) Fatal(msg string, args ...interface{}) {
 
 // log formatea y registra un mensaje
 func (l *SimpleLogger) log(level Level, msg string, args ...interface{}) {
+	if l.format == JSONFormat {
+		l.logger.Println(l.formatJSON(level, msg, args...))
+		return
+	}
+
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
 	levelName := levelNames[level]
 	
@@ -113,6 +126,11 @@ func (l *SimpleLogger) log(level Level, msg string, args ...interface{}) {
 
 // formatArgs formatea argumentos adicionales como pares clave=valor
 func (l *SimpleLogger) formatArgs(args ...interface{}) string {
+	return formatKeyValues(args...)
+}
+
+// formatKeyValues formatea pares clave/valor como "k1=v1 k2=v2"
+func formatKeyValues(args ...interface{}) string {
 	if len(args) == 0 {
 		return ""
 	}
@@ -129,8 +147,62 @@ func (l *SimpleLogger) formatArgs(args ...interface{}) string {
 	return result
 }
 
+// formatJSON codifica el mensaje como un objeto JSON de una sola línea.
+// Los argumentos clave/valor se agregan como campos en el orden recibido.
+func (l *SimpleLogger) formatJSON(level Level, msg string, args ...interface{}) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`{"time":`)
+	writeJSONValue(&buf, time.Now().Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSONValue(&buf, levelKeys[level])
+	buf.WriteString(`,"msg":`)
+	writeJSONValue(&buf, msg)
+
+	for i := 0; i+1 < len(args); i += 2 {
+		buf.WriteByte(',')
+		writeJSONValue(&buf, fmt.Sprint(args[i]))
+		buf.WriteByte(':')
+		writeJSONValue(&buf, jsonFieldValue(args[i+1]))
+	}
+
+	buf.WriteByte('}')
+	return buf.String()
+}
+
+// jsonFieldValue adapta valores que no se serializan bien por sí solos
+func jsonFieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case error:
+		return v.Error()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
+
+// writeJSONValue serializa un valor; si no es serializable usa su representación textual
+func writeJSONValue(buf *bytes.Buffer, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Write(data)
+}
+
 // colorize agrega colores ANSI al nivel de log
 func (l *SimpleLogger) colorize(level Level, text string) string {
+	if !l.color {
+		return text
+	}
+
 	colors := map[Level]string{
 		DebugLevel: "\033[36m", // Cyan
 		InfoLevel:  "\033[32m", // Green
@@ -0,0 +1,195 @@
+// pkg/logger/logger_test.go
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	l := &SimpleLogger{level: InfoLevel, format: JSONFormat, logger: log.New(&buf, "", 0)}
+
+	l.Debug("hidden")
+	l.Warn("Failed to clear alarm", "error", errors.New("boom"), "attempt", 2, "delay", 3*time.Second)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d: %q", len(lines), buf.String())
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("invalid JSON %q: %v", lines[0], err)
+	}
+
+	want := map[string]interface{}{
+		"level":   "warn",
+		"msg":     "Failed to clear alarm",
+		"error":   "boom",
+		"attempt": float64(2),
+		"delay":   "3s",
+	}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("%s = %v, want %v", key, record[key], value)
+		}
+	}
+	if _, err := time.Parse(time.RFC3339Nano, record["time"].(string)); err != nil {
+		t.Errorf("invalid time field: %v", err)
+	}
+}
+
+func TestTextFormatWithoutColor(t *testing.T) {
+	var buf bytes.Buffer
+	l := &SimpleLogger{level: InfoLevel, format: TextFormat, logger: log.New(&buf, "", 0)}
+
+	l.Info("Schedule configured", "wake_time", "08:00")
+
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("unexpected ANSI codes in %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "INFO Schedule configured wake_time=08:00") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestWithAddsFields(t *testing.T) {
+	var buf bytes.Buffer
+	base := &SimpleLogger{level: InfoLevel, format: TextFormat, logger: log.New(&buf, "", 0)}
+
+	l := With(With(base, "run_id", "abc123"), "step", "arm")
+	l.Info("Step completed", "duration", "5ms")
+
+	if !strings.Contains(buf.String(), "Step completed run_id=abc123 step=arm duration=5ms") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for input, want := range map[string]Level{"debug": DebugLevel, "INFO": InfoLevel, "warning": WarnLevel, " error ": ErrorLevel} {
+		got, err := ParseLevel(input)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
+
+func TestRotatingFileRotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.log")
+	file, err := NewRotatingFile(path, 20, time.Hour, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	for _, line := range []string{"first line 1\n", "second line\n", "third line\n", "fourth line\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertFile(t, path, "fourth line\n")
+	assertFile(t, path+".1", "third line\n")
+	assertFile(t, path+".2", "second line\n")
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected at most 2 backups, found %s.3", path)
+	}
+}
+
+func TestRotatingFileRotatesByAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.log")
+	if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Creado hace dos días pero escrito hace un momento, como un log en uso
+	created := time.Now().Add(-48 * time.Hour).Format(time.RFC3339)
+	if err := os.WriteFile(path+".created", []byte(created+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := NewRotatingFile(path, 1024, 24*time.Hour, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	file.Write([]byte("new\n"))
+
+	assertFile(t, path, "new\n")
+	assertFile(t, path+".1", "old\n")
+
+	// La fecha de creación se renueva con el archivo nuevo
+	file.Write([]byte("newer\n"))
+	assertFile(t, path, "new\nnewer\n")
+}
+
+func TestRotatingFileAgeStartsWithoutCreationTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.log")
+	if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	os.Chtimes(path, old, old)
+
+	file, err := NewRotatingFile(path, 1024, 24*time.Hour, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	// Un log de una versión anterior empieza a contar al abrirlo
+	file.Write([]byte("new\n"))
+	assertFile(t, path, "old\nnew\n")
+	if _, err := os.Stat(path + ".created"); err != nil {
+		t.Errorf("creation time not recorded: %v", err)
+	}
+}
+
+func TestRotatingFileReopensAfterAnotherProcessRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rtc-scheduler.log")
+	first, err := NewRotatingFile(path, 30, time.Hour, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+	second, err := NewRotatingFile(path, 30, time.Hour, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+
+	first.Write([]byte("first line 1\n"))
+	second.Write([]byte("second\n"))
+	// first rota; second debe seguir el archivo nuevo en lugar de rotar otra vez
+	first.Write([]byte("third line\n"))
+	second.Write([]byte("fourth line\n"))
+
+	assertFile(t, path+".1", "first line 1\nsecond\n")
+	assertFile(t, path, "third line\nfourth line\n")
+	if _, err := os.Stat(path + ".2"); !os.IsNotExist(err) {
+		t.Errorf("log rotated twice: %s.2 exists", path)
+	}
+}
+
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	if string(data) != want {
+		t.Errorf("%s = %q, want %q", filepath.Base(path), data, want)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,164 @@
+// pkg/logger/options.go
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// Format representa el formato de salida del logger
+type Format int
+
+const (
+	TextFormat Format = iota
+	JSONFormat
+)
+
+// Variables de entorno reconocidas por OptionsFromEnv
+const (
+	EnvLevel  = "RTC_SCHEDULER_LOG_LEVEL"
+	EnvFormat = "RTC_SCHEDULER_LOG_FORMAT"
+	EnvOutput = "RTC_SCHEDULER_LOG_OUTPUT"
+)
+
+// Valores por defecto de rotación del archivo de log
+const (
+	DefaultMaxSize    = 10 * 1024 * 1024 // 10 MiB
+	DefaultMaxAge     = 7 * 24 * time.Hour
+	DefaultMaxBackups = 5
+)
+
+// levelKeys son los nombres de nivel usados en JSON y al parsear
+var levelKeys = map[Level]string{
+	DebugLevel: "debug",
+	InfoLevel:  "info",
+	WarnLevel:  "warn",
+	ErrorLevel: "error",
+	FatalLevel: "fatal",
+}
+
+// Options configura un logger creado con NewWithOptions
+type Options struct {
+	Level  Level
+	Format Format
+
+	// Output es "stdout", "stderr", "journald" o la ruta de un archivo (vacío = stdout)
+	Output string
+
+	// Rotación, solo aplica cuando Output es un archivo (0 = valor por defecto)
+	MaxSize    int64
+	MaxAge     time.Duration
+	MaxBackups int
+}
+
+// DefaultOptions retorna la configuración equivalente a New()
+func DefaultOptions() Options {
+	return Options{
+		Level:  InfoLevel,
+		Format: TextFormat,
+		Output: "stdout",
+	}
+}
+
+// NewWithOptions crea un logger según las opciones indicadas.
+// Los colores solo se usan en formato texto y cuando la salida es una terminal.
+func NewWithOptions(opts Options) (Logger, error) {
+	var writer io.Writer
+	color := false
+
+	switch opts.Output {
+	case "", "stdout":
+		writer = os.Stdout
+		color = isTerminal(os.Stdout)
+	case "stderr":
+		writer = os.Stderr
+		color = isTerminal(os.Stderr)
+	case "journald":
+		return NewJournald(opts.Level)
+	default:
+		file, err := NewRotatingFile(opts.Output, opts.MaxSize, opts.MaxAge, opts.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		writer = file
+	}
+
+	return &SimpleLogger{
+		level:  opts.Level,
+		format: opts.Format,
+		color:  color && opts.Format == TextFormat,
+		logger: log.New(writer, "", 0),
+	}, nil
+}
+
+// OptionsFromEnv aplica sobre opts las variables de entorno definidas.
+// Si el proceso corre bajo systemd con la salida conectada al journal
+// (JOURNAL_STREAM) y opts sigue apuntando a stdout, se usa el protocolo nativo.
+func OptionsFromEnv(opts Options) (Options, error) {
+	if value := os.Getenv(EnvLevel); value != "" {
+		level, err := ParseLevel(value)
+		if err != nil {
+			return opts, fmt.Errorf("%s: %w", EnvLevel, err)
+		}
+		opts.Level = level
+	}
+
+	if value := os.Getenv(EnvFormat); value != "" {
+		format, err := ParseFormat(value)
+		if err != nil {
+			return opts, fmt.Errorf("%s: %w", EnvFormat, err)
+		}
+		opts.Format = format
+	}
+
+	if value := os.Getenv(EnvOutput); value != "" {
+		opts.Output = value
+	} else if (opts.Output == "" || opts.Output == "stdout") && JournalStreamActive() {
+		if _, err := os.Stat(DefaultJournalSocket); err == nil {
+			opts.Output = "journald"
+		}
+	}
+
+	return opts, nil
+}
+
+// ParseLevel convierte un nombre de nivel ("debug", "info", "warn", ...) en Level
+func ParseLevel(value string) (Level, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "warning" {
+		return WarnLevel, nil
+	}
+
+	for level, key := range levelKeys {
+		if key == value {
+			return level, nil
+		}
+	}
+
+	return InfoLevel, fmt.Errorf("unknown log level %q", value)
+}
+
+// ParseFormat convierte "text" o "json" en Format
+func ParseFormat(value string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "text":
+		return TextFormat, nil
+	case "json":
+		return JSONFormat, nil
+	default:
+		return TextFormat, fmt.Errorf("unknown log format %q (use text or json)", value)
+	}
+}
+
+// isTerminal verifica si el archivo es una terminal (dispositivo de caracteres)
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
\ No newline at end of file
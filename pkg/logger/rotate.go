@@ -0,0 +1,218 @@
+// pkg/logger/rotate.go
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// RotatingFile es un io.Writer que escribe en un archivo y lo rota cuando
+// supera un tamaño máximo o una antigüedad máxima. Los archivos rotados se
+// renombran como <ruta>.1, <ruta>.2, ... conservando como máximo maxBackups.
+//
+// La antigüedad se mide desde la creación del archivo, guardada en
+// <ruta>.created (la fecha de modificación de un log en uso es siempre
+// reciente). Ese archivo es también el lock que impide que dos procesos que
+// escriben el mismo log lo roten a la vez.
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	file      *os.File
+	size      int64
+	createdAt time.Time
+}
+
+// NewRotatingFile abre (o crea) el archivo de log. Valores en cero usan los valores por defecto.
+func NewRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*RotatingFile, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	if maxBackups <= 0 {
+		maxBackups = DefaultMaxBackups
+	}
+
+	r := &RotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Write escribe p, rotando antes si corresponde
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	// Otro proceso que escribe el mismo log pudo agrandarlo o rotarlo
+	if err := r.refresh(); err != nil {
+		return 0, err
+	}
+
+	if r.due(len(p)) {
+		if err := r.rotate(len(p)); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close cierra el archivo actual
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// due indica si hay que rotar antes de escribir next bytes más
+func (r *RotatingFile) due(next int) bool {
+	return r.size > 0 && (r.size+int64(next) > r.maxSize || time.Since(r.createdAt) > r.maxAge)
+}
+
+// open abre el archivo en modo append y lee su fecha de creación. Un log sin
+// fecha (vacío o de una versión anterior) empieza a contar desde ahora.
+func (r *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+	created, err := r.readCreated()
+	switch {
+	case r.size == 0 || os.IsNotExist(err):
+		r.createdAt = time.Now()
+		r.writeCreated(r.createdAt)
+	case err != nil:
+		// Otro proceso la está escribiendo: no pisarla
+		r.createdAt = time.Now()
+	default:
+		r.createdAt = created
+	}
+
+	return nil
+}
+
+// rotate desplaza los respaldos (.1 -> .2, ...), renombra el archivo actual a .1
+// y abre uno nuevo. El respaldo más antiguo que excede maxBackups se elimina.
+// Si otro proceso rotó mientras tanto, solo se reabre el archivo nuevo.
+func (r *RotatingFile) rotate(next int) error {
+	lock, err := os.OpenFile(r.createdPath(), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to lock log file: %w", err)
+	}
+	defer lock.Close()
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock log file: %w", err)
+	}
+	defer syscall.Flock(int(lock.Fd()), syscall.LOCK_UN)
+
+	if err := r.refresh(); err != nil {
+		return err
+	}
+	if !r.due(next) {
+		return nil
+	}
+
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	r.file = nil
+
+	os.Remove(r.backupPath(r.maxBackups))
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		os.Rename(r.backupPath(i), r.backupPath(i+1))
+	}
+
+	if err := os.Rename(r.path, r.backupPath(1)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	return r.open()
+}
+
+// refresh actualiza el tamaño con lo que escribieron otros procesos y, si el
+// archivo abierto ya no es el de la ruta (otro proceso lo rotó), abre el nuevo
+func (r *RotatingFile) refresh() error {
+	opened, err := r.file.Stat()
+	if err == nil {
+		current, err := os.Stat(r.path)
+		if err == nil && os.SameFile(opened, current) {
+			r.size = opened.Size()
+			return nil
+		}
+	}
+
+	r.file.Close()
+	r.file = nil
+	return r.open()
+}
+
+// createdPath retorna la ruta del archivo con la fecha de creación del log
+func (r *RotatingFile) createdPath() string {
+	return r.path + ".created"
+}
+
+// readCreated retorna la fecha de creación guardada
+func (r *RotatingFile) readCreated() (time.Time, error) {
+	data, err := os.ReadFile(r.createdPath())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+}
+
+// writeCreated guarda la fecha de creación del log. Un fallo solo retrasa la
+// rotación por antigüedad: el log sigue funcionando.
+func (r *RotatingFile) writeCreated(created time.Time) {
+	os.WriteFile(r.createdPath(), []byte(created.Format(time.RFC3339)+"\n"), 0644)
+}
+
+// backupPath retorna la ruta del respaldo número n
+func (r *RotatingFile) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", r.path, n)
+}
\ No newline at end of file
@@ -0,0 +1,49 @@
+// pkg/logger/with.go
+package logger
+
+// fieldLogger agrega pares clave/valor fijos a cada mensaje del logger base
+type fieldLogger struct {
+	base   Logger
+	fields []interface{}
+}
+
+// With retorna un logger que incluye los pares clave/valor indicados en cada
+// mensaje, p.ej. un identificador de ejecución para correlacionar registros.
+func With(l Logger, args ...interface{}) Logger {
+	if parent, ok := l.(*fieldLogger); ok {
+		return &fieldLogger{base: parent.base, fields: appendFields(parent.fields, args)}
+	}
+	return &fieldLogger{base: l, fields: appendFields(nil, args)}
+}
+
+// Debug registra un mensaje de nivel DEBUG
+func (l *fieldLogger) Debug(msg string, args ...interface{}) {
+	l.base.Debug(msg, appendFields(l.fields, args)...)
+}
+
+// Info registra un mensaje de nivel INFO
+func (l *fieldLogger) Info(msg string, args ...interface{}) {
+	l.base.Info(msg, appendFields(l.fields, args)...)
+}
+
+// Warn registra un mensaje de nivel WARN
+func (l *fieldLogger) Warn(msg string, args ...interface{}) {
+	l.base.Warn(msg, appendFields(l.fields, args)...)
+}
+
+// Error registra un mensaje de nivel ERROR
+func (l *fieldLogger) Error(msg string, args ...interface{}) {
+	l.base.Error(msg, appendFields(l.fields, args)...)
+}
+
+// Fatal registra un mensaje de nivel FATAL y termina el programa
+func (l *fieldLogger) Fatal(msg string, args ...interface{}) {
+	l.base.Fatal(msg, appendFields(l.fields, args)...)
+}
+
+// appendFields copia los campos para no compartir el arreglo subyacente
+func appendFields(fields, args []interface{}) []interface{} {
+	out := make([]interface{}, 0, len(fields)+len(args))
+	out = append(out, fields...)
+	return append(out, args...)
+}
\ No newline at end of file
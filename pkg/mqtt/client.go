@@ -0,0 +1,362 @@
+// pkg/mqtt/client.go
+package mqtt
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultKeepAlive es el intervalo de keep-alive anunciado al broker
+	DefaultKeepAlive = 60 * time.Second
+	// DefaultTimeout limita la conexión y la espera de CONNACK/SUBACK
+	DefaultTimeout = 10 * time.Second
+)
+
+var (
+	ErrNotConnected     = errors.New("MQTT client is not connected")
+	ErrConnectionLost   = errors.New("MQTT connection lost")
+	ErrSubscribeRefused = errors.New("MQTT subscription refused by broker")
+)
+
+// connackErrors describe los códigos de retorno de CONNACK
+var connackErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// Handler procesa un mensaje recibido
+type Handler func(topic string, payload []byte)
+
+// Options configura el cliente
+type Options struct {
+	// Broker es la URL del broker: tcp://host:1883 o ssl://host:8883 (también mqtt:// y mqtts://)
+	Broker    string
+	ClientID  string
+	Username  string
+	Password  string
+	KeepAlive time.Duration
+	Timeout   time.Duration
+	// Will se publica por el broker si la conexión se pierde sin DISCONNECT
+	Will      *Message
+	TLSConfig *tls.Config
+}
+
+// subscription asocia un filtro con su handler
+type subscription struct {
+	filter  string
+	handler Handler
+}
+
+// Client es un cliente MQTT 3.1.1 mínimo: publica y se suscribe con QoS 0.
+// Los mensajes recibidos se entregan en orden desde una única goroutine.
+type Client struct {
+	opts Options
+
+	mu       sync.Mutex
+	conn     net.Conn
+	writeMu  sync.Mutex
+	nextID   uint16
+	subs     []subscription
+	acks     map[uint16]chan []byte
+	messages chan *Message
+	done     chan struct{}
+	err      error
+	lastPong time.Time
+}
+
+// NewClient crea un cliente sin conectar
+func NewClient(opts Options) *Client {
+	if opts.KeepAlive <= 0 {
+		opts.KeepAlive = DefaultKeepAlive
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+
+	return &Client{
+		opts: opts,
+		acks: make(map[uint16]chan []byte),
+	}
+}
+
+// Connect abre la conexión, envía CONNECT y espera CONNACK
+func (c *Client) Connect() error {
+	conn, err := dial(c.opts.Broker, c.opts.Timeout, c.opts.TLSConfig)
+	if err != nil {
+		return err
+	}
+
+	connect := NewConnectPacket(&ConnectInfo{
+		ClientID:     c.opts.ClientID,
+		Username:     c.opts.Username,
+		Password:     c.opts.Password,
+		KeepAlive:    uint16(c.opts.KeepAlive / time.Second),
+		CleanSession: true,
+		Will:         c.opts.Will,
+	})
+
+	conn.SetDeadline(time.Now().Add(c.opts.Timeout))
+	if _, err := connect.WriteTo(conn); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to send CONNECT: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	ack, err := ReadPacket(reader)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to read CONNACK: %w", err)
+	}
+	if ack.Type != CONNACK || len(ack.Body) != 2 {
+		conn.Close()
+		return ErrMalformedPacket
+	}
+	if code := ack.Body[1]; code != 0 {
+		conn.Close()
+		return fmt.Errorf("MQTT connection refused: %s", connackErrors[code])
+	}
+	conn.SetDeadline(time.Time{})
+
+	c.mu.Lock()
+	c.conn = conn
+	c.done = make(chan struct{})
+	c.messages = make(chan *Message, 64)
+	c.err = nil
+	c.lastPong = time.Now()
+	c.mu.Unlock()
+
+	go c.readLoop(reader, c.messages, c.done)
+	go c.dispatchLoop(c.messages, c.done)
+	go c.keepAliveLoop(c.done)
+
+	return nil
+}
+
+// Publish publica un mensaje con QoS 0
+func (c *Client) Publish(topic string, payload []byte, retain bool) error {
+	return c.write(NewPublishPacket(&Message{Topic: topic, Payload: payload, Retain: retain}))
+}
+
+// Subscribe se suscribe al filtro y espera el SUBACK
+func (c *Client) Subscribe(filter string, handler Handler) error {
+	c.mu.Lock()
+	if c.conn == nil {
+		c.mu.Unlock()
+		return ErrNotConnected
+	}
+	c.nextID++
+	if c.nextID == 0 {
+		c.nextID = 1
+	}
+	id := c.nextID
+	ack := make(chan []byte, 1)
+	c.acks[id] = ack
+	c.subs = append(c.subs, subscription{filter: filter, handler: handler})
+	done := c.done
+	c.mu.Unlock()
+
+	if err := c.write(NewSubscribePacket(id, filter)); err != nil {
+		return err
+	}
+
+	select {
+	case codes := <-ack:
+		if len(codes) == 0 || codes[0] == 0x80 {
+			return fmt.Errorf("%w: %s", ErrSubscribeRefused, filter)
+		}
+		return nil
+	case <-done:
+		return ErrConnectionLost
+	case <-time.After(c.opts.Timeout):
+		return fmt.Errorf("timeout waiting for SUBACK on %s", filter)
+	}
+}
+
+// Done se cierra cuando la conexión termina
+func (c *Client) Done() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done
+}
+
+// Err retorna el motivo por el que terminó la conexión
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Disconnect envía DISCONNECT y cierra la conexión (el broker no publica el Will)
+func (c *Client) Disconnect() error {
+	err := c.write(&Packet{Type: DISCONNECT})
+	c.close(nil)
+	return err
+}
+
+// write serializa el envío de paquetes
+func (c *Client) write(p *Packet) error {
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	conn.SetWriteDeadline(time.Now().Add(c.opts.Timeout))
+	if _, err := p.WriteTo(conn); err != nil {
+		c.close(err)
+		return fmt.Errorf("%w: %v", ErrConnectionLost, err)
+	}
+	return nil
+}
+
+// close cierra la conexión una sola vez y registra el motivo
+func (c *Client) close(reason error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return
+	}
+	c.conn.Close()
+	c.conn = nil
+	c.err = reason
+	close(c.done)
+}
+
+// readLoop procesa los paquetes entrantes hasta que la conexión se cierra
+func (c *Client) readLoop(reader *bufio.Reader, messages chan *Message, done chan struct{}) {
+	for {
+		p, err := ReadPacket(reader)
+		if err != nil {
+			select {
+			case <-done:
+			default:
+				c.close(fmt.Errorf("%w: %v", ErrConnectionLost, err))
+			}
+			return
+		}
+
+		switch p.Type {
+		case PUBLISH:
+			msg, err := ParsePublish(p)
+			if err != nil {
+				continue
+			}
+			if msg.QoS == 1 {
+				c.write(&Packet{Type: PUBACK, Body: binary.BigEndian.AppendUint16(nil, msg.PacketID)})
+			}
+			select {
+			case messages <- msg:
+			case <-done:
+				return
+			}
+		case SUBACK:
+			if len(p.Body) < 2 {
+				continue
+			}
+			id := binary.BigEndian.Uint16(p.Body)
+			c.mu.Lock()
+			ack := c.acks[id]
+			delete(c.acks, id)
+			c.mu.Unlock()
+			if ack != nil {
+				ack <- p.Body[2:]
+			}
+		case PINGRESP:
+			c.mu.Lock()
+			c.lastPong = time.Now()
+			c.mu.Unlock()
+		}
+	}
+}
+
+// dispatchLoop ejecuta los handlers fuera de la goroutine de lectura para que
+// un handler lento no retrase el keep-alive
+func (c *Client) dispatchLoop(messages chan *Message, done chan struct{}) {
+	for {
+		var msg *Message
+		select {
+		case msg = <-messages:
+		case <-done:
+			return
+		}
+
+		c.mu.Lock()
+		subs := append([]subscription(nil), c.subs...)
+		c.mu.Unlock()
+
+		for _, sub := range subs {
+			if MatchTopic(sub.filter, msg.Topic) {
+				sub.handler(msg.Topic, msg.Payload)
+			}
+		}
+	}
+}
+
+// keepAliveLoop envía PINGREQ y cierra la conexión si el broker deja de responder
+func (c *Client) keepAliveLoop(done chan struct{}) {
+	interval := c.opts.KeepAlive / 2
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			silent := time.Since(c.lastPong)
+			c.mu.Unlock()
+
+			if silent > c.opts.KeepAlive+interval {
+				c.close(fmt.Errorf("%w: no PINGRESP for %s", ErrConnectionLost, silent.Round(time.Second)))
+				return
+			}
+			c.write(&Packet{Type: PINGREQ})
+		}
+	}
+}
+
+// dial abre la conexión TCP o TLS según el esquema de la URL del broker
+func dial(broker string, timeout time.Duration, tlsConfig *tls.Config) (net.Conn, error) {
+	u, err := url.Parse(broker)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid MQTT broker URL %q", broker)
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	switch u.Scheme {
+	case "tcp", "mqtt":
+		return dialer.Dial("tcp", hostPort(u, "1883"))
+	case "ssl", "tls", "mqtts":
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		return tls.DialWithDialer(dialer, "tcp", hostPort(u, "8883"), tlsConfig)
+	default:
+		return nil, fmt.Errorf("unsupported MQTT broker scheme %q", u.Scheme)
+	}
+}
+
+// hostPort agrega el puerto por defecto si la URL no lo indica
+func hostPort(u *url.URL, defaultPort string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	return net.JoinHostPort(u.Hostname(), defaultPort)
+}
\ No newline at end of file
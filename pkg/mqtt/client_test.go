@@ -0,0 +1,118 @@
+// pkg/mqtt/client_test.go
+package mqtt_test
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+	"time"
+
+	"rtc-scheduler/pkg/mqtt"
+	"rtc-scheduler/pkg/mqtt/mqtttest"
+)
+
+func TestPacketRoundTrip(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 300) // longitud restante de 2 bytes
+	var buf bytes.Buffer
+	mqtt.NewPublishPacket(&mqtt.Message{Topic: "a/b", Payload: payload, Retain: true}).WriteTo(&buf)
+
+	p, err := mqtt.ReadPacket(bufio.NewReader(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := mqtt.ParsePublish(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Topic != "a/b" || !msg.Retain || !bytes.Equal(msg.Payload, payload) {
+		t.Errorf("unexpected message %q retain=%v len=%d", msg.Topic, msg.Retain, len(msg.Payload))
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		filter, topic string
+		want          bool
+	}{
+		{"rtc/host/command/+", "rtc/host/command/enable", true},
+		{"rtc/host/command/+", "rtc/host/command/enable/x", false},
+		{"rtc/#", "rtc/host/status", true},
+		{"rtc/host/status", "rtc/host/status", true},
+		{"rtc/host/status", "rtc/host", false},
+	}
+	for _, tt := range tests {
+		if got := mqtt.MatchTopic(tt.filter, tt.topic); got != tt.want {
+			t.Errorf("MatchTopic(%q, %q) = %v, want %v", tt.filter, tt.topic, got, tt.want)
+		}
+	}
+}
+
+func TestClientPublishSubscribe(t *testing.T) {
+	broker := mqtttest.NewBroker()
+	defer broker.Close()
+
+	client := mqtt.NewClient(mqtt.Options{
+		Broker:   broker.URL(),
+		ClientID: "test",
+		Username: "user",
+		Password: "secret",
+		Will:     &mqtt.Message{Topic: "rtc/availability", Payload: []byte("offline"), Retain: true},
+	})
+	if err := client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Disconnect()
+
+	connects := broker.Connects()
+	if len(connects) != 1 || connects[0].Username != "user" || connects[0].Password != "secret" || connects[0].Will == nil {
+		t.Fatalf("unexpected CONNECT %+v", connects)
+	}
+
+	received := make(chan string, 1)
+	if err := client.Subscribe("rtc/command/+", func(topic string, payload []byte) {
+		received <- topic + "=" + string(payload)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	broker.Publish("rtc/command/snooze", []byte("1h"), false)
+	select {
+	case got := <-received:
+		if got != "rtc/command/snooze=1h" {
+			t.Errorf("received %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not delivered to subscriber")
+	}
+
+	if err := client.Publish("rtc/status", []byte(`{"enabled":true}`), true); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := broker.WaitFor("rtc/status", 2*time.Second, nil); !ok {
+		t.Fatal("publish not received by broker")
+	}
+	if broker.Retained("rtc/status") == nil {
+		t.Error("expected retained status message")
+	}
+}
+
+func TestClientDetectsConnectionLoss(t *testing.T) {
+	broker := mqtttest.NewBroker()
+	defer broker.Close()
+
+	client := mqtt.NewClient(mqtt.Options{Broker: broker.URL(), ClientID: "test"})
+	if err := client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	broker.DropClients()
+
+	select {
+	case <-client.Done():
+		if client.Err() == nil {
+			t.Error("expected a connection error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("connection loss not detected")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,247 @@
+// pkg/mqtt/mqtttest/broker.go
+package mqtttest
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"rtc-scheduler/pkg/mqtt"
+)
+
+// Broker es un broker MQTT en memoria para pruebas. Acepta cualquier
+// conexión, reenvía publicaciones QoS 0 a los suscriptores, conserva los
+// mensajes retenidos y publica el Will si un cliente se desconecta sin DISCONNECT.
+type Broker struct {
+	listener net.Listener
+
+	mu        sync.Mutex
+	clients   map[net.Conn]*client
+	retained  map[string]*mqtt.Message
+	published []*mqtt.Message
+	connects  []*mqtt.ConnectInfo
+}
+
+// client es una conexión aceptada por el broker
+type client struct {
+	conn    net.Conn
+	writeMu sync.Mutex
+	filters []string
+	will    *mqtt.Message
+}
+
+// NewBroker inicia un broker escuchando en localhost
+func NewBroker() *Broker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("mqtttest: failed to listen: %v", err))
+	}
+
+	b := &Broker{
+		listener: listener,
+		clients:  make(map[net.Conn]*client),
+		retained: make(map[string]*mqtt.Message),
+	}
+	go b.acceptLoop()
+	return b
+}
+
+// URL retorna la dirección del broker (tcp://127.0.0.1:puerto)
+func (b *Broker) URL() string {
+	return "tcp://" + b.listener.Addr().String()
+}
+
+// Close detiene el broker y cierra todas las conexiones
+func (b *Broker) Close() {
+	b.listener.Close()
+	b.DropClients()
+}
+
+// DropClients cierra abruptamente las conexiones (se publican los Will)
+func (b *Broker) DropClients() {
+	b.mu.Lock()
+	conns := make([]net.Conn, 0, len(b.clients))
+	for conn := range b.clients {
+		conns = append(conns, conn)
+	}
+	b.mu.Unlock()
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+// Connects retorna los CONNECT recibidos
+func (b *Broker) Connects() []*mqtt.ConnectInfo {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*mqtt.ConnectInfo(nil), b.connects...)
+}
+
+// Published retorna los mensajes publicados por los clientes, en orden
+func (b *Broker) Published() []*mqtt.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*mqtt.Message(nil), b.published...)
+}
+
+// Retained retorna el mensaje retenido de un tópico (nil si no hay)
+func (b *Broker) Retained(topic string) *mqtt.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.retained[topic]
+}
+
+// WaitFor espera hasta que se publique un mensaje en el tópico que cumpla match
+// (nil acepta cualquiera) y lo retorna
+func (b *Broker) WaitFor(topic string, timeout time.Duration, match func(*mqtt.Message) bool) (*mqtt.Message, bool) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		for _, msg := range b.Published() {
+			if msg.Topic == topic && (match == nil || match(msg)) {
+				return msg, true
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return nil, false
+}
+
+// Publish envía un mensaje a los clientes suscritos, como si lo publicara otro cliente
+func (b *Broker) Publish(topic string, payload []byte, retain bool) {
+	b.route(&mqtt.Message{Topic: topic, Payload: payload, Retain: retain}, false)
+}
+
+// acceptLoop acepta conexiones hasta que se cierra el listener
+func (b *Broker) acceptLoop() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.serve(conn)
+	}
+}
+
+// serve atiende una conexión
+func (b *Broker) serve(conn net.Conn) {
+	c := &client{conn: conn}
+	reader := bufio.NewReader(conn)
+	graceful := false
+
+	defer func() {
+		b.mu.Lock()
+		delete(b.clients, conn)
+		b.mu.Unlock()
+		conn.Close()
+
+		if !graceful && c.will != nil {
+			b.route(c.will, false)
+		}
+	}()
+
+	for {
+		p, err := mqtt.ReadPacket(reader)
+		if err != nil {
+			return
+		}
+
+		switch p.Type {
+		case mqtt.CONNECT:
+			info, err := mqtt.ParseConnect(p)
+			if err != nil {
+				return
+			}
+			c.will = info.Will
+			b.mu.Lock()
+			b.clients[conn] = c
+			b.connects = append(b.connects, info)
+			b.mu.Unlock()
+			c.send(&mqtt.Packet{Type: mqtt.CONNACK, Body: []byte{0, 0}})
+
+		case mqtt.SUBSCRIBE:
+			id, filters, err := mqtt.ParseSubscribe(p)
+			if err != nil {
+				return
+			}
+			body := binary.BigEndian.AppendUint16(nil, id)
+			b.mu.Lock()
+			c.filters = append(c.filters, filters...)
+			var retained []*mqtt.Message
+			for _, msg := range b.retained {
+				for _, filter := range filters {
+					if mqtt.MatchTopic(filter, msg.Topic) {
+						retained = append(retained, msg)
+						break
+					}
+				}
+			}
+			b.mu.Unlock()
+			for range filters {
+				body = append(body, 0)
+			}
+			c.send(&mqtt.Packet{Type: mqtt.SUBACK, Body: body})
+			for _, msg := range retained {
+				c.send(mqtt.NewPublishPacket(msg))
+			}
+
+		case mqtt.PUBLISH:
+			msg, err := mqtt.ParsePublish(p)
+			if err != nil {
+				return
+			}
+			if msg.QoS == 1 {
+				c.send(&mqtt.Packet{Type: mqtt.PUBACK, Body: binary.BigEndian.AppendUint16(nil, msg.PacketID)})
+			}
+			b.route(msg, true)
+
+		case mqtt.PINGREQ:
+			c.send(&mqtt.Packet{Type: mqtt.PINGRESP})
+
+		case mqtt.DISCONNECT:
+			graceful = true
+			return
+		}
+	}
+}
+
+// route registra el mensaje y lo reenvía a los suscriptores con QoS 0
+func (b *Broker) route(msg *mqtt.Message, fromClient bool) {
+	forward := &mqtt.Message{Topic: msg.Topic, Payload: append([]byte(nil), msg.Payload...)}
+
+	b.mu.Lock()
+	if fromClient {
+		b.published = append(b.published, &mqtt.Message{Topic: msg.Topic, Payload: forward.Payload, Retain: msg.Retain})
+	}
+	if msg.Retain {
+		if len(msg.Payload) == 0 {
+			delete(b.retained, msg.Topic)
+		} else {
+			b.retained[msg.Topic] = &mqtt.Message{Topic: msg.Topic, Payload: forward.Payload, Retain: true}
+		}
+	}
+	var targets []*client
+	for _, c := range b.clients {
+		for _, filter := range c.filters {
+			if mqtt.MatchTopic(filter, msg.Topic) {
+				targets = append(targets, c)
+				break
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	for _, c := range targets {
+		c.send(mqtt.NewPublishPacket(forward))
+	}
+}
+
+// send escribe un paquete al cliente
+func (c *client) send(p *mqtt.Packet) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	p.WriteTo(c.conn)
+}
\ No newline at end of file
@@ -0,0 +1,281 @@
+// pkg/mqtt/packet.go
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Tipos de paquete de MQTT 3.1.1
+const (
+	CONNECT    byte = 1
+	CONNACK    byte = 2
+	PUBLISH    byte = 3
+	PUBACK     byte = 4
+	SUBSCRIBE  byte = 8
+	SUBACK     byte = 9
+	PINGREQ    byte = 12
+	PINGRESP   byte = 13
+	DISCONNECT byte = 14
+)
+
+// maxRemaining es la longitud máxima representable en el encabezado fijo
+const maxRemaining = 268435455
+
+var ErrMalformedPacket = errors.New("malformed MQTT packet")
+
+// Packet es un paquete MQTT sin decodificar: tipo, flags y el resto del contenido
+type Packet struct {
+	Type  byte
+	Flags byte
+	Body  []byte
+}
+
+// ReadPacket lee un paquete completo
+func ReadPacket(r *bufio.Reader) (*Packet, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return nil, ErrMalformedPacket
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		length += int(b&0x7f) * multiplier
+		if b&0x80 == 0 {
+			break
+		}
+		multiplier *= 128
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	return &Packet{Type: header >> 4, Flags: header & 0x0f, Body: body}, nil
+}
+
+// WriteTo escribe el paquete con su encabezado fijo
+func (p *Packet) WriteTo(w io.Writer) (int64, error) {
+	if len(p.Body) > maxRemaining {
+		return 0, fmt.Errorf("MQTT packet too large: %d bytes", len(p.Body))
+	}
+
+	buf := make([]byte, 0, len(p.Body)+5)
+	buf = append(buf, p.Type<<4|p.Flags)
+	length := len(p.Body)
+	for {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 0x80
+		}
+		buf = append(buf, b)
+		if length == 0 {
+			break
+		}
+	}
+	buf = append(buf, p.Body...)
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Message es un mensaje publicado
+type Message struct {
+	Topic    string
+	Payload  []byte
+	Retain   bool
+	QoS      byte
+	PacketID uint16
+}
+
+// NewPublishPacket construye un PUBLISH (QoS 0 si packetID es 0)
+func NewPublishPacket(msg *Message) *Packet {
+	var flags byte
+	if msg.Retain {
+		flags |= 0x01
+	}
+	flags |= (msg.QoS & 0x03) << 1
+
+	body := appendString(nil, msg.Topic)
+	if msg.QoS > 0 {
+		body = binary.BigEndian.AppendUint16(body, msg.PacketID)
+	}
+	body = append(body, msg.Payload...)
+
+	return &Packet{Type: PUBLISH, Flags: flags, Body: body}
+}
+
+// ParsePublish decodifica un PUBLISH
+func ParsePublish(p *Packet) (*Message, error) {
+	topic, rest, err := readString(p.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &Message{Topic: topic, Retain: p.Flags&0x01 != 0, QoS: (p.Flags >> 1) & 0x03}
+	if msg.QoS > 0 {
+		if len(rest) < 2 {
+			return nil, ErrMalformedPacket
+		}
+		msg.PacketID = binary.BigEndian.Uint16(rest)
+		rest = rest[2:]
+	}
+	msg.Payload = rest
+
+	return msg, nil
+}
+
+// NewSubscribePacket construye un SUBSCRIBE con QoS 0 para cada filtro
+func NewSubscribePacket(packetID uint16, filters ...string) *Packet {
+	body := binary.BigEndian.AppendUint16(nil, packetID)
+	for _, filter := range filters {
+		body = appendString(body, filter)
+		body = append(body, 0)
+	}
+	return &Packet{Type: SUBSCRIBE, Flags: 0x02, Body: body}
+}
+
+// ParseSubscribe decodifica un SUBSCRIBE y retorna el ID y los filtros
+func ParseSubscribe(p *Packet) (uint16, []string, error) {
+	if len(p.Body) < 2 {
+		return 0, nil, ErrMalformedPacket
+	}
+	id := binary.BigEndian.Uint16(p.Body)
+
+	var filters []string
+	rest := p.Body[2:]
+	for len(rest) > 0 {
+		filter, next, err := readString(rest)
+		if err != nil || len(next) < 1 {
+			return 0, nil, ErrMalformedPacket
+		}
+		filters = append(filters, filter)
+		rest = next[1:]
+	}
+
+	return id, filters, nil
+}
+
+// ConnectInfo son los campos de un CONNECT
+type ConnectInfo struct {
+	ClientID     string
+	Username     string
+	Password     string
+	KeepAlive    uint16
+	CleanSession bool
+	Will         *Message
+}
+
+// NewConnectPacket construye un CONNECT
+func NewConnectPacket(info *ConnectInfo) *Packet {
+	var flags byte
+	if info.CleanSession {
+		flags |= 0x02
+	}
+	if info.Will != nil {
+		flags |= 0x04 | (info.Will.QoS&0x03)<<3
+		if info.Will.Retain {
+			flags |= 0x20
+		}
+	}
+	if info.Password != "" {
+		flags |= 0x40
+	}
+	if info.Username != "" {
+		flags |= 0x80
+	}
+
+	body := appendString(nil, "MQTT")
+	body = append(body, 4, flags)
+	body = binary.BigEndian.AppendUint16(body, info.KeepAlive)
+	body = appendString(body, info.ClientID)
+	if info.Will != nil {
+		body = appendString(body, info.Will.Topic)
+		body = appendBytes(body, info.Will.Payload)
+	}
+	if info.Username != "" {
+		body = appendString(body, info.Username)
+	}
+	if info.Password != "" {
+		body = appendString(body, info.Password)
+	}
+
+	return &Packet{Type: CONNECT, Body: body}
+}
+
+// ParseConnect decodifica un CONNECT
+func ParseConnect(p *Packet) (*ConnectInfo, error) {
+	protocol, rest, err := readString(p.Body)
+	if err != nil || protocol != "MQTT" || len(rest) < 4 {
+		return nil, ErrMalformedPacket
+	}
+	flags := rest[1]
+	info := &ConnectInfo{
+		KeepAlive:    binary.BigEndian.Uint16(rest[2:]),
+		CleanSession: flags&0x02 != 0,
+	}
+	rest = rest[4:]
+
+	if info.ClientID, rest, err = readString(rest); err != nil {
+		return nil, err
+	}
+	if flags&0x04 != 0 {
+		will := &Message{Retain: flags&0x20 != 0, QoS: (flags >> 3) & 0x03}
+		var payload string
+		if will.Topic, rest, err = readString(rest); err != nil {
+			return nil, err
+		}
+		if payload, rest, err = readString(rest); err != nil {
+			return nil, err
+		}
+		will.Payload = []byte(payload)
+		info.Will = will
+	}
+	if flags&0x80 != 0 {
+		if info.Username, rest, err = readString(rest); err != nil {
+			return nil, err
+		}
+	}
+	if flags&0x40 != 0 {
+		if info.Password, _, err = readString(rest); err != nil {
+			return nil, err
+		}
+	}
+
+	return info, nil
+}
+
+// appendString agrega un string con prefijo de longitud de 2 bytes
+func appendString(buf []byte, s string) []byte {
+	return appendBytes(buf, []byte(s))
+}
+
+// appendBytes agrega datos binarios con prefijo de longitud de 2 bytes
+func appendBytes(buf []byte, data []byte) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(data)))
+	return append(buf, data...)
+}
+
+// readString lee un string con prefijo de longitud y retorna el resto
+func readString(data []byte) (string, []byte, error) {
+	if len(data) < 2 {
+		return "", nil, ErrMalformedPacket
+	}
+	n := int(binary.BigEndian.Uint16(data))
+	if len(data) < 2+n {
+		return "", nil, ErrMalformedPacket
+	}
+	return string(data[2 : 2+n]), data[2+n:], nil
+}
\ No newline at end of file
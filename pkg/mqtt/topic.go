@@ -0,0 +1,24 @@
+// pkg/mqtt/topic.go
+package mqtt
+
+import "strings"
+
+// MatchTopic verifica si un tópico coincide con un filtro con comodines "+" y "#"
+func MatchTopic(filter, topic string) bool {
+	filterParts := strings.Split(filter, "/")
+	topicParts := strings.Split(topic, "/")
+
+	for i, part := range filterParts {
+		if part == "#" {
+			return true
+		}
+		if i >= len(topicParts) {
+			return false
+		}
+		if part != "+" && part != topicParts[i] {
+			return false
+		}
+	}
+
+	return len(filterParts) == len(topicParts)
+}
\ No newline at end of file
@@ -0,0 +1,222 @@
+// pkg/solar/solar.go
+package solar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoEvent       = errors.New("the sun does not reach that altitude on this day (polar day or night)")
+	ErrInvalidAnchor = errors.New("invalid solar time, use sunrise, sunset, dawn or dusk with an optional offset such as sunset-30m")
+)
+
+// MaxOffset es el desplazamiento máximo respecto del evento solar
+const MaxOffset = 12 * time.Hour
+
+// Event es un momento del día definido por la altura del sol
+type Event int
+
+const (
+	// Sunrise es la salida del sol (borde superior en el horizonte, con refracción)
+	Sunrise Event = iota
+	// Sunset es la puesta del sol
+	Sunset
+	// Dawn es el comienzo del crepúsculo civil matutino (sol 6° bajo el horizonte)
+	Dawn
+	// Dusk es el fin del crepúsculo civil vespertino
+	Dusk
+)
+
+var eventNames = []string{"sunrise", "sunset", "dawn", "dusk"}
+
+func (e Event) String() string {
+	if int(e) < len(eventNames) {
+		return eventNames[e]
+	}
+	return fmt.Sprintf("Event(%d)", int(e))
+}
+
+// zenith es el ángulo cenital del sol en el evento, en grados
+func (e Event) zenith() float64 {
+	if e == Dawn || e == Dusk {
+		return 96
+	}
+	// 90° más 34' de refracción y 16' de semidiámetro
+	return 90.833
+}
+
+// morning indica si el evento ocurre antes del mediodía solar
+func (e Event) morning() bool {
+	return e == Sunrise || e == Dawn
+}
+
+// Time calcula el evento para el día de date (en la zona de date) en las
+// coordenadas dadas, en grados (latitud norte y longitud este positivas).
+// Usa las ecuaciones de la NOAA (basadas en Meeus), con un error típico
+// menor a un minuto entre los círculos polares. Retorna ErrNoEvent si ese
+// día el sol no cruza la altura del evento.
+func Time(event Event, date time.Time, latitude, longitude float64) (time.Time, error) {
+	noon := solarNoon(date, longitude)
+
+	// Dos pasadas: la segunda recalcula la posición del sol a la hora estimada
+	t := noon
+	for i := 0; i < 2; i++ {
+		declination, eqTime := position(t)
+		ha, ok := hourAngle(event.zenith(), latitude, declination)
+		if !ok {
+			return time.Time{}, ErrNoEvent
+		}
+		if event.morning() {
+			ha = -ha
+		}
+		minutes := 720 - 4*longitude - eqTime + 4*ha
+		t = utcMidnight(noon).Add(time.Duration(minutes * float64(time.Minute)))
+	}
+	return t.Truncate(time.Second).In(date.Location()), nil
+}
+
+// solarNoon retorna el mediodía solar del día local de date
+func solarNoon(date time.Time, longitude float64) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		_, eqTime := position(day.Add(12 * time.Hour))
+		noon := day.Add(time.Duration((720 - 4*longitude - eqTime) * float64(time.Minute)))
+
+		// Con zonas horarias alejadas de la longitud el mediodía puede caer
+		// en otro día UTC: se corrige hasta que coincida con el día local
+		local := noon.In(date.Location())
+		shift := dayNumber(date) - dayNumber(local)
+		if shift == 0 {
+			return noon
+		}
+		day = day.AddDate(0, 0, shift)
+	}
+	return day.Add(12 * time.Hour)
+}
+
+// dayNumber cuenta días civiles para comparar fechas de zonas distintas
+func dayNumber(t time.Time) int {
+	return int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// utcMidnight retorna la medianoche UTC del día de t
+func utcMidnight(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// position retorna la declinación del sol (grados) y la ecuación del tiempo
+// (minutos) en el instante t
+func position(t time.Time) (float64, float64) {
+	julianDay := float64(t.Unix())/86400 + 2440587.5
+	c := (julianDay - 2451545) / 36525
+
+	meanLong := math.Mod(280.46646+c*(36000.76983+c*0.0003032), 360)
+	meanAnomaly := 357.52911 + c*(35999.05029-0.0001537*c)
+	eccentricity := 0.016708634 - c*(0.000042037+0.0000001267*c)
+	center := sin(meanAnomaly)*(1.914602-c*(0.004817+0.000014*c)) +
+		sin(2*meanAnomaly)*(0.019993-0.000101*c) +
+		sin(3*meanAnomaly)*0.000289
+
+	omega := 125.04 - 1934.136*c
+	apparentLong := meanLong + center - 0.00569 - 0.00478*sin(omega)
+	meanObliquity := 23 + (26+(21.448-c*(46.815+c*(0.00059-c*0.001813)))/60)/60
+	obliquity := meanObliquity + 0.00256*cos(omega)
+
+	declination := degrees(math.Asin(sin(obliquity) * sin(apparentLong)))
+
+	y := math.Pow(math.Tan(radians(obliquity/2)), 2)
+	eqTime := 4 * degrees(y*sin(2*meanLong)-
+		2*eccentricity*sin(meanAnomaly)+
+		4*eccentricity*y*sin(meanAnomaly)*cos(2*meanLong)-
+		0.5*y*y*sin(4*meanLong)-
+		1.25*eccentricity*eccentricity*sin(2*meanAnomaly))
+	return declination, eqTime
+}
+
+// hourAngle retorna el ángulo horario (grados) en que el sol tiene el
+// ángulo cenital dado; ok es false si ese día no lo alcanza
+func hourAngle(zenith, latitude, declination float64) (float64, bool) {
+	value := cos(zenith)/(cos(latitude)*cos(declination)) - math.Tan(radians(latitude))*math.Tan(radians(declination))
+	if value < -1 || value > 1 {
+		return 0, false
+	}
+	return degrees(math.Acos(value)), true
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+func sin(deg float64) float64     { return math.Sin(radians(deg)) }
+func cos(deg float64) float64     { return math.Cos(radians(deg)) }
+
+// Anchor es un horario relativo a un evento solar: "sunset-30m", "sunrise"
+type Anchor struct {
+	Event  Event
+	Offset time.Duration
+}
+
+// ParseAnchor interpreta un evento (sunrise, sunset, dawn, dusk) seguido
+// opcionalmente de un desplazamiento con signo ("-30m", "+1h15m"), de hasta
+// MaxOffset. Las mayúsculas y los espacios no importan.
+func ParseAnchor(value string) (Anchor, error) {
+	text := strings.ToLower(strings.Join(strings.Fields(value), ""))
+	for i, name := range eventNames {
+		if !strings.HasPrefix(text, name) {
+			continue
+		}
+		anchor := Anchor{Event: Event(i)}
+		rest := text[len(name):]
+		if rest == "" {
+			return anchor, nil
+		}
+		if rest[0] != '+' && rest[0] != '-' {
+			break
+		}
+		offset, err := time.ParseDuration(rest)
+		if err != nil || len(rest) < 2 || rest[1] == '+' || rest[1] == '-' || offset > MaxOffset || offset < -MaxOffset {
+			break
+		}
+		anchor.Offset = offset
+		return anchor, nil
+	}
+	return Anchor{}, fmt.Errorf("%w: %q", ErrInvalidAnchor, value)
+}
+
+// IsAnchor indica si value es un horario solar válido
+func IsAnchor(value string) bool {
+	_, err := ParseAnchor(value)
+	return err == nil
+}
+
+// String retorna la forma canónica: "sunset-30m", "dawn+1h15m", "sunrise"
+func (a Anchor) String() string {
+	if a.Offset == 0 {
+		return a.Event.String()
+	}
+	sign, offset := "+", a.Offset
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	text := offset.String()
+	// time.Duration.String agrega unidades en cero: "1h0m0s" → "1h"
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return a.Event.String() + sign + text
+}
+
+// On calcula el horario para el día de date en las coordenadas dadas
+func (a Anchor) On(date time.Time, latitude, longitude float64) (time.Time, error) {
+	t, err := Time(a.Event, date, latitude, longitude)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(a.Offset), nil
+}
\ No newline at end of file
@@ -0,0 +1,127 @@
+// pkg/solar/solar_test.go
+package solar_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"rtc-scheduler/pkg/solar"
+)
+
+func TestTimeMatchesAlmanac(t *testing.T) {
+	// Horas publicadas por el USNO / NOAA, redondeadas al minuto
+	tests := []struct {
+		place     string
+		lat, lon  float64
+		utcOffset int
+		date      string
+		event     solar.Event
+		want      string
+	}{
+		{"London", 51.5074, -0.1278, 1, "2024-06-20", solar.Sunrise, "2024-06-20 04:43"},
+		{"London", 51.5074, -0.1278, 1, "2024-06-20", solar.Sunset, "2024-06-20 21:21"},
+		{"London", 51.5074, -0.1278, 0, "2024-12-21", solar.Sunrise, "2024-12-21 08:04"},
+		{"London", 51.5074, -0.1278, 0, "2024-12-21", solar.Sunset, "2024-12-21 15:53"},
+		{"New York", 40.7128, -74.0060, -4, "2024-06-20", solar.Sunrise, "2024-06-20 05:25"},
+		{"New York", 40.7128, -74.0060, -4, "2024-06-20", solar.Sunset, "2024-06-20 20:31"},
+		{"New York", 40.7128, -74.0060, -5, "2024-12-21", solar.Dawn, "2024-12-21 06:46"},
+		{"New York", 40.7128, -74.0060, -5, "2024-12-21", solar.Sunrise, "2024-12-21 07:17"},
+		{"New York", 40.7128, -74.0060, -5, "2024-12-21", solar.Sunset, "2024-12-21 16:32"},
+		{"New York", 40.7128, -74.0060, -5, "2024-12-21", solar.Dusk, "2024-12-21 17:03"},
+		{"Sydney", -33.8688, 151.2093, 11, "2024-12-21", solar.Sunrise, "2024-12-21 05:41"},
+		{"Sydney", -33.8688, 151.2093, 11, "2024-12-21", solar.Sunset, "2024-12-21 20:05"},
+		{"Los Angeles", 34.0522, -118.2437, -7, "2024-07-04", solar.Sunrise, "2024-07-04 05:47"},
+		{"Los Angeles", 34.0522, -118.2437, -7, "2024-07-04", solar.Sunset, "2024-07-04 20:08"},
+		// La puesta del solsticio en Reikiavik cae pasada la medianoche
+		{"Reykjavik", 64.1466, -21.9426, 0, "2024-06-21", solar.Sunrise, "2024-06-21 02:55"},
+		{"Reykjavik", 64.1466, -21.9426, 0, "2024-06-21", solar.Sunset, "2024-06-22 00:04"},
+	}
+
+	for _, tt := range tests {
+		zone := time.FixedZone("local", tt.utcOffset*3600)
+		date, _ := time.ParseInLocation("2006-01-02", tt.date, zone)
+		want, _ := time.ParseInLocation("2006-01-02 15:04", tt.want, zone)
+
+		got, err := solar.Time(tt.event, date, tt.lat, tt.lon)
+		if err != nil {
+			t.Errorf("%s %s %s: %v", tt.place, tt.date, tt.event, err)
+			continue
+		}
+		if diff := got.Sub(want); diff < -2*time.Minute || diff > 2*time.Minute {
+			t.Errorf("%s %s %s = %s, want %s", tt.place, tt.date, tt.event, got.Format("2006-01-02 15:04:05"), tt.want)
+		}
+		if got.Location() != zone {
+			t.Errorf("%s %s %s: result not in the date's zone", tt.place, tt.date, tt.event)
+		}
+	}
+}
+
+func TestTimePolar(t *testing.T) {
+	zone := time.FixedZone("CET", 3600)
+	const lat, lon = 69.6492, 18.9553 // Tromsø
+
+	winter := time.Date(2024, 12, 21, 0, 0, 0, 0, zone)
+	if _, err := solar.Time(solar.Sunrise, winter, lat, lon); !errors.Is(err, solar.ErrNoEvent) {
+		t.Errorf("polar night sunrise error = %v, want ErrNoEvent", err)
+	}
+	// En la noche polar sigue habiendo crepúsculo civil cerca del mediodía
+	dawn, err := solar.Time(solar.Dawn, winter, lat, lon)
+	if err != nil || dawn.Hour() < 8 || dawn.Hour() > 11 {
+		t.Errorf("polar night dawn = %v, %v", dawn, err)
+	}
+
+	summer := time.Date(2024, 6, 21, 0, 0, 0, 0, zone)
+	if _, err := solar.Time(solar.Sunset, summer, lat, lon); !errors.Is(err, solar.ErrNoEvent) {
+		t.Errorf("midnight sun sunset error = %v, want ErrNoEvent", err)
+	}
+}
+
+func TestParseAnchor(t *testing.T) {
+	tests := []struct {
+		value  string
+		event  solar.Event
+		offset time.Duration
+		canon  string
+	}{
+		{"sunrise", solar.Sunrise, 0, "sunrise"},
+		{"sunset-30m", solar.Sunset, -30 * time.Minute, "sunset-30m"},
+		{"Dawn + 1h15m", solar.Dawn, 75 * time.Minute, "dawn+1h15m"},
+		{"dusk+2h", solar.Dusk, 2 * time.Hour, "dusk+2h"},
+		{"sunset-90m", solar.Sunset, -90 * time.Minute, "sunset-1h30m"},
+	}
+	for _, tt := range tests {
+		anchor, err := solar.ParseAnchor(tt.value)
+		if err != nil {
+			t.Errorf("ParseAnchor(%q): %v", tt.value, err)
+			continue
+		}
+		if anchor.Event != tt.event || anchor.Offset != tt.offset {
+			t.Errorf("ParseAnchor(%q) = %+v", tt.value, anchor)
+		}
+		if anchor.String() != tt.canon {
+			t.Errorf("ParseAnchor(%q).String() = %q, want %q", tt.value, anchor.String(), tt.canon)
+		}
+	}
+
+	for _, value := range []string{"", "07:30", "noon", "sunset30m", "sunset-30", "sunset--30m", "sunrise+13h", "sundown"} {
+		if _, err := solar.ParseAnchor(value); !errors.Is(err, solar.ErrInvalidAnchor) {
+			t.Errorf("ParseAnchor(%q) error = %v, want ErrInvalidAnchor", value, err)
+		}
+	}
+}
+
+func TestAnchorOn(t *testing.T) {
+	zone := time.FixedZone("EST", -5*3600)
+	date := time.Date(2024, 12, 21, 0, 0, 0, 0, zone)
+	anchor, _ := solar.ParseAnchor("sunset-30m")
+
+	got, err := anchor.On(date, 40.7128, -74.0060)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sunset, _ := solar.Time(solar.Sunset, date, 40.7128, -74.0060)
+	if !got.Equal(sunset.Add(-30 * time.Minute)) {
+		t.Errorf("On = %v, want 30m before %v", got, sunset)
+	}
+}
\ No newline at end of file
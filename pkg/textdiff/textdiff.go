@@ -0,0 +1,119 @@
+// pkg/textdiff/textdiff.go
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op indica si una línea se conserva, se elimina o se agrega
+type Op byte
+
+const (
+	Equal  Op = ' '
+	Delete Op = '-'
+	Insert Op = '+'
+)
+
+// Line es una línea del resultado de Lines
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines compara dos textos línea por línea (subsecuencia común más larga).
+// Pensado para archivos chicos como la configuración: usa O(n·m) memoria.
+func Lines(a, b string) []Line {
+	x, y := splitLines(a), splitLines(b)
+
+	// lcs[i][j] = largo de la subsecuencia común de x[i:] e y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []Line
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			lines = append(lines, Line{Equal, x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Delete, x[i]})
+			i++
+		default:
+			lines = append(lines, Line{Insert, y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		lines = append(lines, Line{Delete, x[i]})
+	}
+	for ; j < len(y); j++ {
+		lines = append(lines, Line{Insert, y[j]})
+	}
+	return lines
+}
+
+// Changed indica si el resultado contiene alguna diferencia
+func Changed(lines []Line) bool {
+	for _, l := range lines {
+		if l.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// Unified formatea el resultado mostrando solo los cambios con context líneas
+// alrededor; los tramos omitidos se marcan con "@@ ... @@"
+func Unified(lines []Line, context int) string {
+	keep := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.Op == Equal {
+			continue
+		}
+		for k := i - context; k <= i+context; k++ {
+			if k >= 0 && k < len(lines) {
+				keep[k] = true
+			}
+		}
+	}
+
+	var sb strings.Builder
+	skipped := 0
+	for i, l := range lines {
+		if !keep[i] {
+			skipped++
+			continue
+		}
+		if skipped > 0 {
+			fmt.Fprintf(&sb, "@@ %d unchanged line(s) @@\n", skipped)
+			skipped = 0
+		}
+		fmt.Fprintf(&sb, "%c %s\n", l.Op, l.Text)
+	}
+	return sb.String()
+}
+
+// splitLines separa el texto en líneas sin el salto final
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
\ No newline at end of file
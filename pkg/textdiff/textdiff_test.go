@@ -0,0 +1,48 @@
+// pkg/textdiff/textdiff_test.go
+package textdiff_test
+
+import (
+	"testing"
+
+	"rtc-scheduler/pkg/textdiff"
+)
+
+func TestLines(t *testing.T) {
+	a := "{\n  \"wake_time\": \"07:00\",\n  \"shutdown_time\": \"23:00\",\n  \"enabled\": true\n}\n"
+	b := "{\n  \"wake_time\": \"06:30\",\n  \"shutdown_time\": \"23:00\",\n  \"enabled\": true\n}\n"
+
+	lines := textdiff.Lines(a, b)
+	if !textdiff.Changed(lines) {
+		t.Fatal("expected a change")
+	}
+
+	var ops string
+	for _, l := range lines {
+		ops += string(l.Op)
+	}
+	if ops != " -+   " {
+		t.Errorf("unexpected ops %q", ops)
+	}
+
+	if textdiff.Changed(textdiff.Lines(a, a)) {
+		t.Error("identical texts reported as changed")
+	}
+}
+
+func TestUnifiedContext(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n"
+	b := "1\n2\n3\n4\n5\n6\n7\nocho\n"
+
+	got := textdiff.Unified(textdiff.Lines(a, b), 1)
+	want := "@@ 6 unchanged line(s) @@\n  7\n- 8\n+ ocho\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLinesEmpty(t *testing.T) {
+	lines := textdiff.Lines("", "a\nb")
+	if len(lines) != 2 || lines[0].Op != textdiff.Insert || lines[1].Op != textdiff.Insert {
+		t.Errorf("unexpected diff %+v", lines)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,248 @@
+// pkg/timeparse/timeparse.go
+package timeparse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrUnrecognized = errors.New("unrecognized time, use e.g. 07:30, 7:30am, +8h, tomorrow 07:30, mon 06:00 or 2026-12-24 06:00")
+
+// Kind indica cómo se expresó el momento
+type Kind int
+
+const (
+	// Clock es solo una hora del día ("07:30", "7:30am"): la próxima ocurrencia
+	Clock Kind = iota
+	// Relative es un desplazamiento desde la base ("+8h", "in 2d")
+	Relative
+	// Absolute fija el día ("tomorrow 07:30", "mon 06:00", "2026-12-24 06:00")
+	Absolute
+)
+
+// Result es un momento interpretado
+type Result struct {
+	Time time.Time
+	Kind Kind
+}
+
+// Clock retorna la hora del día como HH:MM
+func (r Result) Clock() string {
+	return r.Time.Format("15:04")
+}
+
+// Describe explica la interpretación: "Mon 2026-10-19 07:30 (in 12h5m)"
+func (r Result) Describe(now time.Time) string {
+	when := r.Time.Format("Mon 2006-01-02 15:04")
+	d := r.Time.Sub(now).Round(time.Minute)
+	switch {
+	case d > 0:
+		return fmt.Sprintf("%s (in %s)", when, formatDuration(d))
+	case d < 0:
+		return fmt.Sprintf("%s (%s ago)", when, formatDuration(-d))
+	}
+	return when + " (now)"
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// Parse interpreta value respecto de base (normalmente ahora), en la zona de base:
+//
+//	07:30, 7:30, 7:30am, 7 pm, noon, midnight  próxima ocurrencia después de base
+//	+8h, +1h30m, in 2d                          base más la duración
+//	today 07:30, tomorrow 7am                   ese día
+//	mon 06:00, friday 6pm                       el próximo día de la semana (hoy si aún no pasó)
+//	2026-12-24 06:00, 2026-12-24T06:00:00+01:00 fecha y hora exactas
+//
+// Las mayúsculas y los espacios extra no importan.
+func Parse(value string, base time.Time) (Result, error) {
+	text := strings.ToLower(strings.Join(strings.Fields(value), " "))
+	text = strings.NewReplacer(" am", "am", " pm", "pm").Replace(text)
+	if text == "" {
+		return Result{}, ErrUnrecognized
+	}
+
+	if d, err := ParseDuration(text); err == nil && isRelative(text) {
+		return Result{Time: base.Add(d), Kind: Relative}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(value)); err == nil {
+		return Result{Time: t.In(base.Location()), Kind: Absolute}, nil
+	}
+
+	if hour, minute, ok := parseClock(text); ok {
+		t := at(base, hour, minute)
+		if !t.After(base) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return Result{Time: t, Kind: Clock}, nil
+	}
+
+	// Un día y una hora, en cualquier orden ("tomorrow 07:30", "07:30 tomorrow")
+	day, clock := text, ""
+	if i := strings.LastIndex(text, " "); i > 0 {
+		day, clock = text[:i], text[i+1:]
+		if _, _, ok := parseClock(clock); !ok {
+			if i = strings.Index(text, " "); i > 0 {
+				clock, day = text[:i], text[i+1:]
+			}
+		}
+	}
+	hour, minute, ok := parseClock(clock)
+	if !ok {
+		return Result{}, fmt.Errorf("%w: %q", ErrUnrecognized, value)
+	}
+
+	switch day {
+	case "today":
+		return Result{Time: at(base, hour, minute), Kind: Absolute}, nil
+	case "tomorrow":
+		return Result{Time: at(base.AddDate(0, 0, 1), hour, minute), Kind: Absolute}, nil
+	}
+	if weekday, ok := weekdays[day]; ok {
+		t := at(base, hour, minute)
+		days := (int(weekday) - int(base.Weekday()) + 7) % 7
+		if days == 0 && !t.After(base) {
+			days = 7
+		}
+		return Result{Time: t.AddDate(0, 0, days), Kind: Absolute}, nil
+	}
+	if date, err := time.ParseInLocation("2006-01-02", day, base.Location()); err == nil {
+		return Result{Time: at(date, hour, minute), Kind: Absolute}, nil
+	}
+	return Result{}, fmt.Errorf("%w: %q", ErrUnrecognized, value)
+}
+
+// ParseDuration acepta las duraciones de time.ParseDuration más días ("2d",
+// "1d12h"), con un "+" o "in " opcional delante. Debe ser positiva.
+func ParseDuration(value string) (time.Duration, error) {
+	text := strings.ToLower(strings.TrimSpace(value))
+	text = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(text, "+"), "in "))
+	text = strings.ReplaceAll(text, " ", "")
+
+	var days time.Duration
+	if i := strings.Index(text, "d"); i >= 0 {
+		n, err := strconv.Atoi(text[:i])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		text = text[i+1:]
+	}
+
+	var rest time.Duration
+	if text != "" {
+		d, err := time.ParseDuration(text)
+		if err != nil || strings.HasPrefix(text, "-") {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		rest = d
+	}
+
+	if days+rest <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return days + rest, nil
+}
+
+// isRelative indica si el texto es explícitamente un desplazamiento. Sin "+"
+// ni "in", "8h" también lo es; "7" no (podría ser una hora).
+func isRelative(text string) bool {
+	if strings.HasPrefix(text, "+") || strings.HasPrefix(text, "in ") {
+		return true
+	}
+	last := text[len(text)-1]
+	return last < '0' || last > '9'
+}
+
+// parseClock interpreta una hora del día en formato 24 h (H:MM, HH:MM) o
+// 12 h (7am, 7:30pm), o las palabras noon y midnight
+func parseClock(text string) (int, int, bool) {
+	switch text {
+	case "noon":
+		return 12, 0, true
+	case "midnight":
+		return 0, 0, true
+	}
+
+	suffix := ""
+	if strings.HasSuffix(text, "am") || strings.HasSuffix(text, "pm") {
+		suffix = text[len(text)-2:]
+		text = text[:len(text)-2]
+	}
+
+	hourText, minuteText := text, "00"
+	if i := strings.Index(text, ":"); i >= 0 {
+		hourText, minuteText = text[:i], text[i+1:]
+		if len(minuteText) != 2 {
+			return 0, 0, false
+		}
+	} else if suffix == "" {
+		// Un número solo es ambiguo (¿hora o duración?)
+		return 0, 0, false
+	}
+	if len(hourText) == 0 || len(hourText) > 2 {
+		return 0, 0, false
+	}
+
+	hour, err := strconv.Atoi(hourText)
+	if err != nil {
+		return 0, 0, false
+	}
+	minute, err := strconv.Atoi(minuteText)
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, false
+	}
+
+	switch suffix {
+	case "":
+		if hour < 0 || hour > 23 {
+			return 0, 0, false
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		hour %= 12
+		if suffix == "pm" {
+			hour += 12
+		}
+	}
+	return hour, minute, true
+}
+
+// at retorna el día de t a la hora indicada
+func at(t time.Time, hour, minute int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
+}
+
+// formatDuration muestra una duración redondeada al minuto: "2d3h", "45m"
+func formatDuration(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	d -= time.Duration(days) * 24 * time.Hour
+	hours := int(d / time.Hour)
+	minutes := int((d - time.Duration(hours)*time.Hour) / time.Minute)
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	return strings.Join(parts, "")
+}
\ No newline at end of file
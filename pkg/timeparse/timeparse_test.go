@@ -0,0 +1,120 @@
+// pkg/timeparse/timeparse_test.go
+package timeparse_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"rtc-scheduler/pkg/timeparse"
+)
+
+// base es un domingo por la tarde
+var base = time.Date(2026, 10, 18, 19, 20, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		kind  timeparse.Kind
+	}{
+		{"07:30", "2026-10-19 07:30", timeparse.Clock},
+		{"7:30", "2026-10-19 07:30", timeparse.Clock},
+		{"22:00", "2026-10-18 22:00", timeparse.Clock},
+		{"19:20", "2026-10-19 19:20", timeparse.Clock},
+		{"7:30am", "2026-10-19 07:30", timeparse.Clock},
+		{"7:30 PM", "2026-10-18 19:30", timeparse.Clock},
+		{"12am", "2026-10-19 00:00", timeparse.Clock},
+		{"12pm", "2026-10-19 12:00", timeparse.Clock},
+		{"noon", "2026-10-19 12:00", timeparse.Clock},
+		{"midnight", "2026-10-19 00:00", timeparse.Clock},
+		{"+8h", "2026-10-19 03:20", timeparse.Relative},
+		{"+1h30m", "2026-10-18 20:50", timeparse.Relative},
+		{"in 2d", "2026-10-20 19:20", timeparse.Relative},
+		{"45m", "2026-10-18 20:05", timeparse.Relative},
+		{"tomorrow 07:30", "2026-10-19 07:30", timeparse.Absolute},
+		{"Tomorrow  7:30 am", "2026-10-19 07:30", timeparse.Absolute},
+		{"07:30 tomorrow", "2026-10-19 07:30", timeparse.Absolute},
+		{"today 23:00", "2026-10-18 23:00", timeparse.Absolute},
+		{"today 06:00", "2026-10-18 06:00", timeparse.Absolute},
+		{"mon 06:00", "2026-10-19 06:00", timeparse.Absolute},
+		{"friday 6pm", "2026-10-23 18:00", timeparse.Absolute},
+		{"sun 21:00", "2026-10-18 21:00", timeparse.Absolute},
+		{"sun 08:00", "2026-10-25 08:00", timeparse.Absolute},
+		{"2026-12-24 06:00", "2026-12-24 06:00", timeparse.Absolute},
+		{"2026-12-24 6am", "2026-12-24 06:00", timeparse.Absolute},
+		{"2026-12-24T06:00:00Z", "2026-12-24 06:00", timeparse.Absolute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := timeparse.Parse(tt.input, base)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			if got.Time.Format("2006-01-02 15:04") != tt.want || got.Kind != tt.kind {
+				t.Errorf("Parse(%q) = %s kind %d, want %s kind %d",
+					tt.input, got.Time.Format("2006-01-02 15:04"), got.Kind, tt.want, tt.kind)
+			}
+		})
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, input := range []string{"", "7", "24:00", "7:5", "13pm", "0am", "tomorrow", "someday 07:00", "-1h", "+0m", "2026-13-01 06:00", "07:30 08:00"} {
+		if got, err := timeparse.Parse(input, base); !errors.Is(err, timeparse.ErrUnrecognized) {
+			t.Errorf("Parse(%q) = %v, %v; want ErrUnrecognized", input, got.Time, err)
+		}
+	}
+}
+
+func TestParseKeepsZone(t *testing.T) {
+	madrid := time.FixedZone("CET", 3600)
+	got, err := timeparse.Parse("2026-12-24T06:00:00Z", base.In(madrid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Time.Location() != madrid || got.Clock() != "07:00" {
+		t.Errorf("got %v, want 07:00 CET", got.Time)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30m":     30 * time.Minute,
+		"+1h":     time.Hour,
+		"in 90m":  90 * time.Minute,
+		"2d":      48 * time.Hour,
+		"1d12h":   36 * time.Hour,
+		"1D 30M":  24*time.Hour + 30*time.Minute,
+		"1h30m0s": 90 * time.Minute,
+	}
+	for input, want := range tests {
+		if got, err := timeparse.ParseDuration(input); err != nil || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"", "0s", "-5m", "d", "xd", "tomorrow", "07:30"} {
+		if got, err := timeparse.ParseDuration(input); err == nil {
+			t.Errorf("ParseDuration(%q) = %v, want error", input, got)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{base.Add(12*time.Hour + 5*time.Minute), "Mon 2026-10-19 07:25 (in 12h5m)"},
+		{base.Add(50 * time.Hour), "Tue 2026-10-20 21:20 (in 2d2h)"},
+		{base.Add(-30 * time.Minute), "Sun 2026-10-18 18:50 (30m ago)"},
+		{base, "Sun 2026-10-18 19:20 (now)"},
+	}
+	for _, tt := range tests {
+		if got := (timeparse.Result{Time: tt.at}).Describe(base); got != tt.want {
+			t.Errorf("Describe = %q, want %q", got, tt.want)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,347 @@
+// pkg/yaml/yaml.go
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SyntaxError indica la línea (desde 1) donde el documento no es válido
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("yaml: line %d: %s", e.Line, e.Msg)
+}
+
+// Parse lee un documento YAML con un subconjunto del formato: mapas y listas por
+// indentación, listas en línea ([a, b]), escalares simples o entre comillas y
+// comentarios. Los escalares se devuelven como string; los mapas como
+// map[string]interface{} y las listas como []interface{}.
+// No soporta anclas, etiquetas, bloques literales (| >) ni varios documentos.
+func Parse(data []byte) (map[string]interface{}, error) {
+	lines, err := splitLines(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{lines: lines}
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	if lines[0].indent != 0 {
+		return nil, p.errorf(lines[0], "document must start at column 1")
+	}
+	if lines[0].isItem() {
+		return nil, p.errorf(lines[0], "top level must be a mapping")
+	}
+
+	doc, err := p.mapping(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(lines) {
+		return nil, p.errorf(lines[p.pos], "unexpected indentation")
+	}
+	return doc, nil
+}
+
+// line es una línea con contenido, sin comentario ni indentación
+type line struct {
+	num    int
+	indent int
+	text   string
+}
+
+// isItem indica si la línea es un elemento de lista ("- valor" o "-")
+func (l line) isItem() bool {
+	return l.text == "-" || strings.HasPrefix(l.text, "- ")
+}
+
+type parser struct {
+	lines []line
+	pos   int
+}
+
+func (p *parser) errorf(l line, format string, args ...interface{}) error {
+	return &SyntaxError{Line: l.num, Msg: fmt.Sprintf(format, args...)}
+}
+
+// splitLines descarta líneas vacías, comentarios y el marcador "---" inicial
+func splitLines(data string) ([]line, error) {
+	var lines []line
+	for i, raw := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		num := i + 1
+		trimmed := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, &SyntaxError{Line: num, Msg: "tabs are not allowed for indentation"}
+		}
+
+		text, err := stripComment(trimmed)
+		if err != nil {
+			return nil, &SyntaxError{Line: num, Msg: err.Error()}
+		}
+		if text == "" {
+			continue
+		}
+		if text == "---" && len(lines) == 0 {
+			continue
+		}
+		if text == "---" || text == "..." {
+			return nil, &SyntaxError{Line: num, Msg: "multiple documents are not supported"}
+		}
+
+		lines = append(lines, line{num: num, indent: len(raw) - len(trimmed), text: text})
+	}
+	return lines, nil
+}
+
+// stripComment quita un comentario (# al inicio o precedido de espacio) fuera de comillas
+func stripComment(s string) (string, error) {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.ContainsRune(" \t:-[,", rune(s[i-1])) {
+				quote = c
+			}
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return strings.TrimRight(s[:i], " \t"), nil
+		}
+	}
+	if quote != 0 {
+		return "", fmt.Errorf("unterminated quoted string")
+	}
+	return strings.TrimRight(s, " \t"), nil
+}
+
+// node lee el bloque que empieza en la línea actual con la indentación dada
+func (p *parser) node(indent int) (interface{}, error) {
+	if p.lines[p.pos].isItem() {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent)
+}
+
+// mapping lee pares "clave: valor" con la misma indentación
+func (p *parser) mapping(indent int) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, p.errorf(l, "unexpected indentation")
+		}
+		if l.isItem() {
+			return nil, p.errorf(l, "list item where a key was expected")
+		}
+
+		key, rest, ok := splitKey(l.text)
+		if !ok {
+			return nil, p.errorf(l, "expected \"key: value\", got %q", l.text)
+		}
+		if _, exists := result[key]; exists {
+			return nil, p.errorf(l, "duplicate key %q", key)
+		}
+		p.pos++
+
+		value, err := p.value(l, indent, rest)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// sequence lee elementos "- valor" con la misma indentación
+func (p *parser) sequence(indent int) ([]interface{}, error) {
+	result := []interface{}{}
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent || (l.indent == indent && !l.isItem()) {
+			break
+		}
+		if l.indent > indent {
+			return nil, p.errorf(l, "unexpected indentation")
+		}
+
+		rest := strings.TrimLeft(strings.TrimPrefix(l.text, "-"), " ")
+		if _, _, isMap := splitKey(rest); isMap && !isQuoted(rest) {
+			// "- clave: valor" abre un mapa indentado en la columna de la clave
+			p.lines[p.pos].indent = indent + len(l.text) - len(rest)
+			p.lines[p.pos].text = rest
+			item, err := p.mapping(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, item)
+			continue
+		}
+		p.pos++
+
+		item, err := p.value(l, indent, rest)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// value interpreta lo que sigue a "clave:" o "-": un escalar en la misma línea
+// o un bloque en las líneas siguientes
+func (p *parser) value(l line, indent int, rest string) (interface{}, error) {
+	if rest != "" {
+		return scalar(rest, func(msg string) error { return p.errorf(l, "%s", msg) })
+	}
+	if p.pos >= len(p.lines) {
+		return "", nil
+	}
+
+	next := p.lines[p.pos]
+	switch {
+	case next.indent > indent:
+		return p.node(next.indent)
+	case next.indent == indent && next.isItem() && !l.isItem():
+		// Lista sin indentar bajo una clave ("key:\n- a")
+		return p.sequence(indent)
+	default:
+		return "", nil
+	}
+}
+
+// splitKey separa "clave: valor"; el separador es ":" seguido de espacio o fin de línea
+func splitKey(text string) (key, rest string, ok bool) {
+	start := 0
+	if isQuoted(text) {
+		end := strings.IndexByte(text[1:], text[0])
+		if end < 0 {
+			return "", "", false
+		}
+		start = end + 2
+	}
+
+	for i := start; i < len(text); i++ {
+		if text[i] != ':' || (i+1 < len(text) && text[i+1] != ' ') {
+			continue
+		}
+		key = strings.TrimSpace(text[:i])
+		if isQuoted(key) {
+			unquoted, err := unquote(key)
+			if err != nil {
+				return "", "", false
+			}
+			key = unquoted
+		}
+		return key, strings.TrimSpace(text[i+1:]), key != ""
+	}
+	return "", "", false
+}
+
+// scalar convierte el texto de un valor en string o, si es [a, b], en lista
+func scalar(text string, fail func(string) error) (interface{}, error) {
+	switch {
+	case isQuoted(text):
+		value, err := unquote(text)
+		if err != nil {
+			return nil, fail(err.Error())
+		}
+		return value, nil
+	case strings.HasPrefix(text, "["):
+		return flowSequence(text, fail)
+	case strings.HasPrefix(text, "{"):
+		return nil, fail("flow mappings are not supported")
+	case text == "|" || text == ">" || strings.HasPrefix(text, "|") || strings.HasPrefix(text, ">"):
+		return nil, fail("block scalars are not supported")
+	case strings.HasPrefix(text, "&") || strings.HasPrefix(text, "*") || strings.HasPrefix(text, "!"):
+		return nil, fail("anchors, aliases and tags are not supported")
+	default:
+		return text, nil
+	}
+}
+
+// flowSequence lee una lista en línea de escalares: [a, "b", 'c']
+func flowSequence(text string, fail func(string) error) (interface{}, error) {
+	if !strings.HasSuffix(text, "]") {
+		return nil, fail("unterminated flow sequence")
+	}
+	inner := strings.TrimSpace(text[1 : len(text)-1])
+	result := []interface{}{}
+	if inner == "" {
+		return result, nil
+	}
+
+	var quote byte
+	start := 0
+	for i := 0; i <= len(inner); i++ {
+		if i < len(inner) {
+			c := inner[i]
+			switch {
+			case quote == '"' && c == '\\':
+				i++
+				continue
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+				continue
+			case c == '"' || c == '\'':
+				quote = c
+				continue
+			case c == '[' || c == '{':
+				return nil, fail("nested flow collections are not supported")
+			case c != ',':
+				continue
+			}
+		}
+
+		item := strings.TrimSpace(inner[start:i])
+		if item == "" {
+			return nil, fail("empty item in flow sequence")
+		}
+		value, err := scalar(item, fail)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+		start = i + 1
+	}
+	return result, nil
+}
+
+func isQuoted(text string) bool {
+	return len(text) > 0 && (text[0] == '"' || text[0] == '\'')
+}
+
+// unquote interpreta "..." con escapes de Go y '...' con la comilla simple duplicada
+func unquote(text string) (string, error) {
+	if len(text) < 2 || text[len(text)-1] != text[0] {
+		return "", fmt.Errorf("invalid quoted string %s", text)
+	}
+	if text[0] == '\'' {
+		inner := text[1 : len(text)-1]
+		if strings.Contains(strings.ReplaceAll(inner, "''", ""), "'") {
+			return "", fmt.Errorf("invalid quoted string %s", text)
+		}
+		return strings.ReplaceAll(inner, "''", "'"), nil
+	}
+
+	value, err := strconv.Unquote(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid quoted string %s", text)
+	}
+	return value, nil
+}
\ No newline at end of file
@@ -0,0 +1,95 @@
+// pkg/yaml/yaml_test.go
+package yaml_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"rtc-scheduler/pkg/yaml"
+)
+
+func TestParse(t *testing.T) {
+	doc := `# rtc-scheduler
+---
+rtc:
+  device: "/dev/rtc0"   # comentario
+  timezone: Europe/Madrid
+
+scheduler:
+  type: 'at'
+  log_file: ""
+  note: it's a#b # comment
+peers:
+  - nas
+  - "backup: offsite"
+  - name: printer
+    delay: 30s
+hooks:
+- https://example.com/hook#frag
+events: [armed, "error", 'shutdown']
+empty:
+`
+	got, err := yaml.Parse([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"rtc": map[string]interface{}{"device": "/dev/rtc0", "timezone": "Europe/Madrid"},
+		"scheduler": map[string]interface{}{
+			"type":     "at",
+			"log_file": "",
+			"note":     "it's a#b",
+		},
+		"peers": []interface{}{
+			"nas",
+			"backup: offsite",
+			map[string]interface{}{"name": "printer", "delay": "30s"},
+		},
+		"hooks":  []interface{}{"https://example.com/hook#frag"},
+		"events": []interface{}{"armed", "error", "shutdown"},
+		"empty":  "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	got, err := yaml.Parse([]byte("# nothing here\n\n"))
+	if err != nil || len(got) != 0 {
+		t.Errorf("Parse(empty) = %v, %v", got, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]struct {
+		doc  string
+		line int
+	}{
+		"tab indent":        {"rtc:\n\tdevice: x\n", 2},
+		"bad indent":        {"rtc:\n  device: x\n    timezone: y\n", 3},
+		"duplicate key":     {"a: 1\nb: 2\na: 3\n", 3},
+		"no key":            {"rtc:\n  just text\n", 2},
+		"top-level list":    {"- a\n- b\n", 1},
+		"unterminated":      {"a: \"open\n", 1},
+		"block scalar":      {"a: |\n  text\n", 1},
+		"flow mapping":      {"a: {b: c}\n", 1},
+		"nested flow":       {"a: [b, [c]]\n", 1},
+		"second document":   {"a: 1\n---\nb: 2\n", 2},
+		"item in a mapping": {"a:\n  b: 1\n  - c\n", 3},
+	}
+
+	for name, tc := range cases {
+		_, err := yaml.Parse([]byte(tc.doc))
+		var syntax *yaml.SyntaxError
+		if !errors.As(err, &syntax) {
+			t.Errorf("%s: expected SyntaxError, got %v", name, err)
+			continue
+		}
+		if syntax.Line != tc.line {
+			t.Errorf("%s: error on line %d, want %d (%v)", name, syntax.Line, tc.line, err)
+		}
+	}
+}
\ No newline at end of file